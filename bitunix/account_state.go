@@ -0,0 +1,672 @@
+package bitunix
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/model"
+	"go.uber.org/zap"
+)
+
+type AccountBalance struct {
+	Coin      model.MarginCoin
+	Available float64
+	Frozen    float64
+	Margin    float64
+	UpdatedAt time.Time
+}
+
+type AccountOrder struct {
+	OrderID      string
+	ClientID     string
+	Symbol       model.Symbol
+	Side         model.TradeSide
+	Type         model.OrderType
+	MarginMode   model.MarginMode
+	PositionMode model.PositionMode
+	Leverage     int
+	Quantity     float64
+	Price        float64
+	Fee          float64
+	ReduceOnly   bool
+	Status       model.OrderStatus
+	CreateTime   time.Time
+	UpdatedAt    time.Time
+}
+
+type AccountPosition struct {
+	PositionID    string
+	Symbol        model.Symbol
+	Side          model.PositionSide
+	MarginMode    model.MarginMode
+	PositionMode  model.PositionMode
+	Leverage      int
+	Quantity      float64
+	EntryValue    float64
+	Margin        float64
+	RealizedPNL   float64
+	UnrealizedPNL float64
+	Funding       float64
+	Fee           float64
+	CreateTime    time.Time
+	UpdatedAt     time.Time
+}
+
+type AccountTpSlOrder struct {
+	OrderID      string
+	PositionID   string
+	Symbol       model.Symbol
+	TPPrice      float64
+	TPStopType   model.StopType
+	TPOrderType  model.OrderType
+	TPOrderPrice float64
+	TPQuantity   float64
+	SLPrice      float64
+	SLStopType   model.StopType
+	SLOrderType  model.OrderType
+	SLOrderPrice float64
+	SLQuantity   float64
+	UpdatedAt    time.Time
+}
+
+// AccountState mirrors balance, open orders, open positions and pending tp/sl
+// orders of an account. It is seeded from the REST endpoints and kept current
+// by the private websocket channels. Updates are applied per entity in order of
+// the message timestamp, older updates delivered late by the worker pool are dropped.
+type AccountState struct {
+	client           ApiClient
+	marginCoin       model.MarginCoin
+	pageSize         int64
+	seedTimeout      time.Duration
+	seedBackoff      time.Duration
+	seedBackoffLimit time.Duration
+	timeSync         *TimeSync
+	logger           *zap.Logger
+
+	balance    AccountBalance
+	hasBalance bool
+	orders     map[string]AccountOrder
+	positions  map[string]AccountPosition
+	tpSlOrders map[string]AccountTpSlOrder
+	// Timestamp of the last update applied per entity, kept for closed entities
+	// so that late updates cannot bring them back
+	versions map[string]int64
+
+	synced     bool
+	seeding    bool
+	generation int
+	queued     []func()
+	retryDelay time.Duration
+	retryAt    time.Time
+	cancelSeed context.CancelFunc
+	closed     bool
+	mu         sync.RWMutex
+}
+
+type AccountStateOption func(*AccountState)
+
+func WithAccountStateMarginCoin(coin model.MarginCoin) AccountStateOption {
+	return func(s *AccountState) {
+		s.marginCoin = coin
+	}
+}
+
+func WithAccountStatePageSize(size int64) AccountStateOption {
+	return func(s *AccountState) {
+		s.pageSize = size
+	}
+}
+
+// WithAccountStateTimeSync versions the REST snapshots with the exchange clock measured by timeSync
+func WithAccountStateTimeSync(timeSync *TimeSync) AccountStateOption {
+	return func(s *AccountState) {
+		s.timeSync = timeSync
+	}
+}
+
+func WithAccountStateLogger(logger *zap.Logger) AccountStateOption {
+	return func(s *AccountState) {
+		s.logger = logger
+	}
+}
+
+func NewAccountState(client ApiClient, options ...AccountStateOption) *AccountState {
+	s := &AccountState{
+		client:           client,
+		marginCoin:       "USDT",
+		pageSize:         100,
+		seedTimeout:      30 * time.Second,
+		seedBackoff:      time.Second,
+		seedBackoffLimit: 30 * time.Second,
+		logger:           zap.NewNop(),
+		orders:           make(map[string]AccountOrder),
+		positions:        make(map[string]AccountPosition),
+		tpSlOrders:       make(map[string]AccountTpSlOrder),
+		versions:         make(map[string]int64),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.retryDelay = s.seedBackoff
+
+	return s
+}
+
+// Subscribe registers the state on all private channels of the websocket client
+func (s *AccountState) Subscribe(ws PrivateWebsocketClient) error {
+	if err := ws.SubscribeBalance(s); err != nil {
+		return err
+	}
+	if err := ws.SubscribePositions(s); err != nil {
+		return err
+	}
+	if err := ws.SubscribeOrders(s); err != nil {
+		return err
+	}
+	return ws.SubscribeTpSlOrders(s)
+}
+
+// exchangeTime is the current time in exchange milliseconds, the clock of the websocket timestamps
+func (s *AccountState) exchangeTime() int64 {
+	if s.timeSync != nil {
+		return s.timeSync.UnixMilli()
+	}
+	return time.Now().UnixMilli()
+}
+
+// Seed replaces the state with the REST snapshots. Websocket updates received
+// while seeding are applied on top of the snapshot afterwards.
+func (s *AccountState) Seed(ctx context.Context) error {
+	s.mu.Lock()
+	s.seeding = true
+	generation := s.generation
+	s.mu.Unlock()
+
+	seededAt := s.exchangeTime()
+	snapshot, err := s.fetchSnapshot(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seeding = false
+	if err != nil {
+		// The queued updates predate the next snapshot, which covers them
+		s.queued = nil
+		s.retryAt = time.Now().Add(s.retryDelay)
+		s.retryDelay = min(2*s.retryDelay, s.seedBackoffLimit)
+		return err
+	}
+	s.retryDelay = s.seedBackoff
+	s.retryAt = time.Time{}
+
+	if generation != s.generation {
+		// Invalidated while fetching, the snapshot may predate the reconnect
+		s.startSeed()
+		return nil
+	}
+
+	s.balance, s.hasBalance = snapshot.balance, snapshot.hasBalance
+	s.orders = snapshot.orders
+	s.positions = snapshot.positions
+	s.tpSlOrders = snapshot.tpSlOrders
+	clear(s.versions)
+	for key := range s.orders {
+		s.versions["order:"+key] = seededAt
+	}
+	for key := range s.positions {
+		s.versions["position:"+key] = seededAt
+	}
+	for key := range s.tpSlOrders {
+		s.versions["tpsl:"+key] = seededAt
+	}
+	if s.hasBalance {
+		s.versions["balance"] = seededAt
+	}
+
+	for _, apply := range s.queued {
+		apply()
+	}
+	s.queued = nil
+	s.synced = true
+
+	return nil
+}
+
+type accountSnapshot struct {
+	balance    AccountBalance
+	hasBalance bool
+	orders     map[string]AccountOrder
+	positions  map[string]AccountPosition
+	tpSlOrders map[string]AccountTpSlOrder
+}
+
+func (s *AccountState) fetchSnapshot(ctx context.Context) (*accountSnapshot, error) {
+	snapshot := &accountSnapshot{tpSlOrders: make(map[string]AccountTpSlOrder)}
+
+	balance, err := s.client.GetAccountBalance(ctx, model.AccountBalanceParams{MarginCoin: s.marginCoin})
+	if err != nil {
+		return nil, err
+	}
+	if balance.Data != nil {
+		snapshot.hasBalance = true
+		snapshot.balance = AccountBalance{
+			Coin:      s.marginCoin,
+			Available: balance.Data.Available,
+			Frozen:    balance.Data.Frozen,
+			Margin:    balance.Data.Margin,
+		}
+	}
+
+	if snapshot.positions, err = s.fetchPositions(ctx); err != nil {
+		return nil, err
+	}
+
+	if snapshot.orders, err = s.fetchOrders(ctx); err != nil {
+		return nil, err
+	}
+
+	for skip := int64(0); ; {
+		tpSlOrders, err := s.client.GetPendingTPSLOrder(ctx, model.PendingTPSLOrderParams{Skip: skip, Limit: s.pageSize})
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range tpSlOrders.Data {
+			snapshot.tpSlOrders[order.ID] = accountTpSlOrderFromPending(order)
+		}
+
+		skip += int64(len(tpSlOrders.Data))
+		if int64(len(tpSlOrders.Data)) < s.pageSize {
+			break
+		}
+	}
+
+	return snapshot, nil
+}
+
+func (s *AccountState) fetchPositions(ctx context.Context) (map[string]AccountPosition, error) {
+	response, err := s.client.GetPendingPositions(ctx, model.PendingPositionParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[string]AccountPosition, len(response.Data))
+	for _, position := range response.Data {
+		positions[position.PositionID] = accountPositionFromPending(position)
+	}
+
+	return positions, nil
+}
+
+func (s *AccountState) fetchOrders(ctx context.Context) (map[string]AccountOrder, error) {
+	orders := make(map[string]AccountOrder)
+	for skip := int64(0); ; {
+		response, err := s.client.GetPendingOrder(ctx, model.PendingOrderParams{Skip: skip, Limit: s.pageSize})
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range response.Data.OrderList {
+			orders[order.OrderID] = accountOrderFromPending(order)
+		}
+
+		skip += int64(len(response.Data.OrderList))
+		if len(response.Data.OrderList) == 0 || skip >= response.Data.Total {
+			return orders, nil
+		}
+	}
+}
+
+// Invalidate marks the state as stale and seeds it again from REST. The
+// reconnecting private websocket calls it before resubscribing.
+func (s *AccountState) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.synced = false
+	s.generation++
+	s.queued = nil
+	s.startSeed()
+}
+
+// Close cancels a running background seed, the state is not seeded again afterwards
+func (s *AccountState) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	if s.cancelSeed != nil {
+		s.cancelSeed()
+	}
+}
+
+// startSeed seeds in the background, after a failed seed not before the backoff has passed
+func (s *AccountState) startSeed() {
+	if s.seeding || s.closed {
+		return
+	}
+
+	if time.Now().Before(s.retryAt) {
+		return
+	}
+	s.seeding = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.seedTimeout)
+	s.cancelSeed = cancel
+
+	go func() {
+		defer cancel()
+
+		err := s.Seed(ctx)
+
+		s.mu.Lock()
+		closed := s.closed
+		wait := time.Until(s.retryAt)
+		s.mu.Unlock()
+
+		if err == nil || closed {
+			return
+		}
+		s.logger.Warn("failed to seed account state", zap.Error(err))
+
+		time.AfterFunc(wait, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			if !s.synced {
+				s.startSeed()
+			}
+		})
+	}()
+}
+
+func (s *AccountState) Synced() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.synced
+}
+
+// update applies fn unless a newer update of the same entity was applied already.
+// Until seeded, updates are queued and applied once the snapshot is in place.
+func (s *AccountState) update(key string, ts int64, fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	apply := func() {
+		if ts < s.versions[key] {
+			return
+		}
+		s.versions[key] = ts
+		fn()
+	}
+
+	if !s.synced || s.seeding {
+		s.startSeed()
+		// Updates are only queued while a seed runs, older ones are covered by the next snapshot
+		if s.seeding {
+			s.queued = append(s.queued, apply)
+		}
+		return
+	}
+	apply()
+}
+
+func (s *AccountState) SubscribeBalance(msg *model.BalanceChannelMessage) {
+	event := msg.Data
+	if !strings.EqualFold(event.Coin, s.marginCoin.String()) {
+		return
+	}
+
+	s.update("balance", msg.Ts, func() {
+		s.hasBalance = true
+		s.balance = AccountBalance{
+			Coin:      model.MarginCoin(event.Coin),
+			Available: event.Available,
+			Frozen:    event.Frozen,
+			Margin:    event.Margin,
+			UpdatedAt: time.UnixMilli(msg.Ts),
+		}
+	})
+}
+
+func (s *AccountState) SubscribePosition(msg *model.PositionChannelMessage) {
+	event := msg.Data
+	s.update("position:"+event.PositionID, msg.TimeStamp, func() {
+		if event.Event == model.PositionEventClose {
+			delete(s.positions, event.PositionID)
+			return
+		}
+
+		s.positions[event.PositionID] = AccountPosition{
+			PositionID:    event.PositionID,
+			Symbol:        event.Symbol,
+			Side:          event.Side,
+			MarginMode:    event.MarginMode,
+			PositionMode:  event.PositionMode,
+			Leverage:      event.Leverage,
+			Quantity:      event.Quantity,
+			EntryValue:    event.EntryValue,
+			Margin:        event.Margin,
+			RealizedPNL:   event.RealizedPNL,
+			UnrealizedPNL: event.UnrealizedPNL,
+			Funding:       event.Funding,
+			Fee:           event.Fee,
+			CreateTime:    event.CreateTime,
+			UpdatedAt:     time.UnixMilli(msg.TimeStamp),
+		}
+	})
+}
+
+func (s *AccountState) SubscribeOrder(msg *model.OrderChannelMessage) {
+	event := msg.Data
+	s.update("order:"+event.OrderID, msg.TimeStamp, func() {
+		if event.Event == model.OrderEventClose || event.OrderStatus.IsTerminal() {
+			delete(s.orders, event.OrderID)
+			return
+		}
+
+		// The order channel does not carry the client id
+		clientID := s.orders[event.OrderID].ClientID
+		s.orders[event.OrderID] = AccountOrder{
+			OrderID:      event.OrderID,
+			ClientID:     clientID,
+			Symbol:       event.Symbol,
+			Side:         event.Side,
+			Type:         event.Type,
+			MarginMode:   event.PositionType,
+			PositionMode: event.PositionMode,
+			Leverage:     event.Leverage,
+			Quantity:     event.Quantity,
+			Price:        event.Price,
+			Fee:          event.Fee,
+			ReduceOnly:   event.ReductionOnly,
+			Status:       event.OrderStatus,
+			CreateTime:   event.CreateTime,
+			UpdatedAt:    time.UnixMilli(msg.TimeStamp),
+		}
+	})
+}
+
+func (s *AccountState) SubscribeTpSlOrder(msg *model.TpSlOrderChannelMessage) {
+	event := msg.Data
+	s.update("tpsl:"+event.OrderID, msg.Timestamp, func() {
+		if event.Event == model.TPSLEventClose || event.Status.IsTerminal() {
+			delete(s.tpSlOrders, event.OrderID)
+			return
+		}
+
+		s.tpSlOrders[event.OrderID] = AccountTpSlOrder{
+			OrderID:      event.OrderID,
+			PositionID:   event.PositionID,
+			Symbol:       event.Symbol,
+			TPPrice:      event.TPPrice,
+			TPStopType:   event.TPStopType,
+			TPOrderType:  event.TPOrderType,
+			TPOrderPrice: event.TPOrderPrice,
+			TPQuantity:   event.TPQuantity,
+			SLPrice:      event.SLPrice,
+			SLStopType:   event.SLStopType,
+			SLOrderType:  event.SLOrderType,
+			SLOrderPrice: event.SLOrderPrice,
+			SLQuantity:   event.SLQuantity,
+			UpdatedAt:    time.UnixMilli(msg.Timestamp),
+		}
+	})
+}
+
+func (s *AccountState) Balance() (AccountBalance, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.balance, s.hasBalance
+}
+
+// AvailableMargin returns the balance available to open new positions
+func (s *AccountState) AvailableMargin() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.balance.Available
+}
+
+// OpenOrders returns the open orders of symbol ordered by creation, all open orders when symbol is empty
+func (s *AccountState) OpenOrders(symbol model.Symbol) []AccountOrder {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	symbol = symbol.Normalize()
+	orders := make([]AccountOrder, 0, len(s.orders))
+	for _, order := range s.orders {
+		if symbol == "" || order.Symbol == symbol {
+			orders = append(orders, order)
+		}
+	}
+	slices.SortFunc(orders, func(a, b AccountOrder) int { return a.CreateTime.Compare(b.CreateTime) })
+
+	return orders
+}
+
+func (s *AccountState) Order(orderID string) (AccountOrder, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	order, ok := s.orders[orderID]
+	return order, ok
+}
+
+func (s *AccountState) Position(positionID string) (AccountPosition, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	position, ok := s.positions[positionID]
+	return position, ok
+}
+
+// Positions returns the open positions of symbol, all open positions when symbol is empty
+func (s *AccountState) Positions(symbol model.Symbol) []AccountPosition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	symbol = symbol.Normalize()
+	positions := make([]AccountPosition, 0, len(s.positions))
+	for _, position := range s.positions {
+		if symbol == "" || position.Symbol == symbol {
+			positions = append(positions, position)
+		}
+	}
+	slices.SortFunc(positions, func(a, b AccountPosition) int { return a.CreateTime.Compare(b.CreateTime) })
+
+	return positions
+}
+
+// TpSlOrders returns the pending tp/sl orders attached to a position
+func (s *AccountState) TpSlOrders(positionID string) []AccountTpSlOrder {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var orders []AccountTpSlOrder
+	for _, order := range s.tpSlOrders {
+		if order.PositionID == positionID {
+			orders = append(orders, order)
+		}
+	}
+	slices.SortFunc(orders, func(a, b AccountTpSlOrder) int { return a.UpdatedAt.Compare(b.UpdatedAt) })
+
+	return orders
+}
+
+func accountOrderFromPending(order model.PendingOrder) AccountOrder {
+	return AccountOrder{
+		OrderID:      order.OrderID,
+		ClientID:     order.ClientID,
+		Symbol:       order.Symbol,
+		Side:         order.Side,
+		Type:         order.OrderType,
+		MarginMode:   order.MarginMode,
+		PositionMode: order.PositionMode,
+		Leverage:     order.Leverage,
+		Quantity:     order.Quantity,
+		Price:        order.Price,
+		Fee:          order.Fee,
+		ReduceOnly:   order.ReduceOnly,
+		Status:       order.Status,
+		CreateTime:   order.CreateTime,
+		UpdatedAt:    order.ModifyTime,
+	}
+}
+
+func accountPositionFromPending(position model.PendingPosition) AccountPosition {
+	// Pending positions report the side of the opening trade
+	side := model.PositionSideLong
+	if position.Side == model.TradeSideSell {
+		side = model.PositionSideShort
+	}
+
+	return AccountPosition{
+		PositionID:    position.PositionID,
+		Symbol:        position.Symbol,
+		Side:          side,
+		MarginMode:    position.MarginMode,
+		PositionMode:  position.PositionMode,
+		Leverage:      position.Leverage,
+		Quantity:      position.Qty,
+		EntryValue:    position.EntryValue,
+		Margin:        position.Margin,
+		RealizedPNL:   position.RealizedPNL,
+		UnrealizedPNL: position.UnrealizedPNL,
+		Funding:       position.Funding,
+		Fee:           position.Fees,
+		CreateTime:    position.CreateTime,
+		UpdatedAt:     position.ModifyTime,
+	}
+}
+
+func accountTpSlOrderFromPending(order model.PendingTPSLOrder) AccountTpSlOrder {
+	return AccountTpSlOrder{
+		OrderID:      order.ID,
+		PositionID:   order.PositionID,
+		Symbol:       order.Symbol,
+		TPPrice:      valueOf(order.TpPrice),
+		TPStopType:   valueOf(order.TpStopType),
+		TPOrderType:  valueOf(order.TpOrderType),
+		TPOrderPrice: valueOf(order.TpOrderPrice),
+		TPQuantity:   valueOf(order.TpQty),
+		SLPrice:      valueOf(order.SlPrice),
+		SLStopType:   valueOf(order.SlStopType),
+		SLOrderType:  valueOf(order.SlOrderType),
+		SLOrderPrice: valueOf(order.SlOrderPrice),
+		SLQuantity:   valueOf(order.SlQty),
+	}
+}
+
+func valueOf[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,238 @@
+package bitunix
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+	"go.uber.org/zap"
+)
+
+type accountSnapshotClient struct {
+	ApiClient
+	orders []model.PendingOrder
+	seeds  atomic.Int32
+}
+
+func (c *accountSnapshotClient) GetAccountBalance(ctx context.Context, params model.AccountBalanceParams) (*model.AccountBalanceResponse, error) {
+	c.seeds.Add(1)
+	return &model.AccountBalanceResponse{Data: &model.AccountBalanceEntry{MarginCoin: params.MarginCoin, Available: 1000, Frozen: 50}}, nil
+}
+
+func (c *accountSnapshotClient) GetPendingPositions(ctx context.Context, params model.PendingPositionParams) (*model.PendingPositionResponse, error) {
+	return &model.PendingPositionResponse{Data: []model.PendingPosition{
+		{PositionID: "pos1", Symbol: "BTCUSDT", Side: model.TradeSideSell, Qty: 0.5},
+	}}, nil
+}
+
+func (c *accountSnapshotClient) GetPendingOrder(ctx context.Context, params model.PendingOrderParams) (*model.PendingOrderResponse, error) {
+	response := &model.PendingOrderResponse{}
+	response.Data.Total = int64(len(c.orders))
+	end := min(params.Skip+params.Limit, int64(len(c.orders)))
+	response.Data.OrderList = c.orders[params.Skip:end]
+	return response, nil
+}
+
+func (c *accountSnapshotClient) GetPendingTPSLOrder(ctx context.Context, params model.PendingTPSLOrderParams) (*model.PendingTPSLOrderResponse, error) {
+	tpPrice := 60000.0
+	return &model.PendingTPSLOrderResponse{Data: []model.PendingTPSLOrder{
+		{ID: "tpsl1", PositionID: "pos1", Symbol: "BTCUSDT", TpPrice: &tpPrice},
+	}}, nil
+}
+
+func newAccountSnapshotClient() *accountSnapshotClient {
+	return &accountSnapshotClient{orders: []model.PendingOrder{
+		{OrderID: "order1", ClientID: "client1", Symbol: "BTCUSDT", Status: model.OrderStatusNew, CreateTime: time.UnixMilli(1000)},
+		{OrderID: "order2", Symbol: "ETHUSDT", Status: model.OrderStatusNew, CreateTime: time.UnixMilli(2000)},
+		{OrderID: "order3", Symbol: "BTCUSDT", Status: model.OrderStatusPartFilled, CreateTime: time.UnixMilli(3000)},
+	}}
+}
+
+func orderUpdate(ts int64, orderID string, event model.OrderEventType, status model.OrderStatus, qty float64) *model.OrderChannelMessage {
+	return &model.OrderChannelMessage{
+		Channel:   model.ChannelOrder,
+		TimeStamp: ts,
+		Data:      model.OrderEvent{Event: event, OrderID: orderID, Symbol: "BTCUSDT", OrderStatus: status, Quantity: qty},
+	}
+}
+
+func TestAccountStateSeedsFromREST(t *testing.T) {
+	state := NewAccountState(newAccountSnapshotClient(), WithAccountStatePageSize(2))
+	require.NoError(t, state.Seed(context.Background()))
+	assert.True(t, state.Synced())
+
+	orders := state.OpenOrders("btcusdt")
+	require.Len(t, orders, 2)
+	assert.Equal(t, "order1", orders[0].OrderID)
+	assert.Equal(t, "order3", orders[1].OrderID)
+	assert.Len(t, state.OpenOrders(""), 3, "orders must be fetched across pages")
+
+	position, ok := state.Position("pos1")
+	require.True(t, ok)
+	assert.Equal(t, model.PositionSideShort, position.Side)
+	assert.Equal(t, 0.5, position.Quantity)
+
+	tpSl := state.TpSlOrders("pos1")
+	require.Len(t, tpSl, 1)
+	assert.Equal(t, 60000.0, tpSl[0].TPPrice)
+
+	assert.Equal(t, 1000.0, state.AvailableMargin())
+}
+
+func TestAccountStateAppliesUpdatesInTimestampOrder(t *testing.T) {
+	state := NewAccountState(newAccountSnapshotClient())
+	require.NoError(t, state.Seed(context.Background()))
+
+	now := time.Now().UnixMilli() + 1000
+	state.SubscribeOrder(orderUpdate(now+20, "order1", model.OrderEventUpdate, model.OrderStatusPartFilled, 2))
+	state.SubscribeOrder(orderUpdate(now+10, "order1", model.OrderEventUpdate, model.OrderStatusNew, 1))
+
+	order, ok := state.Order("order1")
+	require.True(t, ok)
+	assert.Equal(t, model.OrderStatusPartFilled, order.Status)
+	assert.Equal(t, "client1", order.ClientID, "the client id from the snapshot must be kept")
+
+	state.SubscribeOrder(orderUpdate(now+40, "order1", model.OrderEventClose, model.OrderStatusFilled, 2))
+	state.SubscribeOrder(orderUpdate(now+30, "order1", model.OrderEventUpdate, model.OrderStatusPartFilled, 2))
+	_, ok = state.Order("order1")
+	assert.False(t, ok, "a late update must not bring back a closed order")
+
+	// Updates older than the snapshot are dropped
+	state.SubscribeOrder(orderUpdate(1, "order2", model.OrderEventClose, model.OrderStatusCanceled, 0))
+	_, ok = state.Order("order2")
+	assert.True(t, ok)
+
+	state.SubscribePosition(&model.PositionChannelMessage{TimeStamp: now, Data: model.PositionEvent{Event: model.PositionEventClose, PositionID: "pos1"}})
+	_, ok = state.Position("pos1")
+	assert.False(t, ok)
+
+	state.SubscribeBalance(&model.BalanceChannelMessage{Ts: now, Data: model.BalanceEvent{Coin: "BTC", Available: 1}})
+	state.SubscribeBalance(&model.BalanceChannelMessage{Ts: now, Data: model.BalanceEvent{Coin: "usdt", Available: 750}})
+	assert.Equal(t, 750.0, state.AvailableMargin())
+}
+
+func TestAccountStateSeedsOnFirstUpdate(t *testing.T) {
+	client := newAccountSnapshotClient()
+	state := NewAccountState(client)
+
+	state.SubscribeOrder(orderUpdate(time.Now().UnixMilli()+1000, "order4", model.OrderEventCreate, model.OrderStatusNew, 1))
+
+	require.Eventually(t, state.Synced, time.Second, time.Millisecond)
+	_, ok := state.Order("order4")
+	assert.True(t, ok, "updates received before the snapshot must be applied on top of it")
+	assert.Len(t, state.OpenOrders(""), 4)
+	assert.Equal(t, int32(1), client.seeds.Load())
+}
+
+func TestAccountStateVersionsSnapshotWithExchangeClock(t *testing.T) {
+	// The local clock runs an hour ahead of the exchange
+	timeSync := NewTimeSync()
+	timeSync.offset = -time.Hour
+	state := NewAccountState(newAccountSnapshotClient(), WithAccountStateTimeSync(timeSync))
+	require.NoError(t, state.Seed(context.Background()))
+
+	exchangeNow := time.Now().Add(-time.Hour).UnixMilli()
+	state.SubscribeOrder(orderUpdate(exchangeNow+1000, "order1", model.OrderEventUpdate, model.OrderStatusPartFilled, 1))
+
+	order, ok := state.Order("order1")
+	require.True(t, ok)
+	assert.Equal(t, model.OrderStatusPartFilled, order.Status)
+}
+
+type failingBalanceClient struct {
+	*accountSnapshotClient
+	failures atomic.Int32
+}
+
+func (c *failingBalanceClient) GetAccountBalance(ctx context.Context, params model.AccountBalanceParams) (*model.AccountBalanceResponse, error) {
+	if c.failures.Add(-1) >= 0 {
+		c.seeds.Add(1)
+		return nil, errors.NewNetworkError("GET", "connection reset", nil)
+	}
+	return c.accountSnapshotClient.GetAccountBalance(ctx, params)
+}
+
+func TestAccountStateBacksOffAfterFailedSeed(t *testing.T) {
+	client := &failingBalanceClient{accountSnapshotClient: newAccountSnapshotClient()}
+	client.failures.Store(1)
+	state := NewAccountState(client)
+	state.retryDelay = 50 * time.Millisecond
+
+	state.SubscribeOrder(orderUpdate(time.Now().UnixMilli()-1000, "order4", model.OrderEventCreate, model.OrderStatusNew, 1))
+	require.Eventually(t, func() bool {
+		state.mu.RLock()
+		defer state.mu.RUnlock()
+		return !state.retryAt.IsZero()
+	}, time.Second, time.Millisecond)
+
+	// Updates during the backoff neither start a seed nor pile up
+	for i := range 100 {
+		state.SubscribeOrder(orderUpdate(time.Now().UnixMilli(), "order5", model.OrderEventUpdate, model.OrderStatusNew, float64(i)))
+	}
+	assert.Equal(t, int32(1), client.seeds.Load())
+	state.mu.RLock()
+	assert.Empty(t, state.queued)
+	state.mu.RUnlock()
+
+	// The seed is retried once the backoff has passed
+	require.Eventually(t, state.Synced, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), client.seeds.Load())
+	_, ok := state.Order("order4")
+	assert.False(t, ok, "updates queued for the failed seed are dropped")
+}
+
+func TestAccountStateCloseStopsReseeding(t *testing.T) {
+	client := &failingBalanceClient{accountSnapshotClient: newAccountSnapshotClient()}
+	client.failures.Store(1)
+	state := NewAccountState(client)
+	state.retryDelay = 10 * time.Millisecond
+
+	state.Invalidate()
+	require.Eventually(t, func() bool { return client.seeds.Load() == 1 }, time.Second, time.Millisecond)
+	state.Close()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), client.seeds.Load())
+	assert.False(t, state.Synced())
+
+	state.Invalidate()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(1), client.seeds.Load())
+}
+
+type fakePrivateWebsocketClient struct {
+	PrivateWebsocketClient
+}
+
+func (fakePrivateWebsocketClient) SubscribeBalance(BalanceSubscriber) error      { return nil }
+func (fakePrivateWebsocketClient) SubscribePositions(PositionSubscriber) error   { return nil }
+func (fakePrivateWebsocketClient) SubscribeOrders(OrderSubscriber) error         { return nil }
+func (fakePrivateWebsocketClient) SubscribeTpSlOrders(TpSlOrderSubscriber) error { return nil }
+
+func TestReconnectingPrivateWebsocketReseedsAccountState(t *testing.T) {
+	client := newAccountSnapshotClient()
+	state := NewAccountState(client)
+	require.NoError(t, state.Seed(context.Background()))
+
+	r := &ReconnectingPrivateWebsocketClient{
+		client:               fakePrivateWebsocketClient{},
+		logger:               zap.NewNop(),
+		balanceSubscribers:   make(map[BalanceSubscriber]struct{}),
+		positionSubscribers:  make(map[PositionSubscriber]struct{}),
+		orderSubscribers:     make(map[OrderSubscriber]struct{}),
+		tpSlOrderSubscribers: make(map[TpSlOrderSubscriber]struct{}),
+	}
+	require.NoError(t, state.Subscribe(r))
+
+	require.NoError(t, r.resubscribeAll())
+	require.Eventually(t, func() bool { return state.Synced() && client.seeds.Load() == 2 }, time.Second, time.Millisecond)
+
+	// Subscribed to four channels, the state is seeded once per reconnect
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(2), client.seeds.Load())
+}
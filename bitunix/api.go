@@ -19,26 +19,44 @@ import (
 
 type ApiClient interface {
 	PlaceOrder(ctx context.Context, request *model.OrderRequest) (*model.OrderResponse, error)
+	BatchPlaceOrders(ctx context.Context, requests []model.OrderRequest) (*model.BatchOrderResponse, error)
 	CancelOrders(ctx context.Context, request *model.CancelOrderRequest) (*model.CancelOrderResponse, error)
+	CancelAllOrders(ctx context.Context, symbol model.Symbol) (*model.CancelOrderResponse, error)
+	ModifyOrder(ctx context.Context, request *model.ModifyOrderRequest) (*model.ModifyOrderResponse, error)
 	GetTradeHistory(ctx context.Context, params model.TradeHistoryParams) (*model.TradeHistoryResponse, error)
 	GetOrderHistory(ctx context.Context, params model.OrderHistoryParams) (*model.OrderHistoryResponse, error)
 	GetPositionHistory(ctx context.Context, params model.PositionHistoryParams) (*model.PositionHistoryResponse, error)
 	PlaceTpSlOrder(ctx context.Context, request *model.TPSLOrderRequest) (*model.TpSlOrderResponse, error)
+	ModifyTpSlOrder(ctx context.Context, request *model.ModifyTPSLOrderRequest) (*model.ModifyTpSlOrderResponse, error)
+	CancelTpSlOrder(ctx context.Context, request *model.CancelTPSLOrderRequest) (*model.CancelTpSlOrderResponse, error)
+	PlacePositionTpSl(ctx context.Context, request *model.PositionTPSLOrderRequest) (*model.PositionTpSlOrderResponse, error)
+	ModifyPositionTpSl(ctx context.Context, request *model.PositionTPSLOrderRequest) (*model.PositionTpSlOrderResponse, error)
 	GetPendingTPSLOrder(ctx context.Context, params model.PendingTPSLOrderParams) (*model.PendingTPSLOrderResponse, error)
 	GetTPSLOrderHistory(ctx context.Context, params model.TPSLOrderHistoryParams) (*model.TPSLOrderHistoryResponse, error)
 	GetAccountBalance(ctx context.Context, params model.AccountBalanceParams) (*model.AccountBalanceResponse, error)
 	GetPendingPositions(ctx context.Context, params model.PendingPositionParams) (*model.PendingPositionResponse, error)
 	GetOrderDetail(ctx context.Context, request *OrderDetailRequest) (*model.OrderDetailResponse, error)
 	GetPendingOrder(ctx context.Context, params model.PendingOrderParams) (*model.PendingOrderResponse, error)
+	ChangeLeverage(ctx context.Context, request *model.ChangeLeverageRequest) (*model.ChangeLeverageResponse, error)
+	ChangeMarginMode(ctx context.Context, request *model.ChangeMarginModeRequest) (*model.ChangeMarginModeResponse, error)
+	GetLeverageAndMarginMode(ctx context.Context, params model.LeverageAndMarginModeParams) (*model.LeverageAndMarginModeResponse, error)
+	ChangePositionMode(ctx context.Context, request *model.ChangePositionModeRequest) (*model.ChangePositionModeResponse, error)
+	AdjustPositionMargin(ctx context.Context, request *model.AdjustPositionMarginRequest) (*model.AdjustPositionMarginResponse, error)
+	FlashClosePosition(ctx context.Context, positionID string) (*model.FlashClosePositionResponse, error)
+	CloseAllPositions(ctx context.Context, symbol model.Symbol) (*model.CloseAllPositionsResponse, error)
 }
 
 func generateTimestamp() int64 { return time.Now().UnixMilli() }
 
 type apiClient struct {
-	restClient rest.Client
-	baseURI    string
-	logLevel   model.LogLevel
-	logger     *zap.Logger
+	restClient  rest.Client
+	baseURI     string
+	logLevel    model.LogLevel
+	logger      *zap.Logger
+	rateLimiter *rest.RateLimiter
+	retryPolicy *rest.RetryPolicy
+	timeSync    *TimeSync
+	transport   http.RoundTripper
 }
 
 type ClientOption func(*apiClient)
@@ -61,6 +79,38 @@ func WithLogger(logger *zap.Logger) ClientOption {
 	}
 }
 
+func WithRateLimiter(limiter *rest.RateLimiter) ClientOption {
+	return func(c *apiClient) {
+		c.rateLimiter = limiter
+	}
+}
+
+func WithRetryPolicy(policy rest.RetryPolicy) ClientOption {
+	return func(c *apiClient) {
+		c.retryPolicy = &policy
+	}
+}
+
+// WithTimeSync signs requests with the exchange clock measured by timeSync instead of the local clock
+func WithTimeSync(timeSync *TimeSync) ClientOption {
+	return func(c *apiClient) {
+		c.timeSync = timeSync
+	}
+}
+
+// WithTransport sends requests through transport, e.g. a rest.Recorder or rest.Player
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *apiClient) {
+		c.transport = transport
+	}
+}
+
+func DefaultRateLimits() rest.RateLimits {
+	return rest.RateLimits{
+		Default: rest.RateLimit{Requests: 10, Per: time.Second},
+	}
+}
+
 func NewApiClient(apiKey, apiSecret string, option ...ClientOption) (ApiClient, error) {
 	client := &apiClient{
 		baseURI:  "https://fapi.bitunix.com/",
@@ -70,15 +120,14 @@ func NewApiClient(apiKey, apiSecret string, option ...ClientOption) (ApiClient,
 		option(client)
 	}
 
-	restOptions := []rest.ClientOption{
-		rest.WithRequestSigner(RequestSigner(apiKey, apiSecret, generateTimestamp, security.GenerateNonce)),
+	timestampFunc := generateTimestamp
+	if client.timeSync != nil {
+		timestampFunc = client.timeSync.UnixMilli
 	}
 
-	if client.logger != nil {
-		restOptions = append(restOptions, rest.WithLogger(client.logger))
-	} else {
-		restOptions = append(restOptions, rest.WithLogLevel(client.logLevel))
-	}
+	restOptions := append(client.restOptions(),
+		rest.WithRequestSigner(RequestSigner(apiKey, apiSecret, timestampFunc, security.GenerateNonce)),
+	)
 
 	restClient, err := rest.New(client.baseURI, restOptions...)
 	if err != nil {
@@ -90,6 +139,35 @@ func NewApiClient(apiKey, apiSecret string, option ...ClientOption) (ApiClient,
 	return client, nil
 }
 
+func (c *apiClient) restOptions() []rest.ClientOption {
+	var restOptions []rest.ClientOption
+
+	if c.logger != nil {
+		restOptions = append(restOptions, rest.WithLogger(c.logger))
+	} else {
+		restOptions = append(restOptions, rest.WithLogLevel(c.logLevel))
+	}
+
+	if c.rateLimiter != nil {
+		restOptions = append(restOptions, rest.WithRateLimiter(c.rateLimiter))
+	}
+
+	if c.transport != nil {
+		restOptions = append(restOptions, rest.WithTransport(c.transport))
+	}
+
+	if c.retryPolicy != nil {
+		restOptions = append(restOptions,
+			rest.WithRetryPolicy(*c.retryPolicy),
+			rest.WithResponseErrorMapper(func(path string, body []byte) error {
+				return apiResponseError(body, path)
+			}),
+		)
+	}
+
+	return restOptions
+}
+
 func generateRequestSignature(apiKey, apiSecret, queryParams, bodyStr string, timestamp int64, nonceBytes []byte) (string, string, string, error) {
 	timestampStr := strconv.FormatInt(timestamp, 10)
 
@@ -113,6 +191,10 @@ func handleAPIResponse(responseBody []byte, endpoint string, result interface{})
 		return errors.NewInternalError(fmt.Sprintf("failed to unmarshal response from %s", endpoint), err)
 	}
 
+	return apiResponseError(responseBody, endpoint)
+}
+
+func apiResponseError(responseBody []byte, endpoint string) error {
 	response := struct {
 		Code    int    `json:"code"`
 		Message string `json:"message,omitempty"`
@@ -6,11 +6,16 @@ import (
 	"encoding/base64"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	bitunixerrors "github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+	"github.com/tradingiq/bitunix-client/rest"
 	"github.com/tradingiq/bitunix-client/security"
 )
 
@@ -312,3 +317,90 @@ func TestNewApiClient_WithOptions(t *testing.T) {
 		t.Errorf("Expected baseURI to be %s, got %s", customURI, apiClient.baseURI)
 	}
 }
+
+func TestWithRateLimiterSharedAcrossClients(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"msg":"Success","data":[]}`))
+	}))
+	defer server.Close()
+
+	limiter := rest.NewRateLimiter(rest.RateLimits{
+		Endpoints: map[string]rest.RateLimit{
+			"/api/v1/futures/market/tickers": {Requests: 1, Per: time.Hour},
+		},
+	})
+
+	apiClient, err := NewApiClient("key", "secret", WithBaseURI(server.URL), WithRateLimiter(limiter))
+	if err != nil {
+		t.Fatalf("Failed to create api client: %v", err)
+	}
+
+	marketClient, err := NewMarketClient(WithBaseURI(server.URL), WithRateLimiter(limiter))
+	if err != nil {
+		t.Fatalf("Failed to create market client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := marketClient.GetTickers(ctx, model.TickersParams{}); err != nil {
+		t.Fatalf("Unexpected error for first ticker request: %v", err)
+	}
+
+	if _, err := marketClient.GetTickers(ctx, model.TickersParams{}); !errors.Is(err, bitunixerrors.ErrRateLimitExceeded) {
+		t.Errorf("Expected ErrRateLimitExceeded, got %v", err)
+	}
+
+	if _, err := apiClient.GetPendingPositions(ctx, model.PendingPositionParams{}); err != nil {
+		t.Errorf("Expected unrelated endpoint to pass, got %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("Expected 2 requests to reach the server, got %d", requests)
+	}
+}
+
+func TestWithTransportRecordsAndReplaysSignedRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":0,"msg":"Success","data":{"marginCoin":"USDT","available":"1000","frozen":"0","margin":"0","transfer":"1000","positionMode":"HEDGE","crossUnrealizedPNL":"0","isolationUnrealizedPNL":"0","bonus":"0"}}`))
+	}))
+	defer server.Close()
+
+	recorder := rest.NewRecorder(nil)
+	live, err := NewApiClient("test-key", "test-secret", WithBaseURI(server.URL), WithTransport(recorder))
+	if err != nil {
+		t.Fatalf("Failed to create api client: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := live.GetAccountBalance(ctx, model.AccountBalanceParams{MarginCoin: "USDT"}); err != nil {
+		t.Fatalf("Live request failed: %v", err)
+	}
+
+	cassette := recorder.Cassette()
+	if len(cassette.Interactions) != 1 {
+		t.Fatalf("Expected 1 recorded interaction, got %d", len(cassette.Interactions))
+	}
+	header := cassette.Interactions[0].Request.Header
+	if header.Get("Api-Key") != rest.RedactedValue || header.Get("Sign") != rest.RedactedValue {
+		t.Errorf("Expected credentials to be redacted, got %v", header)
+	}
+
+	replay, err := NewApiClient("other-key", "other-secret", WithTransport(rest.NewPlayer(cassette)))
+	if err != nil {
+		t.Fatalf("Failed to create api client: %v", err)
+	}
+
+	balance, err := replay.GetAccountBalance(ctx, model.AccountBalanceParams{MarginCoin: "USDT"})
+	if err != nil {
+		t.Fatalf("Replayed request failed: %v", err)
+	}
+	if balance.Data.Available != 1000 {
+		t.Errorf("Expected available 1000, got %v", balance.Data.Available)
+	}
+}
@@ -0,0 +1,362 @@
+package bitunix
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+// Backtest replays recorded klines through a PaperClient. Every closed candle
+// first moves the paper market, so resting orders and tp/sl orders are matched
+// against its range, and is then delivered to the KLineSubscribers of its symbol
+// and interval. Market orders placed from a subscriber fill at the close price.
+// The paper clock follows the close time of the replayed candles, so a run
+// with the same data and strategy always produces the same report.
+type Backtest struct {
+	paper        *PaperClient
+	paperOptions []PaperClientOption
+	speed        float64
+	now          atomic.Int64
+	series       []backtestSeries
+	running      atomic.Bool
+
+	subscriberMtx sync.Mutex
+	subscribers   map[KLineSubscriber]struct{}
+}
+
+type backtestSeries struct {
+	symbol   model.Symbol
+	interval model.Interval
+	klines   []model.Kline
+}
+
+type BacktestOption func(*Backtest)
+
+// WithBacktestSpeed replays the candles speed times faster than they were recorded.
+// By default candles are replayed without waiting.
+func WithBacktestSpeed(speed float64) BacktestOption {
+	return func(b *Backtest) {
+		b.speed = speed
+	}
+}
+
+// WithBacktestPaperOptions configures the simulated exchange, e.g. its balance, fee and slippage models
+func WithBacktestPaperOptions(options ...PaperClientOption) BacktestOption {
+	return func(b *Backtest) {
+		b.paperOptions = append(b.paperOptions, options...)
+	}
+}
+
+func NewBacktest(options ...BacktestOption) *Backtest {
+	b := &Backtest{
+		subscribers: make(map[KLineSubscriber]struct{}),
+	}
+
+	for _, option := range options {
+		option(b)
+	}
+
+	b.paper = NewPaperClient(append(b.paperOptions, WithPaperClock(b.clock))...)
+	return b
+}
+
+func (b *Backtest) clock() time.Time {
+	return time.UnixMilli(b.now.Load()).UTC()
+}
+
+// Client is the simulated exchange orders of the backtested strategy are routed through
+func (b *Backtest) Client() *PaperClient {
+	return b.paper
+}
+
+// AddKlines adds the candles of symbol to the replay. Candles of several
+// symbols and intervals are merged by their close time.
+func (b *Backtest) AddKlines(symbol model.Symbol, interval model.Interval, klines []model.Kline) error {
+	if symbol == "" {
+		return errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	interval = interval.Normalize()
+	if interval.Duration() <= 0 {
+		return errors.NewValidationError("interval", fmt.Sprintf("%s has no fixed length", interval), nil)
+	}
+
+	sorted := slices.Clone(klines)
+	slices.SortStableFunc(sorted, func(a, b model.Kline) int { return a.Time.Compare(b.Time) })
+	b.series = append(b.series, backtestSeries{symbol: symbol.Normalize(), interval: interval, klines: sorted})
+	return nil
+}
+
+// LoadKlines adds the candles of a file written by WriteKlines to the replay
+func (b *Backtest) LoadKlines(symbol model.Symbol, interval model.Interval, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.NewInternalError("failed to open kline file", err)
+	}
+	defer file.Close()
+
+	klines, err := ReadKlines(file)
+	if err != nil {
+		return err
+	}
+	return b.AddKlines(symbol, interval, klines)
+}
+
+// ReadKlines reads klines stored one JSON object per line in the shape returned by GetKline
+func ReadKlines(r io.Reader) ([]model.Kline, error) {
+	var klines []model.Kline
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var kline model.Kline
+		err := decoder.Decode(&kline)
+		if err == io.EOF {
+			return klines, nil
+		}
+		if err != nil {
+			return nil, errors.NewInternalError(fmt.Sprintf("failed to decode kline %d", len(klines)+1), err)
+		}
+		klines = append(klines, kline)
+	}
+}
+
+// WriteKlines stores klines, e.g. fetched with GetKline, for ReadKlines
+func WriteKlines(w io.Writer, klines []model.Kline) error {
+	encoder := json.NewEncoder(w)
+	for _, kline := range klines {
+		if err := encoder.Encode(kline); err != nil {
+			return errors.NewInternalError("failed to encode kline", err)
+		}
+	}
+	return nil
+}
+
+// SubscribeKLine delivers the replayed candles of the subscriber's symbol and
+// interval. The recorded candles are delivered whichever price type is subscribed.
+func (b *Backtest) SubscribeKLine(subscriber KLineSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	b.subscriberMtx.Lock()
+	defer b.subscriberMtx.Unlock()
+
+	b.subscribers[subscriber] = struct{}{}
+	return nil
+}
+
+func (b *Backtest) UnsubscribeKLine(subscriber KLineSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	b.subscriberMtx.Lock()
+	defer b.subscriberMtx.Unlock()
+
+	delete(b.subscribers, subscriber)
+	return nil
+}
+
+type backtestCandle struct {
+	series    *backtestSeries
+	kline     model.Kline
+	closeTime time.Time
+}
+
+func (b *Backtest) candles() []backtestCandle {
+	var candles []backtestCandle
+	for i := range b.series {
+		s := &b.series[i]
+		for _, kline := range s.klines {
+			candles = append(candles, backtestCandle{series: s, kline: kline, closeTime: kline.Time.Add(s.interval.Duration())})
+		}
+	}
+
+	slices.SortStableFunc(candles, func(a, b backtestCandle) int { return a.closeTime.Compare(b.closeTime) })
+	return candles
+}
+
+// Run replays all candles and reports the result. A Backtest can only be run once.
+func (b *Backtest) Run(ctx context.Context) (*BacktestReport, error) {
+	if !b.running.CompareAndSwap(false, true) {
+		return nil, errors.NewValidationError("backtest", "has already been run", nil)
+	}
+
+	candles := b.candles()
+	if len(candles) == 0 {
+		return nil, errors.NewValidationError("klines", "no candles to replay", nil)
+	}
+
+	b.now.Store(candles[0].kline.Time.UnixMilli())
+	report := &BacktestReport{
+		Start:        candles[0].kline.Time,
+		StartBalance: b.paper.Balance(),
+	}
+	report.Equity = append(report.Equity, b.paper.equityPoint())
+
+	for i := 0; i < len(candles); {
+		closeTime := candles[i].closeTime
+		if err := b.wait(ctx, closeTime); err != nil {
+			return nil, err
+		}
+		b.now.Store(closeTime.UnixMilli())
+
+		for ; i < len(candles) && candles[i].closeTime.Equal(closeTime); i++ {
+			b.replay(candles[i])
+		}
+		report.Equity = append(report.Equity, b.paper.equityPoint())
+	}
+
+	report.End = b.clock()
+	b.paper.summarize(report)
+	return report, nil
+}
+
+// wait paces the replay when a speed is set
+func (b *Backtest) wait(ctx context.Context, next time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if b.speed <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(float64(next.Sub(b.clock())) / b.speed))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (b *Backtest) replay(candle backtestCandle) {
+	s, k := candle.series, candle.kline
+	b.paper.MoveTo(s.symbol, k.LowPrice, k.HighPrice, k.ClosePrice)
+
+	msg := &model.KLineChannelMessage{
+		Channel: fmt.Sprintf("%s_kline_%s", model.PriceTypeMarket, s.interval),
+		Symbol:  s.symbol,
+		Ts:      candle.closeTime.UnixMilli(),
+		Data: model.KLineEvent{
+			OpenPrice:   k.OpenPrice,
+			HighPrice:   k.HighPrice,
+			LowPrice:    k.LowPrice,
+			ClosePrice:  k.ClosePrice,
+			BaseVolume:  k.BaseVolume,
+			QuoteVolume: k.QuoteVolume,
+			Exact: model.KLineEventDecimals{
+				OpenPrice:   model.NewDecimalFromFloat(k.OpenPrice),
+				HighPrice:   model.NewDecimalFromFloat(k.HighPrice),
+				LowPrice:    model.NewDecimalFromFloat(k.LowPrice),
+				ClosePrice:  model.NewDecimalFromFloat(k.ClosePrice),
+				BaseVolume:  model.NewDecimalFromFloat(k.BaseVolume),
+				QuoteVolume: model.NewDecimalFromFloat(k.QuoteVolume),
+			},
+		},
+	}
+
+	for _, subscriber := range paperSubscribers(&b.subscriberMtx, b.subscribers) {
+		if subscriber.SubscribeSymbol().Normalize() == s.symbol && subscriber.SubscribeInterval().Normalize() == s.interval {
+			subscriber.SubscribeKLine(msg)
+		}
+	}
+}
+
+// EquityPoint is the account value after all candles closing at Time were replayed
+type EquityPoint struct {
+	Time    time.Time
+	Balance float64
+	Equity  float64
+}
+
+// BacktestTrade is a closed position with its result after fees
+type BacktestTrade struct {
+	Position model.HistoricalPosition
+	NetPNL   float64
+}
+
+// BacktestReport is the outcome of a Backtest. Positions still open at the
+// end are valued in the final equity but not counted as trades.
+type BacktestReport struct {
+	Start        time.Time
+	End          time.Time
+	StartBalance float64
+	EndBalance   float64
+	EndEquity    float64
+	TotalFees    float64
+	Equity       []EquityPoint
+	// MaxDrawdown is the largest fall of the equity from a previous peak,
+	// MaxDrawdownPercent the same fall relative to that peak, 0.1 being 10%
+	MaxDrawdown        float64
+	MaxDrawdownPercent float64
+	Trades             []BacktestTrade
+	Fills              []model.HistoricalTrade
+	OpenPositions      []model.PendingPosition
+	Wins               int
+	Losses             int
+	WinRate            float64
+}
+
+func (c *PaperClient) equityPoint() EquityPoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return EquityPoint{Time: c.engine.Now(), Balance: c.engine.Balance, Equity: c.engine.Equity()}
+}
+
+func (c *PaperClient) summarize(report *BacktestReport) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report.EndBalance = c.engine.Balance
+	report.EndEquity = c.engine.Equity()
+
+	for _, t := range c.engine.Trades {
+		report.Fills = append(report.Fills, paperHistoricalTrade(t))
+		report.TotalFees += t.Fee
+	}
+
+	for _, p := range c.engine.PositionHistory {
+		trade := BacktestTrade{Position: paperHistoricalPosition(p), NetPNL: p.RealizedPNL - p.Fee}
+		report.Trades = append(report.Trades, trade)
+		switch {
+		case trade.NetPNL > 0:
+			report.Wins++
+		case trade.NetPNL < 0:
+			report.Losses++
+		}
+	}
+	if len(report.Trades) > 0 {
+		report.WinRate = float64(report.Wins) / float64(len(report.Trades))
+	}
+
+	for _, p := range c.engine.SortedPositions() {
+		report.OpenPositions = append(report.OpenPositions, paperPendingPosition(p, c.engine.Prices[p.Symbol]))
+	}
+
+	var peak float64
+	for i, point := range report.Equity {
+		if i == 0 || point.Equity > peak {
+			peak = point.Equity
+			continue
+		}
+		if drawdown := peak - point.Equity; drawdown > report.MaxDrawdown {
+			report.MaxDrawdown = drawdown
+			if peak > 0 {
+				report.MaxDrawdownPercent = drawdown / peak
+			}
+		}
+	}
+}
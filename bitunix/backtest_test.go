@@ -0,0 +1,182 @@
+package bitunix
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+var backtestBase = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func backtestKlines(closes ...float64) []model.Kline {
+	klines := make([]model.Kline, len(closes))
+	open := closes[0]
+	for i, closePrice := range closes {
+		klines[i] = model.Kline{
+			Time:       backtestBase.Add(time.Duration(i) * time.Minute),
+			OpenPrice:  open,
+			HighPrice:  max(open, closePrice),
+			LowPrice:   min(open, closePrice),
+			ClosePrice: closePrice,
+			BaseVolume: 1,
+		}
+		open = closePrice
+	}
+	return klines
+}
+
+// flipStrategy buys one contract when flat and sells it once the close moved by step
+type flipStrategy struct {
+	t      *testing.T
+	client ApiClient
+	entry  float64
+	step   float64
+	times  []int64
+}
+
+func (s *flipStrategy) SubscribeKLine(msg *model.KLineChannelMessage) {
+	s.times = append(s.times, msg.Ts)
+	closePrice := msg.Data.ClosePrice
+	ctx := context.Background()
+
+	switch {
+	case s.entry == 0:
+		_, err := s.client.PlaceOrder(ctx, &model.OrderRequest{Symbol: "BTCUSDT", TradeSide: model.TradeSideBuy, Side: model.SideOpen, OrderType: model.OrderTypeMarket, Qty: 1})
+		require.NoError(s.t, err)
+		s.entry = closePrice
+	case closePrice >= s.entry+s.step || closePrice <= s.entry-s.step:
+		_, err := s.client.CloseAllPositions(ctx, "BTCUSDT")
+		require.NoError(s.t, err)
+		s.entry = 0
+	}
+}
+
+func (s *flipStrategy) SubscribeInterval() model.Interval {
+	return model.Interval1Min
+}
+
+func (s *flipStrategy) SubscribeSymbol() model.Symbol {
+	return "BTCUSDT"
+}
+
+func (s *flipStrategy) SubscribePriceType() model.PriceType {
+	return model.PriceTypeMarket
+}
+
+type testKLineSubscriber struct {
+	symbol   model.Symbol
+	interval model.Interval
+	fn       func(*model.KLineChannelMessage)
+}
+
+func (s *testKLineSubscriber) SubscribeKLine(msg *model.KLineChannelMessage) {
+	s.fn(msg)
+}
+
+func (s *testKLineSubscriber) SubscribeInterval() model.Interval {
+	return s.interval
+}
+
+func (s *testKLineSubscriber) SubscribeSymbol() model.Symbol {
+	return s.symbol
+}
+
+func (s *testKLineSubscriber) SubscribePriceType() model.PriceType {
+	return model.PriceTypeMarket
+}
+
+func TestBacktestReplaysKlinesFromDisk(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteKlines(&buf, backtestKlines(100, 110, 110, 105, 95, 96)))
+	path := filepath.Join(t.TempDir(), "btcusdt.jsonl")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+
+	backtest := NewBacktest(WithBacktestPaperOptions(WithPaperBalance(1000), WithPaperFees(0, 0.001)))
+	require.NoError(t, backtest.LoadKlines("BTCUSDT", model.Interval1Min, path))
+	strategy := &flipStrategy{t: t, client: backtest.Client(), step: 10}
+	require.NoError(t, backtest.SubscribeKLine(strategy))
+
+	report, err := backtest.Run(context.Background())
+	require.NoError(t, err)
+
+	// Buy 100, sell 110, buy 110, sell 95, buy 96
+	require.Len(t, strategy.times, 6)
+	assert.Equal(t, backtestBase.Add(time.Minute).UnixMilli(), strategy.times[0])
+
+	require.Len(t, report.Trades, 2)
+	assert.InDelta(t, 10-0.1-0.11, report.Trades[0].NetPNL, 1e-9)
+	assert.InDelta(t, -15-0.11-0.095, report.Trades[1].NetPNL, 1e-9)
+	assert.Equal(t, 1, report.Wins)
+	assert.Equal(t, 1, report.Losses)
+	assert.Equal(t, 0.5, report.WinRate)
+	assert.Len(t, report.Fills, 5)
+	require.Len(t, report.OpenPositions, 1)
+
+	fees := 0.1 + 0.11 + 0.11 + 0.095 + 0.096
+	assert.InDelta(t, fees, report.TotalFees, 1e-9)
+	assert.InDelta(t, 1000-5-fees, report.EndBalance, 1e-9)
+	assert.Equal(t, report.EndBalance, report.EndEquity, "the open position is at its entry price")
+
+	require.Len(t, report.Equity, 7)
+	assert.Equal(t, backtestBase, report.Equity[0].Time)
+	assert.Equal(t, backtestBase.Add(6*time.Minute), report.End)
+	assert.InDelta(t, 1000+10-0.1-0.11, report.Equity[2].Equity, 1e-9)
+	assert.InDelta(t, 15+0.11+0.095+0.096, report.MaxDrawdown, 1e-9)
+	assert.InDelta(t, report.MaxDrawdown/report.Equity[2].Equity, report.MaxDrawdownPercent, 1e-12)
+
+	_, err = backtest.Run(context.Background())
+	assert.Error(t, err)
+}
+
+func TestBacktestSlippageAndMergedSeries(t *testing.T) {
+	backtest := NewBacktest(WithBacktestPaperOptions(WithPaperFees(0, 0), WithPaperSlippage(FixedSlippage(1))))
+	require.NoError(t, backtest.AddKlines("BTCUSDT", model.Interval1Min, backtestKlines(100, 100, 100)))
+	require.NoError(t, backtest.AddKlines("ETHUSDT", model.Interval3Min, backtestKlines(10)))
+	assert.Error(t, backtest.AddKlines("BTCUSDT", model.Interval1Month, nil))
+
+	var closes []int64
+	require.NoError(t, backtest.SubscribeKLine(&testKLineSubscriber{
+		symbol: "ETHUSDT", interval: model.Interval3Min,
+		fn: func(msg *model.KLineChannelMessage) {
+			closes = append(closes, msg.Ts)
+			price, ok := backtest.Client().Price("BTCUSDT")
+			assert.True(t, ok)
+			assert.Equal(t, 100.0, price)
+		},
+	}))
+
+	strategy := &flipStrategy{t: t, client: backtest.Client(), step: 1000}
+	require.NoError(t, backtest.SubscribeKLine(strategy))
+
+	report, err := backtest.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []int64{backtestBase.Add(3 * time.Minute).UnixMilli()}, closes)
+
+	require.Len(t, report.Fills, 1)
+	assert.Equal(t, 101.0, report.Fills[0].Price)
+	assert.Empty(t, report.Trades)
+	assert.InDelta(t, -1, report.EndEquity-report.EndBalance, 1e-9)
+	assert.Len(t, report.Equity, 4, "the candles closing together form one point")
+}
+
+func TestBacktestRunHonorsContext(t *testing.T) {
+	backtest := NewBacktest(WithBacktestSpeed(1))
+	require.NoError(t, backtest.AddKlines("BTCUSDT", model.Interval1Day, backtestKlines(100, 101)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := backtest.Run(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestReadKlinesRejectsInvalidLines(t *testing.T) {
+	_, err := ReadKlines(bytes.NewBufferString(`{"time":"1","open":"1","high":"1","low":"1","close":"1"}` + "\n" + `{"open":"x"}`))
+	assert.Error(t, err)
+}
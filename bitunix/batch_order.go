@@ -0,0 +1,146 @@
+package bitunix
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+const maxBatchOrderSize = 10
+
+// BatchPlaceOrders places requests in chunks of ten per symbol, every order needs a client ID. Orders of
+// chunks the exchange did not answer, e.g. on a timeout, may have been placed and are returned in an
+// errors.UnconfirmedOrdersError. Chunks not sent because ctx ended are reported in the failure list.
+func (c *apiClient) BatchPlaceOrders(ctx context.Context, requests []model.OrderRequest) (*model.BatchOrderResponse, error) {
+	if len(requests) == 0 {
+		return nil, errors.NewValidationError("orderList", "must contain at least one order", nil)
+	}
+
+	var symbols []model.Symbol
+	ordersBySymbol := make(map[model.Symbol][]model.OrderRequest)
+	for i, request := range requests {
+		if request.Symbol == "" {
+			return nil, errors.NewValidationError(fmt.Sprintf("orderList[%d].symbol", i), "is required", nil)
+		}
+
+		// Unconfirmed orders can only be looked up by their client ID
+		if request.ClientID == "" {
+			return nil, errors.NewValidationError(fmt.Sprintf("orderList[%d].clientId", i), "is required", nil)
+		}
+
+		symbol := request.Symbol.Normalize()
+		if _, ok := ordersBySymbol[symbol]; !ok {
+			symbols = append(symbols, symbol)
+		}
+		ordersBySymbol[symbol] = append(ordersBySymbol[symbol], request)
+	}
+
+	response := &model.BatchOrderResponse{
+		Data: model.BatchOrderResponseData{
+			SuccessList: make([]model.BatchOrderResult, 0, len(requests)),
+			FailureList: make([]model.BatchOrderFailure, 0),
+		},
+	}
+
+	var unconfirmed []string
+	var unconfirmedErr error
+	for _, symbol := range symbols {
+		orders := ordersBySymbol[symbol]
+		for start := 0; start < len(orders); start += maxBatchOrderSize {
+			end := min(start+maxBatchOrderSize, len(orders))
+			chunk := orders[start:end]
+
+			if err := ctx.Err(); err != nil {
+				response.Data.FailureList = append(response.Data.FailureList, unsentFailures(chunk, err)...)
+				continue
+			}
+
+			chunkResponse, err := c.placeOrderBatch(ctx, &model.BatchOrderRequest{Symbol: symbol, OrderList: chunk})
+			var apiErr *errors.APIError
+			switch {
+			case stderrors.As(err, &apiErr):
+				// The first rejection decides the code, a batch only reports success when every chunk succeeded
+				if response.Code == 0 {
+					response.Code = apiErr.Code
+					response.Message = apiErr.Message
+				}
+				response.Data.FailureList = append(response.Data.FailureList, batchFailures(chunk, apiErr)...)
+				continue
+			case err != nil:
+				for _, order := range chunk {
+					unconfirmed = append(unconfirmed, order.ClientID)
+				}
+				unconfirmedErr = cmp.Or(unconfirmedErr, err)
+				continue
+			}
+
+			if response.Code == 0 {
+				response.Code = chunkResponse.Code
+				response.Message = chunkResponse.Message
+			}
+			response.Data.SuccessList = append(response.Data.SuccessList, chunkResponse.Data.SuccessList...)
+			response.Data.FailureList = append(response.Data.FailureList, chunkResponse.Data.FailureList...)
+		}
+	}
+
+	if len(unconfirmed) > 0 {
+		return response, errors.NewUnconfirmedOrdersError(unconfirmed, unconfirmedErr)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) placeOrderBatch(ctx context.Context, request *model.BatchOrderRequest) (*model.BatchOrderResponse, error) {
+	marshaledRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal batch order request", err)
+	}
+
+	endpoint := "/api/v1/futures/trade/batch_order"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.BatchOrderResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func batchFailures(orders []model.OrderRequest, apiErr *errors.APIError) []model.BatchOrderFailure {
+	failures := make([]model.BatchOrderFailure, 0, len(orders))
+	for _, order := range orders {
+		failures = append(failures, model.BatchOrderFailure{
+			ClientId:  order.ClientID,
+			ErrorMsg:  apiErr.Error(),
+			ErrorCode: strconv.Itoa(apiErr.Code),
+		})
+	}
+
+	return failures
+}
+
+func unsentFailures(orders []model.OrderRequest, err error) []model.BatchOrderFailure {
+	failures := make([]model.BatchOrderFailure, 0, len(orders))
+	for _, order := range orders {
+		failures = append(failures, model.BatchOrderFailure{
+			ClientId: order.ClientID,
+			ErrorMsg: fmt.Sprintf("not sent: %v", err),
+		})
+	}
+
+	return failures
+}
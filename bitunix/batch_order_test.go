@@ -0,0 +1,282 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+type batchOrderPayload struct {
+	Symbol    string                   `json:"symbol"`
+	OrderList []map[string]interface{} `json:"orderList"`
+}
+
+func TestBatchPlaceOrders(t *testing.T) {
+	var mu sync.Mutex
+	var batches []batchOrderPayload
+
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/futures/trade/batch_order" {
+			t.Errorf("Expected request path /api/v1/futures/trade/batch_order, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload batchOrderPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("Failed to unmarshal request body: %v", err)
+		}
+
+		mu.Lock()
+		batches = append(batches, payload)
+		mu.Unlock()
+
+		var successList []model.BatchOrderResult
+		var failureList []model.BatchOrderFailure
+		for _, order := range payload.OrderList {
+			clientID := order["clientId"].(string)
+			if clientID == "BTCUSDT-3" {
+				failureList = append(failureList, model.BatchOrderFailure{ClientId: clientID, ErrorMsg: "Insufficient balance", ErrorCode: "20003"})
+				continue
+			}
+			successList = append(successList, model.BatchOrderResult{OrderId: "order-" + clientID, ClientId: clientID})
+		}
+
+		response, _ := json.Marshal(map[string]interface{}{
+			"code": 0,
+			"msg":  "Success",
+			"data": model.BatchOrderResponseData{SuccessList: successList, FailureList: failureList},
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(response)
+	})
+	defer mockAPI.Close()
+
+	var requests []model.OrderRequest
+	for i := 0; i < 23; i++ {
+		order, err := NewOrderBuilder("BTCUSDT", model.TradeSideBuy, model.SideOpen, 0.001).
+			WithOrderType(model.OrderTypeLimit).
+			WithPrice(float64(50000 - i)).
+			WithClientID(fmt.Sprintf("BTCUSDT-%d", i)).
+			Build()
+		if err != nil {
+			t.Fatalf("Failed to build order: %v", err)
+		}
+		requests = append(requests, order)
+	}
+
+	ethOrder, _ := NewOrderBuilder("ETHUSDT", model.TradeSideSell, model.SideOpen, 0.1).
+		WithClientID("ETHUSDT-0").
+		Build()
+	requests = append(requests, ethOrder)
+
+	response, err := mockAPI.client.BatchPlaceOrders(context.Background(), requests)
+	if err != nil {
+		t.Fatalf("BatchPlaceOrders returned error: %v", err)
+	}
+
+	if len(batches) != 4 {
+		t.Fatalf("Expected 4 batch requests, got %d", len(batches))
+	}
+
+	expectedSizes := []int{10, 10, 3, 1}
+	expectedSymbols := []string{"BTCUSDT", "BTCUSDT", "BTCUSDT", "ETHUSDT"}
+	for i, batch := range batches {
+		if len(batch.OrderList) != expectedSizes[i] {
+			t.Errorf("Expected batch %d to contain %d orders, got %d", i, expectedSizes[i], len(batch.OrderList))
+		}
+		if batch.Symbol != expectedSymbols[i] {
+			t.Errorf("Expected batch %d symbol %s, got %s", i, expectedSymbols[i], batch.Symbol)
+		}
+	}
+
+	if batches[0].OrderList[0]["qty"] != "0.001" {
+		t.Errorf("Expected qty to be marshaled as string \"0.001\", got %v", batches[0].OrderList[0]["qty"])
+	}
+
+	if batches[0].OrderList[0]["price"] != "50000" {
+		t.Errorf("Expected price to be marshaled as string \"50000\", got %v", batches[0].OrderList[0]["price"])
+	}
+
+	if len(response.Data.SuccessList) != 23 {
+		t.Errorf("Expected 23 successful orders, got %d", len(response.Data.SuccessList))
+	}
+
+	if len(response.Data.FailureList) != 1 {
+		t.Fatalf("Expected 1 failed order, got %d", len(response.Data.FailureList))
+	}
+
+	if response.Data.FailureList[0].ClientId != "BTCUSDT-3" {
+		t.Errorf("Expected failed clientId BTCUSDT-3, got %s", response.Data.FailureList[0].ClientId)
+	}
+
+	if response.Data.FailureList[0].ErrorCode != "20003" {
+		t.Errorf("Expected error code 20003, got %s", response.Data.FailureList[0].ErrorCode)
+	}
+}
+
+func TestBatchPlaceOrders_ChunkError(t *testing.T) {
+	calls := 0
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if calls == 1 {
+			w.Write([]byte(`{"code":10005,"msg":"Rate limit exceeded"}`))
+			return
+		}
+
+		w.Write([]byte(`{"code":0,"msg":"Success","data":{"successList":[{"orderId":"2","clientId":"c-10"}],"failureList":[]}}`))
+	})
+	defer mockAPI.Close()
+
+	var requests []model.OrderRequest
+	for i := 0; i < 11; i++ {
+		order, _ := NewOrderBuilder("BTCUSDT", model.TradeSideBuy, model.SideOpen, 1).
+			WithClientID(fmt.Sprintf("c-%d", i)).
+			Build()
+		requests = append(requests, order)
+	}
+
+	response, err := mockAPI.client.BatchPlaceOrders(context.Background(), requests)
+	if err != nil {
+		t.Fatalf("BatchPlaceOrders returned error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected 2 batch requests, got %d", calls)
+	}
+
+	if len(response.Data.FailureList) != 10 {
+		t.Fatalf("Expected 10 failed orders, got %d", len(response.Data.FailureList))
+	}
+
+	for _, failure := range response.Data.FailureList {
+		if failure.ErrorCode != "10005" {
+			t.Errorf("Expected error code 10005, got %s", failure.ErrorCode)
+		}
+	}
+
+	if len(response.Data.SuccessList) != 1 || response.Data.SuccessList[0].ClientId != "c-10" {
+		t.Errorf("Expected c-10 to succeed, got %+v", response.Data.SuccessList)
+	}
+
+	if response.Code != 10005 {
+		t.Errorf("Expected the rejected chunk's code 10005, got %d", response.Code)
+	}
+}
+
+func TestBatchPlaceOrders_UnconfirmedChunk(t *testing.T) {
+	calls := 0
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// The connection drops before the exchange answers, the orders may still have been placed
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"msg":"Success","data":{"successList":[{"orderId":"2","clientId":"c-10"}],"failureList":[]}}`))
+	})
+	defer mockAPI.Close()
+
+	var requests []model.OrderRequest
+	for i := 0; i < 11; i++ {
+		order, _ := NewOrderBuilder("BTCUSDT", model.TradeSideBuy, model.SideOpen, 1).
+			WithClientID(fmt.Sprintf("c-%d", i)).
+			Build()
+		requests = append(requests, order)
+	}
+
+	response, err := mockAPI.client.BatchPlaceOrders(context.Background(), requests)
+
+	var unconfirmed *errors.UnconfirmedOrdersError
+	if !stderrors.As(err, &unconfirmed) {
+		t.Fatalf("Expected UnconfirmedOrdersError, got %v", err)
+	}
+
+	if len(unconfirmed.ClientIDs) != 10 || unconfirmed.ClientIDs[0] != "c-0" {
+		t.Errorf("Expected c-0 to c-9 to be unconfirmed, got %v", unconfirmed.ClientIDs)
+	}
+
+	if len(response.Data.FailureList) != 0 {
+		t.Errorf("Expected no failed orders, got %+v", response.Data.FailureList)
+	}
+
+	if len(response.Data.SuccessList) != 1 || response.Data.SuccessList[0].ClientId != "c-10" {
+		t.Errorf("Expected c-10 to succeed, got %+v", response.Data.SuccessList)
+	}
+}
+
+func TestBatchPlaceOrders_Cancelled(t *testing.T) {
+	calls := 0
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	defer mockAPI.Close()
+
+	var requests []model.OrderRequest
+	for i := 0; i < 11; i++ {
+		order, _ := NewOrderBuilder("BTCUSDT", model.TradeSideBuy, model.SideOpen, 1).
+			WithClientID(fmt.Sprintf("c-%d", i)).
+			Build()
+		requests = append(requests, order)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	response, err := mockAPI.client.BatchPlaceOrders(ctx, requests)
+	if calls != 0 {
+		t.Errorf("Expected no batch request, got %d", calls)
+	}
+
+	var unconfirmed *errors.UnconfirmedOrdersError
+	if stderrors.As(err, &unconfirmed) {
+		t.Fatalf("Expected unsent orders not to be unconfirmed, got %v", unconfirmed.ClientIDs)
+	}
+
+	if !stderrors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if len(response.Data.FailureList) != 11 || response.Data.FailureList[10].ClientId != "c-10" {
+		t.Errorf("Expected every order to be reported as not sent, got %+v", response.Data.FailureList)
+	}
+}
+
+func TestBatchPlaceOrders_Validation(t *testing.T) {
+	client, _ := NewApiClient("test-api-key", "test-api-secret", WithBaseURI("http://example.com"))
+
+	_, err := client.BatchPlaceOrders(context.Background(), nil)
+	if err == nil || err.Error() != "validation error: field orderList: must contain at least one order" {
+		t.Errorf("Unexpected error for empty batch: %v", err)
+	}
+
+	_, err = client.BatchPlaceOrders(context.Background(), []model.OrderRequest{{Qty: 1}})
+	if err == nil || err.Error() != "validation error: field orderList[0].symbol: is required" {
+		t.Errorf("Unexpected error for missing symbol: %v", err)
+	}
+
+	_, err = client.BatchPlaceOrders(context.Background(), []model.OrderRequest{{Symbol: "BTCUSDT", Qty: 1}})
+	if err == nil || err.Error() != "validation error: field orderList[0].clientId: is required" {
+		t.Errorf("Unexpected error for missing client ID: %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package bitunix
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strconv"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+type historyIteratorConfig struct {
+	pageSize int64
+	maxSkip  int64
+}
+
+type HistoryIteratorOption func(*historyIteratorConfig)
+
+func WithPageSize(size int64) HistoryIteratorOption {
+	return func(c *historyIteratorConfig) {
+		c.pageSize = size
+	}
+}
+
+// WithMaxSkip sets the deepest skip the exchange serves. Once reached, the
+// remaining range is fetched by moving the end of the time window instead.
+func WithMaxSkip(skip int64) HistoryIteratorOption {
+	return func(c *historyIteratorConfig) {
+		c.maxSkip = skip
+	}
+}
+
+func newHistoryIteratorConfig(options []HistoryIteratorOption) historyIteratorConfig {
+	cfg := historyIteratorConfig{pageSize: 100}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	if cfg.pageSize <= 0 {
+		cfg.pageSize = 100
+	}
+
+	return cfg
+}
+
+type historyWindow struct {
+	start *time.Time
+	end   *time.Time
+}
+
+type historySource[T any] struct {
+	fetch  func(ctx context.Context, window historyWindow, skip, limit int64) ([]T, int64, error)
+	timeOf func(T) time.Time
+	keyOf  func(T) string
+}
+
+// paginateHistory pages through a window of newest-first history. When the exchange stops
+// serving pages before the reported total, the window end is moved to the oldest record seen
+// and paging restarts at skip 0; records sharing that timestamp are not yielded twice.
+func paginateHistory[T any](ctx context.Context, window historyWindow, cfg historyIteratorConfig, source historySource[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		var skip int64
+		var oldest time.Time
+		seen := make(map[string]struct{})
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, total, err := source.fetch(ctx, window, skip, cfg.pageSize)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				key := source.keyOf(item)
+				if _, ok := seen[key]; ok {
+					continue
+				}
+
+				t := source.timeOf(item)
+				if oldest.IsZero() || t.Before(oldest) {
+					oldest = t
+					clear(seen)
+				}
+				if t.Equal(oldest) {
+					seen[key] = struct{}{}
+				}
+
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			skip += int64(len(items))
+			if skip >= total {
+				return
+			}
+
+			if int64(len(items)) == cfg.pageSize && (cfg.maxSkip <= 0 || skip+cfg.pageSize <= cfg.maxSkip) {
+				continue
+			}
+
+			if oldest.IsZero() || (window.end != nil && !oldest.Before(*window.end)) {
+				yield(zero, errors.NewInternalError(fmt.Sprintf("history pagination stalled at %s with %d of %d records fetched", oldest.Format(time.RFC3339), skip, total), nil))
+				return
+			}
+
+			end := oldest
+			window.end = &end
+			if window.start == nil {
+				epoch := time.UnixMilli(0)
+				window.start = &epoch
+			}
+			skip = 0
+		}
+	}
+}
+
+func parseHistoryTotal(total string) (int64, error) {
+	if total == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return 0, errors.NewInternalError(fmt.Sprintf("invalid history total %q", total), err)
+	}
+
+	return value, nil
+}
+
+func IterateOrderHistory(ctx context.Context, client ApiClient, params model.OrderHistoryParams, options ...HistoryIteratorOption) iter.Seq2[model.HistoricalOrder, error] {
+	return paginateHistory(ctx, historyWindow{params.StartTime, params.EndTime}, newHistoryIteratorConfig(options), historySource[model.HistoricalOrder]{
+		fetch: func(ctx context.Context, window historyWindow, skip, limit int64) ([]model.HistoricalOrder, int64, error) {
+			page := params
+			page.StartTime, page.EndTime, page.Skip, page.Limit = window.start, window.end, skip, limit
+
+			response, err := client.GetOrderHistory(ctx, page)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			total, err := parseHistoryTotal(response.Data.Total)
+			return response.Data.Orders, total, err
+		},
+		timeOf: func(order model.HistoricalOrder) time.Time { return order.CreateTime },
+		keyOf:  func(order model.HistoricalOrder) string { return order.OrderID },
+	})
+}
+
+func IterateTradeHistory(ctx context.Context, client ApiClient, params model.TradeHistoryParams, options ...HistoryIteratorOption) iter.Seq2[model.HistoricalTrade, error] {
+	return paginateHistory(ctx, historyWindow{params.StartTime, params.EndTime}, newHistoryIteratorConfig(options), historySource[model.HistoricalTrade]{
+		fetch: func(ctx context.Context, window historyWindow, skip, limit int64) ([]model.HistoricalTrade, int64, error) {
+			page := params
+			page.StartTime, page.EndTime, page.Skip, page.Limit = window.start, window.end, skip, limit
+
+			response, err := client.GetTradeHistory(ctx, page)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			total, err := parseHistoryTotal(response.Data.Total)
+			return response.Data.Trades, total, err
+		},
+		timeOf: func(trade model.HistoricalTrade) time.Time { return trade.CreateTime },
+		keyOf:  func(trade model.HistoricalTrade) string { return trade.TradeID },
+	})
+}
+
+// IteratePositionHistory fills in a missing StartTime or EndTime because the
+// position history endpoint ignores either bound unless both are provided.
+func IteratePositionHistory(ctx context.Context, client ApiClient, params model.PositionHistoryParams, options ...HistoryIteratorOption) iter.Seq2[model.HistoricalPosition, error] {
+	window := historyWindow{params.StartTime, params.EndTime}
+	if window.start != nil && window.end == nil {
+		now := time.Now()
+		window.end = &now
+	}
+	if window.end != nil && window.start == nil {
+		epoch := time.UnixMilli(0)
+		window.start = &epoch
+	}
+
+	return paginateHistory(ctx, window, newHistoryIteratorConfig(options), historySource[model.HistoricalPosition]{
+		fetch: func(ctx context.Context, window historyWindow, skip, limit int64) ([]model.HistoricalPosition, int64, error) {
+			page := params
+			page.StartTime, page.EndTime, page.Skip, page.Limit = window.start, window.end, skip, limit
+
+			response, err := client.GetPositionHistory(ctx, page)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			total, err := parseHistoryTotal(response.Data.Total)
+			return response.Data.Positions, total, err
+		},
+		timeOf: func(position model.HistoricalPosition) time.Time { return position.Ctime },
+		keyOf:  func(position model.HistoricalPosition) string { return position.PositionID },
+	})
+}
+
+func IterateTPSLOrderHistory(ctx context.Context, client ApiClient, params model.TPSLOrderHistoryParams, options ...HistoryIteratorOption) iter.Seq2[model.HistoricalTPSLOrder, error] {
+	return paginateHistory(ctx, historyWindow{params.StartTime, params.EndTime}, newHistoryIteratorConfig(options), historySource[model.HistoricalTPSLOrder]{
+		fetch: func(ctx context.Context, window historyWindow, skip, limit int64) ([]model.HistoricalTPSLOrder, int64, error) {
+			page := params
+			page.StartTime, page.EndTime, page.Skip, page.Limit = window.start, window.end, skip, limit
+
+			response, err := client.GetTPSLOrderHistory(ctx, page)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			return response.Data.OrderList, response.Data.Total, nil
+		},
+		timeOf: func(order model.HistoricalTPSLOrder) time.Time { return order.Ctime },
+		keyOf:  func(order model.HistoricalTPSLOrder) string { return order.ID },
+	})
+}
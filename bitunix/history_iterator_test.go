@@ -0,0 +1,203 @@
+package bitunix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+type fakeHistoryRecord struct {
+	id    string
+	ctime int64
+}
+
+// newCappedHistoryAPI serves newest-first records and, like the exchange, returns
+// empty pages once skip reaches maxSkip although the total reports more records.
+func newCappedHistoryAPI(records []fakeHistoryRecord, maxSkip int, requests *[]string) *MockAPI {
+	return NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*requests = append(*requests, query.Encode())
+
+		start, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+		skip, _ := strconv.Atoi(query.Get("skip"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		var matching []fakeHistoryRecord
+		for _, record := range records {
+			if query.Has("startTime") && record.ctime < start {
+				continue
+			}
+			if query.Has("endTime") && record.ctime > end {
+				continue
+			}
+			matching = append(matching, record)
+		}
+
+		var page []string
+		for i := skip; i < len(matching) && i < skip+limit && i < maxSkip; i++ {
+			page = append(page, fmt.Sprintf(`{"tradeId":%q,"orderId":"order","symbol":"BTCUSDT","qty":"1","positionMode":"ONE_WAY","marginMode":"CROSS",`+
+				`"leverage":10,"price":"50000","side":"BUY","orderType":"LIMIT","effect":"GTC","clientId":"","reduceOnly":false,"fee":"0",`+
+				`"realizedPNL":"0","ctime":"%d","roleType":"TAKER"}`, matching[i].id, matching[i].ctime))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"code":0,"msg":"Success","data":{"tradeList":[%s],"total":"%d"}}`, strings.Join(page, ","), len(matching))
+	})
+}
+
+func fakeHistoryRecords(n int) []fakeHistoryRecord {
+	records := make([]fakeHistoryRecord, n)
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	for i := range records {
+		ctime := base - int64(i)*1000
+		// Two records share a timestamp so one of them straddles a window boundary
+		if i == 6 {
+			ctime = records[5].ctime
+		}
+		records[i] = fakeHistoryRecord{id: fmt.Sprintf("trade%02d", i), ctime: ctime}
+	}
+	return records
+}
+
+func TestIterateTradeHistoryPagesWithSkip(t *testing.T) {
+	var requests []string
+	mockAPI := newCappedHistoryAPI(fakeHistoryRecords(10), 100, &requests)
+	defer mockAPI.Close()
+
+	var ids []string
+	for trade, err := range IterateTradeHistory(context.Background(), mockAPI.client, model.TradeHistoryParams{Symbol: "BTCUSDT"}, WithPageSize(4)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, trade.TradeID)
+	}
+
+	if len(ids) != 10 || ids[0] != "trade00" || ids[9] != "trade09" {
+		t.Errorf("Expected trade00..trade09, got %v", ids)
+	}
+
+	if len(requests) != 3 {
+		t.Errorf("Expected 3 page requests, got %d", len(requests))
+	}
+}
+
+func TestIterateTradeHistorySplitsWindowWhenSkipIsCapped(t *testing.T) {
+	var requests []string
+	mockAPI := newCappedHistoryAPI(fakeHistoryRecords(20), 6, &requests)
+	defer mockAPI.Close()
+
+	seen := make(map[string]int)
+	var ids []string
+	for trade, err := range IterateTradeHistory(context.Background(), mockAPI.client, model.TradeHistoryParams{}, WithPageSize(3)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		seen[trade.TradeID]++
+		ids = append(ids, trade.TradeID)
+	}
+
+	if len(ids) != 20 {
+		t.Fatalf("Expected 20 trades, got %d: %v", len(ids), ids)
+	}
+
+	for id, count := range seen {
+		if count != 1 {
+			t.Errorf("Expected %s once, got %d", id, count)
+		}
+	}
+
+	if !strings.Contains(requests[len(requests)-1], "endTime=") {
+		t.Errorf("Expected later requests to narrow the time window, got %s", requests[len(requests)-1])
+	}
+}
+
+func TestIterateTradeHistorySplitsOnConfiguredMaxSkip(t *testing.T) {
+	var requests []string
+	mockAPI := newCappedHistoryAPI(fakeHistoryRecords(12), 100, &requests)
+	defer mockAPI.Close()
+
+	count := 0
+	for _, err := range IterateTradeHistory(context.Background(), mockAPI.client, model.TradeHistoryParams{}, WithPageSize(4), WithMaxSkip(4)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+	}
+
+	if count != 12 {
+		t.Errorf("Expected 12 trades, got %d", count)
+	}
+
+	for _, request := range requests {
+		if strings.Contains(request, "skip=") {
+			t.Errorf("Expected every request to start at skip 0, got %s", request)
+		}
+	}
+}
+
+func TestIterateTradeHistoryStopsEarly(t *testing.T) {
+	var requests []string
+	mockAPI := newCappedHistoryAPI(fakeHistoryRecords(10), 100, &requests)
+	defer mockAPI.Close()
+
+	for trade, err := range IterateTradeHistory(context.Background(), mockAPI.client, model.TradeHistoryParams{}, WithPageSize(4)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if trade.TradeID == "trade01" {
+			break
+		}
+	}
+
+	if len(requests) != 1 {
+		t.Errorf("Expected a single request, got %d", len(requests))
+	}
+}
+
+func TestIterateTradeHistoryReturnsErrors(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":10002,"msg":"Parameter error"}`))
+	})
+	defer mockAPI.Close()
+
+	var errs []error
+	for _, err := range IterateTradeHistory(context.Background(), mockAPI.client, model.TradeHistoryParams{}) {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 1 || errs[0] == nil {
+		t.Errorf("Expected a single error, got %v", errs)
+	}
+}
+
+func TestIteratePositionHistoryFillsMissingBound(t *testing.T) {
+	var query string
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"msg":"Success","data":{"positionList":[],"total":"0"}}`))
+	})
+	defer mockAPI.Close()
+
+	end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, err := range IteratePositionHistory(context.Background(), mockAPI.client, model.PositionHistoryParams{EndTime: &end}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if !strings.Contains(query, "startTime=0") || !strings.Contains(query, fmt.Sprintf("endTime=%d", end.UnixMilli())) {
+		t.Errorf("Expected both time bounds, got %s", query)
+	}
+}
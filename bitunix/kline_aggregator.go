@@ -0,0 +1,423 @@
+package bitunix
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+	"go.uber.org/zap"
+)
+
+// Candle is a closed candle emitted by the KLineAggregator
+type Candle struct {
+	Symbol      model.Symbol
+	PriceType   model.PriceType
+	Interval    time.Duration
+	OpenTime    time.Time
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	BaseVolume  float64
+	QuoteVolume float64
+}
+
+func (c Candle) CloseTime() time.Time {
+	return c.OpenTime.Add(c.Interval)
+}
+
+type CandleSubscriber interface {
+	SubscribeCandle(Candle)
+}
+
+type CandleSubscriberFunc func(Candle)
+
+func (f CandleSubscriberFunc) SubscribeCandle(c Candle) {
+	f(c)
+}
+
+// Native intervals with a fixed length, from the longest to the shortest
+var aggregatorSourceIntervals = []model.Interval{
+	model.Interval1Week,
+	model.Interval3Day,
+	model.Interval1Day,
+	model.Interval12H,
+	model.Interval8H,
+	model.Interval6H,
+	model.Interval4H,
+	model.Interval2H,
+	model.Interval60Min,
+	model.Interval30Min,
+	model.Interval15Min,
+	model.Interval5Min,
+	model.Interval3Min,
+	model.Interval1Min,
+}
+
+// The Unix epoch is a Thursday, weekly candles open on Mondays
+const weekAlignment = 4 * 24 * time.Hour
+
+// KLineAggregator turns the rolling kline stream into closed candles. It
+// subscribes to a native source interval and either emits its candles when
+// they close or folds them into a longer target interval. When updates are
+// missed, for example during a reconnect, the missing candles are fetched via REST.
+type KLineAggregator struct {
+	client          MarketClient
+	symbol          model.Symbol
+	priceType       model.PriceType
+	source          model.Interval
+	sourceDuration  time.Duration
+	target          time.Duration
+	subscriber      CandleSubscriber
+	lateUpdateGrace time.Duration
+	backfillTimeout time.Duration
+	logger          *zap.Logger
+	current         *Candle
+	aggregate       *Candle
+	backfilling     bool
+	queued          []sourceClose
+	cancelBackfill  context.CancelFunc
+	closed          bool
+	ready           []Candle
+	delivering      bool
+	mu              sync.Mutex
+}
+
+// sourceClose is a closed source candle, or with next set the candle before a
+// gap that ends with the candle opening at next
+type sourceClose struct {
+	candle Candle
+	next   time.Time
+}
+
+type KLineAggregatorOption func(*KLineAggregator)
+
+// WithAggregatorSourceInterval overrides the native interval the target is built from
+func WithAggregatorSourceInterval(interval model.Interval) KLineAggregatorOption {
+	return func(a *KLineAggregator) {
+		a.source = interval.Normalize()
+	}
+}
+
+// WithAggregatorLateUpdateGrace sets how long after a boundary an update that
+// still matches the previous candle is treated as belonging to it.
+func WithAggregatorLateUpdateGrace(grace time.Duration) KLineAggregatorOption {
+	return func(a *KLineAggregator) {
+		a.lateUpdateGrace = grace
+	}
+}
+
+func WithAggregatorBackfillTimeout(timeout time.Duration) KLineAggregatorOption {
+	return func(a *KLineAggregator) {
+		a.backfillTimeout = timeout
+	}
+}
+
+func WithAggregatorLogger(logger *zap.Logger) KLineAggregatorOption {
+	return func(a *KLineAggregator) {
+		a.logger = logger
+	}
+}
+
+func NewKLineAggregator(client MarketClient, symbol model.Symbol, priceType model.PriceType, target time.Duration, subscriber CandleSubscriber, options ...KLineAggregatorOption) (*KLineAggregator, error) {
+	if subscriber == nil {
+		return nil, errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	if target <= 0 {
+		return nil, errors.NewValidationError("target", "must be positive", nil)
+	}
+
+	a := &KLineAggregator{
+		client:          client,
+		symbol:          symbol.Normalize(),
+		priceType:       priceType.Normalize(),
+		target:          target,
+		subscriber:      subscriber,
+		lateUpdateGrace: 2 * time.Second,
+		backfillTimeout: 10 * time.Second,
+		logger:          zap.NewNop(),
+	}
+
+	for _, option := range options {
+		option(a)
+	}
+
+	if a.source == "" {
+		for _, interval := range aggregatorSourceIntervals {
+			if target%interval.Duration() == 0 {
+				a.source = interval
+				break
+			}
+		}
+	}
+
+	a.sourceDuration = a.source.Duration()
+	if a.sourceDuration == 0 || target%a.sourceDuration != 0 {
+		return nil, errors.NewValidationError("target", fmt.Sprintf("%s cannot be built from a native interval with a fixed length", target), nil)
+	}
+
+	return a, nil
+}
+
+func (a *KLineAggregator) SubscribeSymbol() model.Symbol {
+	return a.symbol
+}
+
+func (a *KLineAggregator) SubscribeInterval() model.Interval {
+	return a.source
+}
+
+func (a *KLineAggregator) SubscribePriceType() model.PriceType {
+	return a.priceType
+}
+
+func (a *KLineAggregator) SubscribeKLine(msg *model.KLineChannelMessage) {
+	ts := time.UnixMilli(msg.Ts)
+	update := Candle{
+		Symbol:      a.symbol,
+		PriceType:   a.priceType,
+		Interval:    a.sourceDuration,
+		OpenTime:    alignCandle(ts, a.sourceDuration),
+		Open:        msg.Data.OpenPrice,
+		High:        msg.Data.HighPrice,
+		Low:         msg.Data.LowPrice,
+		Close:       msg.Data.ClosePrice,
+		BaseVolume:  msg.Data.BaseVolume,
+		QuoteVolume: msg.Data.QuoteVolume,
+	}
+
+	a.mu.Lock()
+	a.update(update, ts)
+	a.mu.Unlock()
+
+	a.deliver()
+}
+
+func (a *KLineAggregator) update(update Candle, ts time.Time) {
+	if a.current == nil {
+		a.current = &update
+		return
+	}
+
+	switch {
+	case update.OpenTime.Before(a.current.OpenTime):
+		// Stale update delivered out of order
+		return
+	case update.OpenTime.Equal(a.current.OpenTime) || a.isLateUpdate(update, ts):
+		update.OpenTime = a.current.OpenTime
+		a.current = &update
+		return
+	case update.OpenTime.Equal(a.current.CloseTime()):
+		a.enqueue(sourceClose{candle: *a.current})
+	default:
+		a.enqueue(sourceClose{candle: *a.current, next: update.OpenTime})
+	}
+
+	a.current = &update
+}
+
+// enqueue closes a source candle, candles closing while a backfill is running
+// wait for it so subscribers see them in order
+func (a *KLineAggregator) enqueue(closed sourceClose) {
+	if a.backfilling {
+		a.queued = append(a.queued, closed)
+		return
+	}
+
+	if closed.next.IsZero() || a.closed {
+		a.closeSource(closed.candle)
+		return
+	}
+
+	// The REST request must not block the websocket dispatch holding the lock
+	ctx, cancel := context.WithCancel(context.Background())
+	a.backfilling = true
+	a.cancelBackfill = cancel
+	go a.backfill(ctx, closed)
+}
+
+// Close cancels a running backfill, gaps after closing are not backfilled
+func (a *KLineAggregator) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.closed = true
+	if a.cancelBackfill != nil {
+		a.cancelBackfill()
+	}
+}
+
+// The stream carries no candle open time, the final update of a candle can
+// arrive just after the boundary. It still has the open price of the previous
+// candle and at least its volume because volumes accumulate within a candle.
+func (a *KLineAggregator) isLateUpdate(update Candle, ts time.Time) bool {
+	return update.OpenTime.Equal(a.current.CloseTime()) &&
+		ts.Sub(update.OpenTime) < a.lateUpdateGrace &&
+		update.Open == a.current.Open &&
+		update.BaseVolume >= a.current.BaseVolume
+}
+
+// backfill closes the gaps and the candles queued behind them until the queue is empty
+func (a *KLineAggregator) backfill(ctx context.Context, gap sourceClose) {
+	for {
+		candles := a.fetchGap(ctx, gap)
+
+		a.mu.Lock()
+		for _, candle := range candles {
+			a.closeSource(candle)
+		}
+
+		gap = sourceClose{}
+		for len(a.queued) > 0 && gap.next.IsZero() {
+			closed := a.queued[0]
+			a.queued = a.queued[1:]
+			if closed.next.IsZero() {
+				a.closeSource(closed.candle)
+			} else {
+				gap = closed
+			}
+		}
+
+		if gap.next.IsZero() {
+			a.backfilling = false
+			a.cancelBackfill()
+			a.cancelBackfill = nil
+			a.mu.Unlock()
+			a.deliver()
+			return
+		}
+		a.mu.Unlock()
+		a.deliver()
+	}
+}
+
+// fetchGap returns the last streamed candle before a gap and the candles missed
+// before the one opening at gap.next, the REST candles replace streamed state
+func (a *KLineAggregator) fetchGap(ctx context.Context, gap sourceClose) []Candle {
+	ctx, cancel := context.WithTimeout(ctx, a.backfillTimeout)
+	defer cancel()
+
+	next := gap.next
+	start := gap.candle.OpenTime
+	end := next.Add(-a.sourceDuration)
+	response, err := a.client.GetKline(ctx, model.KlineParams{
+		Symbol:    a.symbol,
+		Interval:  a.source,
+		PriceType: a.priceType,
+		StartTime: &start,
+		EndTime:   &end,
+		Limit:     int64(next.Sub(start)/a.sourceDuration) + 1,
+	})
+	if err != nil {
+		a.logger.Warn("failed to backfill klines", zap.String("symbol", a.symbol.String()), zap.Error(err))
+		return []Candle{gap.candle}
+	}
+
+	klines := response.Data
+	sort.Slice(klines, func(i, j int) bool { return klines[i].Time.Before(klines[j].Time) })
+
+	var candles []Candle
+	for _, kline := range klines {
+		if kline.Time.Before(start) || !kline.Time.Before(next) {
+			continue
+		}
+
+		if len(candles) == 0 && !kline.Time.Equal(start) {
+			// Keep the last streamed state if the exchange did not return the candle
+			candles = append(candles, gap.candle)
+		}
+
+		candles = append(candles, Candle{
+			Symbol:      a.symbol,
+			PriceType:   a.priceType,
+			Interval:    a.sourceDuration,
+			OpenTime:    kline.Time,
+			Open:        kline.OpenPrice,
+			High:        kline.HighPrice,
+			Low:         kline.LowPrice,
+			Close:       kline.ClosePrice,
+			BaseVolume:  kline.BaseVolume,
+			QuoteVolume: kline.QuoteVolume,
+		})
+	}
+
+	if len(candles) == 0 {
+		candles = append(candles, gap.candle)
+	}
+
+	return candles
+}
+
+func (a *KLineAggregator) closeSource(candle Candle) {
+	if a.target == a.sourceDuration {
+		a.ready = append(a.ready, candle)
+		return
+	}
+
+	openTime := alignCandle(candle.OpenTime, a.target)
+	if a.aggregate != nil && !a.aggregate.OpenTime.Equal(openTime) {
+		// Missing source candles at the end of the previous target candle
+		a.ready = append(a.ready, *a.aggregate)
+		a.aggregate = nil
+	}
+
+	if a.aggregate == nil {
+		aggregate := candle
+		aggregate.Interval = a.target
+		aggregate.OpenTime = openTime
+		a.aggregate = &aggregate
+	} else {
+		a.aggregate.High = max(a.aggregate.High, candle.High)
+		a.aggregate.Low = min(a.aggregate.Low, candle.Low)
+		a.aggregate.Close = candle.Close
+		a.aggregate.BaseVolume += candle.BaseVolume
+		a.aggregate.QuoteVolume += candle.QuoteVolume
+	}
+
+	if candle.CloseTime().Equal(a.aggregate.CloseTime()) {
+		a.ready = append(a.ready, *a.aggregate)
+		a.aggregate = nil
+	}
+}
+
+// deliver hands the closed candles to the subscriber without holding the lock. A
+// single caller delivers at a time so candles keep their order, candles closed
+// meanwhile are picked up by the caller already delivering.
+func (a *KLineAggregator) deliver() {
+	a.mu.Lock()
+	if a.delivering {
+		a.mu.Unlock()
+		return
+	}
+
+	a.delivering = true
+	for len(a.ready) > 0 {
+		candles := a.ready
+		a.ready = nil
+
+		a.mu.Unlock()
+		for _, candle := range candles {
+			a.subscriber.SubscribeCandle(candle)
+		}
+		a.mu.Lock()
+	}
+	a.delivering = false
+	a.mu.Unlock()
+}
+
+func alignCandle(t time.Time, interval time.Duration) time.Time {
+	var offset time.Duration
+	if interval%(7*24*time.Hour) == 0 {
+		offset = weekAlignment
+	}
+
+	ms := t.UnixMilli() - offset.Milliseconds()
+	step := interval.Milliseconds()
+	aligned := ms - ((ms%step)+step)%step
+	return time.UnixMilli(aligned + offset.Milliseconds()).UTC()
+}
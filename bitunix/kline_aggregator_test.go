@@ -0,0 +1,283 @@
+package bitunix
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+type klineBackfillClient struct {
+	MarketClient
+	klines  []model.Kline
+	release chan struct{}
+	mu      sync.Mutex
+	params  []model.KlineParams
+}
+
+func (c *klineBackfillClient) GetKline(ctx context.Context, params model.KlineParams) (*model.KlineResponse, error) {
+	if c.release != nil {
+		select {
+		case <-c.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.params = append(c.params, params)
+	return &model.KlineResponse{Data: c.klines}, nil
+}
+
+type candleRecorder struct {
+	mu      sync.Mutex
+	candles []Candle
+}
+
+func (r *candleRecorder) SubscribeCandle(c Candle) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.candles = append(r.candles, c)
+}
+
+// waitFor returns the candles once n were emitted, backfills are emitted asynchronously
+func (r *candleRecorder) waitFor(t *testing.T, n int) []Candle {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return len(r.candles) >= n
+	}, time.Second, time.Millisecond)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.candles)
+}
+
+var aggregatorBase = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func klineUpdate(at time.Duration, open, high, low, close, volume float64) *model.KLineChannelMessage {
+	return &model.KLineChannelMessage{
+		Channel: "market_kline_1min",
+		Symbol:  "BTCUSDT",
+		Ts:      aggregatorBase.Add(at).UnixMilli(),
+		Data: model.KLineEvent{
+			OpenPrice:   open,
+			HighPrice:   high,
+			LowPrice:    low,
+			ClosePrice:  close,
+			BaseVolume:  volume,
+			QuoteVolume: volume * close,
+		},
+	}
+}
+
+func TestNewKLineAggregatorSelectsSourceInterval(t *testing.T) {
+	tests := []struct {
+		target time.Duration
+		source model.Interval
+	}{
+		{time.Minute, model.Interval1Min},
+		{10 * time.Minute, model.Interval5Min},
+		{90 * time.Minute, model.Interval30Min},
+		{48 * time.Hour, model.Interval1Day},
+		{14 * 24 * time.Hour, model.Interval1Week},
+	}
+
+	for _, tt := range tests {
+		aggregator, err := NewKLineAggregator(&klineBackfillClient{}, "btcusdt", model.PriceTypeMarket, tt.target, &candleRecorder{})
+		require.NoError(t, err)
+		assert.Equal(t, tt.source, aggregator.SubscribeInterval(), tt.target.String())
+		assert.Equal(t, model.Symbol("BTCUSDT"), aggregator.SubscribeSymbol())
+	}
+
+	_, err := NewKLineAggregator(&klineBackfillClient{}, "BTCUSDT", model.PriceTypeMarket, 90*time.Second, &candleRecorder{})
+	assert.Error(t, err)
+
+	_, err = NewKLineAggregator(&klineBackfillClient{}, "BTCUSDT", model.PriceTypeMarket, 10*time.Minute, &candleRecorder{}, WithAggregatorSourceInterval(model.Interval3Min))
+	assert.Error(t, err)
+}
+
+func TestKLineAggregatorEmitsClosedCandles(t *testing.T) {
+	recorder := &candleRecorder{}
+	aggregator, err := NewKLineAggregator(&klineBackfillClient{}, "BTCUSDT", model.PriceTypeMarket, time.Minute, recorder)
+	require.NoError(t, err)
+
+	aggregator.SubscribeKLine(klineUpdate(10*time.Second, 100, 101, 99, 100, 1))
+	aggregator.SubscribeKLine(klineUpdate(50*time.Second, 100, 103, 99, 102, 3))
+	assert.Empty(t, recorder.candles)
+
+	// The final update of the first candle arrives just after the boundary
+	aggregator.SubscribeKLine(klineUpdate(time.Minute+200*time.Millisecond, 100, 104, 99, 103, 4))
+	assert.Empty(t, recorder.candles)
+
+	aggregator.SubscribeKLine(klineUpdate(time.Minute+5*time.Second, 103, 103, 102, 102, 1))
+	// Out of order updates of a closed candle are dropped
+	aggregator.SubscribeKLine(klineUpdate(55*time.Second, 100, 103, 99, 102, 3))
+	aggregator.SubscribeKLine(klineUpdate(2*time.Minute+time.Second, 102, 102, 102, 102, 1))
+
+	require.Len(t, recorder.candles, 2)
+	assert.Equal(t, Candle{
+		Symbol:      "BTCUSDT",
+		PriceType:   model.PriceTypeMarket,
+		Interval:    time.Minute,
+		OpenTime:    aggregatorBase,
+		Open:        100,
+		High:        104,
+		Low:         99,
+		Close:       103,
+		BaseVolume:  4,
+		QuoteVolume: 412,
+	}, recorder.candles[0])
+	assert.Equal(t, aggregatorBase.Add(time.Minute), recorder.candles[1].OpenTime)
+	assert.Equal(t, aggregatorBase.Add(2*time.Minute), recorder.candles[1].CloseTime())
+}
+
+func TestKLineAggregatorSynthesizesInterval(t *testing.T) {
+	recorder := &candleRecorder{}
+	aggregator, err := NewKLineAggregator(&klineBackfillClient{}, "BTCUSDT", model.PriceTypeMarket, 10*time.Minute, recorder)
+	require.NoError(t, err)
+	require.Equal(t, model.Interval5Min, aggregator.SubscribeInterval())
+
+	aggregator.SubscribeKLine(klineUpdate(4*time.Minute, 100, 105, 98, 104, 2))
+	aggregator.SubscribeKLine(klineUpdate(9*time.Minute, 104, 110, 103, 107, 3))
+	assert.Empty(t, recorder.candles)
+
+	aggregator.SubscribeKLine(klineUpdate(10*time.Minute+5*time.Second, 107, 107, 106, 106, 1))
+
+	require.Len(t, recorder.candles, 1)
+	candle := recorder.candles[0]
+	assert.Equal(t, aggregatorBase, candle.OpenTime)
+	assert.Equal(t, 10*time.Minute, candle.Interval)
+	assert.Equal(t, 100.0, candle.Open)
+	assert.Equal(t, 110.0, candle.High)
+	assert.Equal(t, 98.0, candle.Low)
+	assert.Equal(t, 107.0, candle.Close)
+	assert.Equal(t, 5.0, candle.BaseVolume)
+}
+
+func TestKLineAggregatorBackfillsAfterGap(t *testing.T) {
+	client := &klineBackfillClient{klines: []model.Kline{
+		{Time: aggregatorBase.Add(2 * time.Minute), OpenPrice: 102, HighPrice: 104, LowPrice: 101, ClosePrice: 103, BaseVolume: 2},
+		{Time: aggregatorBase, OpenPrice: 100, HighPrice: 102, LowPrice: 99, ClosePrice: 101, BaseVolume: 5},
+		{Time: aggregatorBase.Add(time.Minute), OpenPrice: 101, HighPrice: 103, LowPrice: 100, ClosePrice: 102, BaseVolume: 1},
+	}}
+	recorder := &candleRecorder{}
+	aggregator, err := NewKLineAggregator(client, "BTCUSDT", model.PriceTypeMarket, time.Minute, recorder)
+	require.NoError(t, err)
+
+	aggregator.SubscribeKLine(klineUpdate(30*time.Second, 100, 101, 99, 100, 3))
+	// A reconnect skipped the candles opening at 1m and 2m
+	aggregator.SubscribeKLine(klineUpdate(3*time.Minute+10*time.Second, 103, 103, 103, 103, 1))
+
+	candles := recorder.waitFor(t, 3)
+	require.Len(t, candles, 3)
+	for i, candle := range candles {
+		assert.Equal(t, aggregatorBase.Add(time.Duration(i)*time.Minute), candle.OpenTime)
+	}
+	assert.Equal(t, 5.0, candles[0].BaseVolume, "the REST candle replaces the partial streamed state")
+
+	require.Len(t, client.params, 1)
+	assert.Equal(t, model.Interval1Min, client.params[0].Interval)
+	assert.Equal(t, aggregatorBase, *client.params[0].StartTime)
+	assert.Equal(t, aggregatorBase.Add(2*time.Minute), *client.params[0].EndTime)
+}
+
+func TestKLineAggregatorKeepsStreamedCandleMissingFromBackfill(t *testing.T) {
+	client := &klineBackfillClient{klines: []model.Kline{
+		{Time: aggregatorBase.Add(-time.Minute), OpenPrice: 99, HighPrice: 100, LowPrice: 98, ClosePrice: 100, BaseVolume: 7},
+	}}
+	recorder := &candleRecorder{}
+	aggregator, err := NewKLineAggregator(client, "BTCUSDT", model.PriceTypeMarket, time.Minute, recorder)
+	require.NoError(t, err)
+
+	aggregator.SubscribeKLine(klineUpdate(30*time.Second, 100, 101, 99, 100, 3))
+	aggregator.SubscribeKLine(klineUpdate(3*time.Minute+10*time.Second, 103, 103, 103, 103, 1))
+
+	candles := recorder.waitFor(t, 1)
+	require.Len(t, candles, 1)
+	assert.Equal(t, aggregatorBase, candles[0].OpenTime)
+	assert.Equal(t, 3.0, candles[0].BaseVolume)
+}
+
+func TestKLineAggregatorBackfillDoesNotBlockUpdates(t *testing.T) {
+	client := &klineBackfillClient{release: make(chan struct{})}
+	recorder := &candleRecorder{}
+	aggregator, err := NewKLineAggregator(client, "BTCUSDT", model.PriceTypeMarket, time.Minute, recorder)
+	require.NoError(t, err)
+
+	aggregator.SubscribeKLine(klineUpdate(30*time.Second, 100, 101, 99, 100, 3))
+	aggregator.SubscribeKLine(klineUpdate(3*time.Minute+10*time.Second, 103, 103, 103, 103, 1))
+
+	// Candles closing during the backfill are held back until it completes
+	aggregator.SubscribeKLine(klineUpdate(4*time.Minute+10*time.Second, 104, 104, 104, 104, 1))
+	recorder.mu.Lock()
+	assert.Empty(t, recorder.candles)
+	recorder.mu.Unlock()
+
+	close(client.release)
+
+	candles := recorder.waitFor(t, 2)
+	require.Len(t, candles, 2)
+	assert.Equal(t, aggregatorBase, candles[0].OpenTime)
+	assert.Equal(t, aggregatorBase.Add(3*time.Minute), candles[1].OpenTime)
+}
+
+func TestKLineAggregatorCloseCancelsBackfill(t *testing.T) {
+	client := &klineBackfillClient{release: make(chan struct{})}
+	recorder := &candleRecorder{}
+	aggregator, err := NewKLineAggregator(client, "BTCUSDT", model.PriceTypeMarket, time.Minute, recorder)
+	require.NoError(t, err)
+
+	aggregator.SubscribeKLine(klineUpdate(30*time.Second, 100, 101, 99, 100, 3))
+	aggregator.SubscribeKLine(klineUpdate(3*time.Minute+10*time.Second, 103, 103, 103, 103, 1))
+	aggregator.Close()
+
+	// The cancelled backfill keeps the streamed candle
+	candles := recorder.waitFor(t, 1)
+	assert.Equal(t, aggregatorBase, candles[0].OpenTime)
+
+	// Later gaps are not backfilled
+	aggregator.SubscribeKLine(klineUpdate(6*time.Minute+10*time.Second, 106, 106, 106, 106, 1))
+	candles = recorder.waitFor(t, 2)
+	assert.Equal(t, aggregatorBase.Add(3*time.Minute), candles[1].OpenTime)
+	assert.Empty(t, client.params)
+}
+
+func TestKLineAggregatorSubscriberCanCallBack(t *testing.T) {
+	var aggregator *KLineAggregator
+	var candles []Candle
+	subscriber := CandleSubscriberFunc(func(c Candle) {
+		candles = append(candles, c)
+		if len(candles) == 1 {
+			// Closes the next candle while the first one is being delivered
+			aggregator.SubscribeKLine(klineUpdate(2*time.Minute+10*time.Second, 102, 102, 102, 102, 1))
+		}
+	})
+
+	var err error
+	aggregator, err = NewKLineAggregator(&klineBackfillClient{}, "BTCUSDT", model.PriceTypeMarket, time.Minute, subscriber)
+	require.NoError(t, err)
+
+	aggregator.SubscribeKLine(klineUpdate(10*time.Second, 100, 100, 100, 100, 1))
+	aggregator.SubscribeKLine(klineUpdate(time.Minute+10*time.Second, 101, 101, 101, 101, 1))
+
+	require.Len(t, candles, 2)
+	assert.Equal(t, aggregatorBase, candles[0].OpenTime)
+	assert.Equal(t, aggregatorBase.Add(time.Minute), candles[1].OpenTime)
+}
+
+func TestAlignCandleWeek(t *testing.T) {
+	wednesday := time.Date(2025, 1, 8, 15, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), alignCandle(wednesday, 7*24*time.Hour))
+	assert.Equal(t, time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC), alignCandle(wednesday, 24*time.Hour))
+}
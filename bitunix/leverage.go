@@ -0,0 +1,101 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+func (c *apiClient) ChangeLeverage(ctx context.Context, request *model.ChangeLeverageRequest) (*model.ChangeLeverageResponse, error) {
+	if request.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	if request.MarginCoin == "" {
+		return nil, errors.NewValidationError("marginCoin", "is required", nil)
+	}
+
+	if request.Leverage <= 0 {
+		return nil, errors.NewValidationError("leverage", "must be greater than zero", nil)
+	}
+
+	marshaledRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal change leverage request", err)
+	}
+
+	endpoint := "/api/v1/futures/account/change_leverage"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.ChangeLeverageResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) ChangeMarginMode(ctx context.Context, request *model.ChangeMarginModeRequest) (*model.ChangeMarginModeResponse, error) {
+	if request.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	if request.MarginCoin == "" {
+		return nil, errors.NewValidationError("marginCoin", "is required", nil)
+	}
+
+	if !request.MarginMode.IsValid() {
+		return nil, errors.NewValidationError("marginMode", "must be ISOLATION or CROSS", nil)
+	}
+
+	marshaledRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal change margin mode request", err)
+	}
+
+	endpoint := "/api/v1/futures/account/change_margin_mode"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.ChangeMarginModeResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) GetLeverageAndMarginMode(ctx context.Context, params model.LeverageAndMarginModeParams) (*model.LeverageAndMarginModeResponse, error) {
+	if params.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	if params.MarginCoin == "" {
+		return nil, errors.NewValidationError("marginCoin", "is required", nil)
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("symbol", params.Symbol.String())
+	queryParams.Add("marginCoin", params.MarginCoin.String())
+
+	endpoint := "/api/v1/futures/account/get_leverage_margin_mode"
+	responseBody, err := c.restClient.Get(ctx, endpoint, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.LeverageAndMarginModeResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,285 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+func TestChangeLeverage(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/futures/account/change_leverage" {
+			t.Errorf("Expected request path /api/v1/futures/account/change_leverage, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("Failed to unmarshal request body: %v", err)
+		}
+
+		if payload["symbol"] != "BTCUSDT" {
+			t.Errorf("Expected symbol BTCUSDT, got %v", payload["symbol"])
+		}
+
+		if payload["marginCoin"] != "USDT" {
+			t.Errorf("Expected marginCoin USDT, got %v", payload["marginCoin"])
+		}
+
+		if payload["leverage"] != float64(20) {
+			t.Errorf("Expected leverage 20, got %v", payload["leverage"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"data":[{"symbol":"BTCUSDT","marginCoin":"USDT","leverage":20}],"msg":"Success"}`))
+	})
+	defer mockAPI.Close()
+
+	resp, err := mockAPI.client.ChangeLeverage(context.Background(), &model.ChangeLeverageRequest{
+		Symbol:     "BTCUSDT",
+		MarginCoin: "USDT",
+		Leverage:   20,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Data) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(resp.Data))
+	}
+
+	if resp.Data[0].Symbol != "BTCUSDT" {
+		t.Errorf("Expected symbol BTCUSDT, got %s", resp.Data[0].Symbol)
+	}
+
+	if resp.Data[0].MarginCoin != "USDT" {
+		t.Errorf("Expected marginCoin USDT, got %s", resp.Data[0].MarginCoin)
+	}
+
+	if resp.Data[0].Leverage != 20 {
+		t.Errorf("Expected leverage 20, got %d", resp.Data[0].Leverage)
+	}
+}
+
+func TestChangeLeverageValidation(t *testing.T) {
+	client, _ := NewApiClient("test-api-key", "test-api-secret", WithBaseURI("http://example.com"))
+
+	tests := []struct {
+		name    string
+		request model.ChangeLeverageRequest
+		field   string
+	}{
+		{
+			name:    "missing symbol",
+			request: model.ChangeLeverageRequest{MarginCoin: "USDT", Leverage: 10},
+			field:   "symbol",
+		},
+		{
+			name:    "missing margin coin",
+			request: model.ChangeLeverageRequest{Symbol: "BTCUSDT", Leverage: 10},
+			field:   "marginCoin",
+		},
+		{
+			name:    "zero leverage",
+			request: model.ChangeLeverageRequest{Symbol: "BTCUSDT", MarginCoin: "USDT"},
+			field:   "leverage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ChangeLeverage(context.Background(), &tt.request)
+			if err == nil {
+				t.Fatal("Expected validation error, got none")
+			}
+
+			var validationErr *errors.ValidationError
+			if !stderrors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+
+			if validationErr.Field != tt.field {
+				t.Errorf("Expected field %s, got %s", tt.field, validationErr.Field)
+			}
+		})
+	}
+}
+
+func TestChangeLeverageInvalidLeverageError(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":20005,"msg":"Invalid leverage"}`))
+	})
+	defer mockAPI.Close()
+
+	_, err := mockAPI.client.ChangeLeverage(context.Background(), &model.ChangeLeverageRequest{
+		Symbol:     "BTCUSDT",
+		MarginCoin: "USDT",
+		Leverage:   500,
+	})
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	if !stderrors.Is(err, errors.ErrInvalidLeverage) {
+		t.Errorf("Expected ErrInvalidLeverage, got %v", err)
+	}
+}
+
+func TestChangeMarginMode(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/futures/account/change_margin_mode" {
+			t.Errorf("Expected request path /api/v1/futures/account/change_margin_mode, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("Failed to unmarshal request body: %v", err)
+		}
+
+		if payload["marginMode"] != "CROSS" {
+			t.Errorf("Expected marginMode CROSS, got %v", payload["marginMode"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"data":[{"symbol":"BTCUSDT","marginCoin":"USDT","marginMode":"CROSS"}],"msg":"Success"}`))
+	})
+	defer mockAPI.Close()
+
+	resp, err := mockAPI.client.ChangeMarginMode(context.Background(), &model.ChangeMarginModeRequest{
+		Symbol:     "BTCUSDT",
+		MarginCoin: "USDT",
+		MarginMode: model.MarginModeCross,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Data) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(resp.Data))
+	}
+
+	if resp.Data[0].MarginMode != model.MarginModeCross {
+		t.Errorf("Expected margin mode CROSS, got %s", resp.Data[0].MarginMode)
+	}
+}
+
+func TestChangeMarginModeValidation(t *testing.T) {
+	client, _ := NewApiClient("test-api-key", "test-api-secret", WithBaseURI("http://example.com"))
+
+	_, err := client.ChangeMarginMode(context.Background(), &model.ChangeMarginModeRequest{
+		Symbol:     "BTCUSDT",
+		MarginCoin: "USDT",
+		MarginMode: "PORTFOLIO",
+	})
+	if err == nil {
+		t.Fatal("Expected validation error, got none")
+	}
+
+	if !stderrors.Is(err, errors.ErrValidation) {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+}
+
+func TestChangeMarginModeOpenOrdersError(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":20006,"msg":"Please cancel open orders"}`))
+	})
+	defer mockAPI.Close()
+
+	_, err := mockAPI.client.ChangeMarginMode(context.Background(), &model.ChangeMarginModeRequest{
+		Symbol:     "BTCUSDT",
+		MarginCoin: "USDT",
+		MarginMode: model.MarginModeIsolation,
+	})
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	if !stderrors.Is(err, errors.ErrOpenOrdersExist) {
+		t.Errorf("Expected ErrOpenOrdersExist, got %v", err)
+	}
+}
+
+func TestGetLeverageAndMarginMode(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/futures/account/get_leverage_margin_mode" {
+			t.Errorf("Expected request path /api/v1/futures/account/get_leverage_margin_mode, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected request method GET, got %s", r.Method)
+		}
+
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			t.Errorf("Expected symbol BTCUSDT, got %s", r.URL.Query().Get("symbol"))
+		}
+
+		if r.URL.Query().Get("marginCoin") != "USDT" {
+			t.Errorf("Expected marginCoin USDT, got %s", r.URL.Query().Get("marginCoin"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"data":{"symbol":"BTCUSDT","marginCoin":"USDT","leverage":25,"marginMode":"ISOLATION"},"msg":"Success"}`))
+	})
+	defer mockAPI.Close()
+
+	resp, err := mockAPI.client.GetLeverageAndMarginMode(context.Background(), model.LeverageAndMarginModeParams{
+		Symbol:     "BTCUSDT",
+		MarginCoin: "USDT",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Data == nil {
+		t.Fatal("Expected data, got nil")
+	}
+
+	if resp.Data.Leverage != 25 {
+		t.Errorf("Expected leverage 25, got %d", resp.Data.Leverage)
+	}
+
+	if resp.Data.MarginMode != model.MarginModeIsolation {
+		t.Errorf("Expected margin mode ISOLATION, got %s", resp.Data.MarginMode)
+	}
+
+	if resp.Data.Symbol != "BTCUSDT" {
+		t.Errorf("Expected symbol BTCUSDT, got %s", resp.Data.Symbol)
+	}
+}
+
+func TestGetLeverageAndMarginModeValidation(t *testing.T) {
+	client, _ := NewApiClient("test-api-key", "test-api-secret", WithBaseURI("http://example.com"))
+
+	_, err := client.GetLeverageAndMarginMode(context.Background(), model.LeverageAndMarginModeParams{Symbol: "BTCUSDT"})
+	if err == nil {
+		t.Fatal("Expected validation error, got none")
+	}
+
+	expected := "validation error: field marginCoin: is required"
+	if err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,233 @@
+package bitunix
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+	"github.com/tradingiq/bitunix-client/rest"
+)
+
+type MarketClient interface {
+	GetTickers(ctx context.Context, params model.TickersParams) (*model.TickersResponse, error)
+	GetDepth(ctx context.Context, params model.DepthParams) (*model.DepthResponse, error)
+	GetKline(ctx context.Context, params model.KlineParams) (*model.KlineResponse, error)
+	GetFundingRate(ctx context.Context, params model.FundingRateParams) (*model.FundingRateResponse, error)
+	GetFundingRateHistory(ctx context.Context, params model.FundingRateHistoryParams) (*model.FundingRateHistoryResponse, error)
+	GetTradingPairs(ctx context.Context, params model.TradingPairsParams) (*model.TradingPairsResponse, error)
+}
+
+func NewMarketClient(option ...ClientOption) (MarketClient, error) {
+	client := &apiClient{
+		baseURI:  "https://fapi.bitunix.com/",
+		logLevel: model.LogLevelNone,
+	}
+	for _, option := range option {
+		option(client)
+	}
+
+	restClient, err := rest.New(client.baseURI, client.restOptions()...)
+	if err != nil {
+		return nil, errors.NewInternalError("creating rest client", err)
+	}
+
+	client.restClient = restClient
+
+	return client, nil
+}
+
+func (c *apiClient) GetTickers(ctx context.Context, params model.TickersParams) (*model.TickersResponse, error) {
+	queryParams := url.Values{}
+	if len(params.Symbols) > 0 {
+		queryParams.Add("symbols", joinSymbols(params.Symbols))
+	}
+
+	endpoint := "/api/v1/futures/market/tickers"
+	responseBody, err := c.restClient.Get(ctx, endpoint, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.TickersResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) GetDepth(ctx context.Context, params model.DepthParams) (*model.DepthResponse, error) {
+	if params.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("symbol", params.Symbol.Normalize().String())
+	if params.Limit > 0 {
+		queryParams.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	endpoint := "/api/v1/futures/market/depth"
+	responseBody, err := c.restClient.Get(ctx, endpoint, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.DepthResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) GetKline(ctx context.Context, params model.KlineParams) (*model.KlineResponse, error) {
+	if params.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	interval, ok := klineIntervals[params.Interval.Normalize()]
+	if !ok {
+		return nil, errors.NewValidationError("interval", "is not a valid interval", nil)
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("symbol", params.Symbol.Normalize().String())
+	queryParams.Add("interval", interval)
+
+	if params.PriceType != "" {
+		switch params.PriceType.Normalize() {
+		case model.PriceTypeMark:
+			queryParams.Add("type", "MARK_PRICE")
+		case model.PriceTypeMarket:
+			queryParams.Add("type", "LAST_PRICE")
+		default:
+			return nil, errors.NewValidationError("type", "must be mark or market", nil)
+		}
+	}
+	if params.StartTime != nil {
+		queryParams.Add("startTime", strconv.FormatInt(params.StartTime.UnixMilli(), 10))
+	}
+	if params.EndTime != nil {
+		queryParams.Add("endTime", strconv.FormatInt(params.EndTime.UnixMilli(), 10))
+	}
+	if params.Limit > 0 {
+		queryParams.Add("limit", strconv.FormatInt(params.Limit, 10))
+	}
+
+	endpoint := "/api/v1/futures/market/kline"
+	responseBody, err := c.restClient.Get(ctx, endpoint, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.KlineResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) GetFundingRate(ctx context.Context, params model.FundingRateParams) (*model.FundingRateResponse, error) {
+	if params.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("symbol", params.Symbol.Normalize().String())
+
+	endpoint := "/api/v1/futures/market/funding_rate"
+	responseBody, err := c.restClient.Get(ctx, endpoint, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.FundingRateResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) GetFundingRateHistory(ctx context.Context, params model.FundingRateHistoryParams) (*model.FundingRateHistoryResponse, error) {
+	if params.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("symbol", params.Symbol.Normalize().String())
+	if params.StartTime != nil {
+		queryParams.Add("startTime", strconv.FormatInt(params.StartTime.UnixMilli(), 10))
+	}
+	if params.EndTime != nil {
+		queryParams.Add("endTime", strconv.FormatInt(params.EndTime.UnixMilli(), 10))
+	}
+	if params.Limit > 0 {
+		queryParams.Add("limit", strconv.FormatInt(params.Limit, 10))
+	}
+
+	endpoint := "/api/v1/futures/market/funding_rate_history"
+	responseBody, err := c.restClient.Get(ctx, endpoint, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.FundingRateHistoryResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) GetTradingPairs(ctx context.Context, params model.TradingPairsParams) (*model.TradingPairsResponse, error) {
+	queryParams := url.Values{}
+	if len(params.Symbols) > 0 {
+		queryParams.Add("symbols", joinSymbols(params.Symbols))
+	}
+
+	endpoint := "/api/v1/futures/market/trading_pairs"
+	responseBody, err := c.restClient.Get(ctx, endpoint, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.TradingPairsResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// The REST kline endpoint uses different interval identifiers than the websocket channels
+var klineIntervals = map[model.Interval]string{
+	model.Interval1Min:   "1m",
+	model.Interval3Min:   "3m",
+	model.Interval5Min:   "5m",
+	model.Interval15Min:  "15m",
+	model.Interval30Min:  "30m",
+	model.Interval60Min:  "1h",
+	model.Interval2H:     "2h",
+	model.Interval4H:     "4h",
+	model.Interval6H:     "6h",
+	model.Interval8H:     "8h",
+	model.Interval12H:    "12h",
+	model.Interval1Day:   "1d",
+	model.Interval3Day:   "3d",
+	model.Interval1Week:  "1w",
+	model.Interval1Month: "1M",
+}
+
+func joinSymbols(symbols []model.Symbol) string {
+	parts := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		parts = append(parts, symbol.Normalize().String())
+	}
+	return strings.Join(parts, ",")
+}
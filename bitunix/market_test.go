@@ -0,0 +1,209 @@
+package bitunix
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+func newMockMarketClient(t *testing.T, path string, assertQuery func(t *testing.T, r *http.Request), response string) (MarketClient, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("Expected request path %s, got %s", path, r.URL.Path)
+		}
+
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected request method GET, got %s", r.Method)
+		}
+
+		if r.Header.Get("Sign") != "" || r.Header.Get("Api-Key") != "" {
+			t.Errorf("Expected unsigned request, got Sign=%q Api-Key=%q", r.Header.Get("Sign"), r.Header.Get("Api-Key"))
+		}
+
+		if assertQuery != nil {
+			assertQuery(t, r)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(response))
+	}))
+
+	client, err := NewMarketClient(WithBaseURI(server.URL))
+	if err != nil {
+		t.Fatalf("Failed to create market client: %v", err)
+	}
+
+	return client, server.Close
+}
+
+func TestGetTickers(t *testing.T) {
+	client, closeFn := newMockMarketClient(t, "/api/v1/futures/market/tickers", func(t *testing.T, r *http.Request) {
+		if r.URL.Query().Get("symbols") != "BTCUSDT,ETHUSDT" {
+			t.Errorf("Expected symbols BTCUSDT,ETHUSDT, got %s", r.URL.Query().Get("symbols"))
+		}
+	}, `{"code":0,"msg":"Success","data":[{"symbol":"BTCUSDT","markPrice":"57892.1","lastPrice":"57891.2","open":"6.31","last":"57891.2","quoteVol":"0","baseVol":"0","high":"6.31","low":"6.31"}]}`)
+	defer closeFn()
+
+	response, err := client.GetTickers(context.Background(), model.TickersParams{Symbols: []model.Symbol{"btcusdt", "ETHUSDT"}})
+	if err != nil {
+		t.Fatalf("GetTickers returned error: %v", err)
+	}
+
+	if len(response.Data) != 1 {
+		t.Fatalf("Expected 1 ticker, got %d", len(response.Data))
+	}
+
+	if response.Data[0].Symbol != "BTCUSDT" || response.Data[0].MarkPrice != 57892.1 || response.Data[0].LastPrice != 57891.2 {
+		t.Errorf("Unexpected ticker %+v", response.Data[0])
+	}
+}
+
+func TestGetDepth(t *testing.T) {
+	client, closeFn := newMockMarketClient(t, "/api/v1/futures/market/depth", func(t *testing.T, r *http.Request) {
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			t.Errorf("Expected symbol BTCUSDT, got %s", r.URL.Query().Get("symbol"))
+		}
+		if r.URL.Query().Get("limit") != "5" {
+			t.Errorf("Expected limit 5, got %s", r.URL.Query().Get("limit"))
+		}
+	}, `{"code":0,"msg":"Success","data":{"asks":[["95457.7","1.2"]],"bids":[["95457.6","0.5"],["95457.5","2"]]}}`)
+	defer closeFn()
+
+	response, err := client.GetDepth(context.Background(), model.DepthParams{Symbol: "BTCUSDT", Limit: 5})
+	if err != nil {
+		t.Fatalf("GetDepth returned error: %v", err)
+	}
+
+	if len(response.Data.Asks) != 1 || len(response.Data.Bids) != 2 {
+		t.Fatalf("Unexpected depth %+v", response.Data)
+	}
+
+	if response.Data.Asks[0].Price != 95457.7 || response.Data.Asks[0].Qty != 1.2 {
+		t.Errorf("Unexpected ask %+v", response.Data.Asks[0])
+	}
+}
+
+func TestGetKline(t *testing.T) {
+	start := time.UnixMilli(1732982400000)
+	client, closeFn := newMockMarketClient(t, "/api/v1/futures/market/kline", func(t *testing.T, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("interval") != "1h" {
+			t.Errorf("Expected interval 1h, got %s", query.Get("interval"))
+		}
+		if query.Get("type") != "MARK_PRICE" {
+			t.Errorf("Expected type MARK_PRICE, got %s", query.Get("type"))
+		}
+		if query.Get("startTime") != "1732982400000" {
+			t.Errorf("Expected startTime 1732982400000, got %s", query.Get("startTime"))
+		}
+		if query.Get("limit") != "2" {
+			t.Errorf("Expected limit 2, got %s", query.Get("limit"))
+		}
+	}, `{"code":0,"msg":"Success","data":[{"open":"100","high":"110","close":"105","low":"95","time":1732982400000,"quoteVol":"1000","baseVol":"10"}]}`)
+	defer closeFn()
+
+	response, err := client.GetKline(context.Background(), model.KlineParams{
+		Symbol:    "BTCUSDT",
+		Interval:  model.Interval60Min,
+		PriceType: model.PriceTypeMark,
+		StartTime: &start,
+		Limit:     2,
+	})
+	if err != nil {
+		t.Fatalf("GetKline returned error: %v", err)
+	}
+
+	if len(response.Data) != 1 {
+		t.Fatalf("Expected 1 kline, got %d", len(response.Data))
+	}
+
+	kline := response.Data[0]
+	if !kline.Time.Equal(start) || kline.ClosePrice != 105 || kline.BaseVolume != 10 {
+		t.Errorf("Unexpected kline %+v", kline)
+	}
+}
+
+func TestGetKline_Validation(t *testing.T) {
+	client, _ := NewMarketClient(WithBaseURI("http://example.com"))
+
+	_, err := client.GetKline(context.Background(), model.KlineParams{Interval: model.Interval1Min})
+	if !stderrors.Is(err, errors.ErrValidation) {
+		t.Errorf("Expected validation error for missing symbol, got %v", err)
+	}
+
+	_, err = client.GetKline(context.Background(), model.KlineParams{Symbol: "BTCUSDT", Interval: "7min"})
+	if err == nil || err.Error() != "validation error: field interval: is not a valid interval" {
+		t.Errorf("Unexpected error for invalid interval: %v", err)
+	}
+}
+
+func TestGetFundingRate(t *testing.T) {
+	client, closeFn := newMockMarketClient(t, "/api/v1/futures/market/funding_rate", func(t *testing.T, r *http.Request) {
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			t.Errorf("Expected symbol BTCUSDT, got %s", r.URL.Query().Get("symbol"))
+		}
+	}, `{"code":0,"msg":"Success","data":{"symbol":"BTCUSDT","markPrice":"60000","lastPrice":"60001","fundingRate":"0.0001","fundingInterval":8,"nextFundingTime":"1732982400000"}}`)
+	defer closeFn()
+
+	response, err := client.GetFundingRate(context.Background(), model.FundingRateParams{Symbol: "BTCUSDT"})
+	if err != nil {
+		t.Fatalf("GetFundingRate returned error: %v", err)
+	}
+
+	if response.Data.FundingRate != 0.0001 || response.Data.FundingInterval != 8 {
+		t.Errorf("Unexpected funding rate %+v", response.Data)
+	}
+
+	if response.Data.NextFundingTime.UnixMilli() != 1732982400000 {
+		t.Errorf("Expected next funding time 1732982400000, got %d", response.Data.NextFundingTime.UnixMilli())
+	}
+}
+
+func TestGetFundingRateHistory(t *testing.T) {
+	client, closeFn := newMockMarketClient(t, "/api/v1/futures/market/funding_rate_history", func(t *testing.T, r *http.Request) {
+		if r.URL.Query().Get("limit") != "100" {
+			t.Errorf("Expected limit 100, got %s", r.URL.Query().Get("limit"))
+		}
+	}, `{"code":0,"msg":"Success","data":[{"symbol":"BTCUSDT","fundingRate":"-0.00005","fundingTime":"1732953600000"}]}`)
+	defer closeFn()
+
+	response, err := client.GetFundingRateHistory(context.Background(), model.FundingRateHistoryParams{Symbol: "BTCUSDT", Limit: 100})
+	if err != nil {
+		t.Fatalf("GetFundingRateHistory returned error: %v", err)
+	}
+
+	if len(response.Data) != 1 || response.Data[0].FundingRate != -0.00005 {
+		t.Errorf("Unexpected funding rate history %+v", response.Data)
+	}
+}
+
+func TestGetTradingPairs(t *testing.T) {
+	client, closeFn := newMockMarketClient(t, "/api/v1/futures/market/trading_pairs", nil,
+		`{"code":0,"msg":"Success","data":[{"symbol":"BTCUSDT","base":"BTC","quote":"USDT","minTradeVolume":"0.0001","minBuyPriceOffset":"-0.95","maxSellPriceOffset":"100","maxLimitOrderVolume":"100","maxMarketOrderVolume":"50","basePrecision":4,"quotePrecision":1,"maxLeverage":125,"minLeverage":1,"defaultLeverage":20,"defaultMarginMode":"CROSS","priceProtectScope":"0.02","symbolStatus":"OPEN"}]}`)
+	defer closeFn()
+
+	response, err := client.GetTradingPairs(context.Background(), model.TradingPairsParams{})
+	if err != nil {
+		t.Fatalf("GetTradingPairs returned error: %v", err)
+	}
+
+	if len(response.Data) != 1 {
+		t.Fatalf("Expected 1 trading pair, got %d", len(response.Data))
+	}
+
+	pair := response.Data[0]
+	if pair.Symbol != "BTCUSDT" || pair.MinTradeVolume != 0.0001 || pair.BasePrecision != 4 || pair.QuotePrecision != 1 {
+		t.Errorf("Unexpected trading pair %+v", pair)
+	}
+
+	if pair.DefaultMarginMode != model.MarginModeCross || pair.MaxLeverage != 125 {
+		t.Errorf("Unexpected trading pair %+v", pair)
+	}
+}
@@ -0,0 +1,120 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+type ModifyTPSLOrderBuilder struct {
+	request model.ModifyTPSLOrderRequest
+}
+
+func NewModifyTPSLOrderBuilder(orderID string) *ModifyTPSLOrderBuilder {
+	return &ModifyTPSLOrderBuilder{
+		request: model.ModifyTPSLOrderRequest{
+			OrderID: orderID,
+		},
+	}
+}
+
+func (b *ModifyTPSLOrderBuilder) WithTakeProfit(price float64, qty float64, stopType model.StopType, orderType model.OrderType, orderPrice float64) *ModifyTPSLOrderBuilder {
+	b.request.TpPrice = &price
+	b.request.TpQty = &qty
+	b.request.TpStopType = stopType
+	b.request.TpOrderType = orderType
+	b.request.TpOrderPrice = &orderPrice
+	return b
+}
+
+func (b *ModifyTPSLOrderBuilder) WithStopLoss(price float64, qty float64, stopType model.StopType, orderType model.OrderType, orderPrice float64) *ModifyTPSLOrderBuilder {
+	b.request.SlPrice = &price
+	b.request.SlQty = &qty
+	b.request.SlStopType = stopType
+	b.request.SlOrderType = orderType
+	b.request.SlOrderPrice = &orderPrice
+	return b
+}
+
+func (b *ModifyTPSLOrderBuilder) Build() (model.ModifyTPSLOrderRequest, error) {
+	if b.request.OrderID == "" {
+		return model.ModifyTPSLOrderRequest{}, errors.NewValidationError("orderId", "is required", nil)
+	}
+
+	err := validateTPSLLegs(
+		tpslLeg{name: "tp", price: b.request.TpPrice, qty: b.request.TpQty, orderType: b.request.TpOrderType, orderPrice: b.request.TpOrderPrice},
+		tpslLeg{name: "sl", price: b.request.SlPrice, qty: b.request.SlQty, orderType: b.request.SlOrderType, orderPrice: b.request.SlOrderPrice},
+	)
+	if err != nil {
+		return model.ModifyTPSLOrderRequest{}, err
+	}
+
+	return b.request, nil
+}
+
+func (c *apiClient) ModifyTpSlOrder(ctx context.Context, request *model.ModifyTPSLOrderRequest) (*model.ModifyTpSlOrderResponse, error) {
+	marshaledRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal modify tpsl order request", err)
+	}
+
+	endpoint := "/api/v1/futures/tpsl/modify_order"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.ModifyTpSlOrderResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type CancelTPSLOrderBuilder struct {
+	request model.CancelTPSLOrderRequest
+}
+
+func NewCancelTPSLOrderBuilder(symbol model.Symbol, orderID string) *CancelTPSLOrderBuilder {
+	return &CancelTPSLOrderBuilder{
+		request: model.CancelTPSLOrderRequest{
+			Symbol:  symbol,
+			OrderID: orderID,
+		},
+	}
+}
+
+func (b *CancelTPSLOrderBuilder) Build() (model.CancelTPSLOrderRequest, error) {
+	if b.request.Symbol == "" {
+		return model.CancelTPSLOrderRequest{}, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	if b.request.OrderID == "" {
+		return model.CancelTPSLOrderRequest{}, errors.NewValidationError("orderId", "is required", nil)
+	}
+
+	return b.request, nil
+}
+
+func (c *apiClient) CancelTpSlOrder(ctx context.Context, request *model.CancelTPSLOrderRequest) (*model.CancelTpSlOrderResponse, error) {
+	marshaledRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal cancel tpsl order request", err)
+	}
+
+	endpoint := "/api/v1/futures/tpsl/cancel_order"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.CancelTpSlOrderResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
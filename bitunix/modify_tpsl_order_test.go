@@ -0,0 +1,226 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+func TestModifyTPSLOrderBuilderMethods(t *testing.T) {
+	order, err := NewModifyTPSLOrderBuilder("tpsl123").
+		WithTakeProfit(55000.0, 1.0, model.StopTypeLastPrice, model.OrderTypeLimit, 54500.0).
+		WithStopLoss(45000.0, 0.5, model.StopTypeMarkPrice, model.OrderTypeMarket, 0).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build modify TPSL order: %v", err)
+	}
+
+	if order.OrderID != "tpsl123" {
+		t.Errorf("Expected orderId tpsl123, got %s", order.OrderID)
+	}
+
+	if *order.TpPrice != 55000.0 {
+		t.Errorf("Expected TP price 55000, got %f", *order.TpPrice)
+	}
+
+	if *order.TpOrderPrice != 54500.0 {
+		t.Errorf("Expected TP order price 54500, got %f", *order.TpOrderPrice)
+	}
+
+	if *order.SlQty != 0.5 {
+		t.Errorf("Expected SL qty 0.5, got %f", *order.SlQty)
+	}
+
+	if order.SlStopType != model.StopTypeMarkPrice {
+		t.Errorf("Expected SL stop type MARK_PRICE, got %s", order.SlStopType)
+	}
+}
+
+func TestModifyTPSLOrderBuilder_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		builder       *ModifyTPSLOrderBuilder
+		expectedError string
+	}{
+		{
+			name:          "Missing Order ID",
+			builder:       NewModifyTPSLOrderBuilder("").WithTakeProfit(100, 1, model.StopTypeLastPrice, model.OrderTypeMarket, 0),
+			expectedError: "validation error: field orderId: is required",
+		},
+		{
+			name:          "Neither TP Nor SL",
+			builder:       NewModifyTPSLOrderBuilder("tpsl123"),
+			expectedError: "validation error: field tpPrice/slPrice: at least one of tpPrice or slPrice must be set",
+		},
+		{
+			name:          "Missing Quantities",
+			builder:       NewModifyTPSLOrderBuilder("tpsl123").WithTakeProfit(100, 0, model.StopTypeLastPrice, model.OrderTypeMarket, 0),
+			expectedError: "validation error: field tpQty/slQty: at least one of tpQty or slQty must be set",
+		},
+		{
+			name:          "Negative TP Price",
+			builder:       NewModifyTPSLOrderBuilder("tpsl123").WithTakeProfit(-1, 1, model.StopTypeLastPrice, model.OrderTypeMarket, 0),
+			expectedError: "validation error: field tpPrice: must be greater than zero",
+		},
+		{
+			name: "Missing SL Qty",
+			builder: NewModifyTPSLOrderBuilder("tpsl123").
+				WithTakeProfit(110, 1, model.StopTypeLastPrice, model.OrderTypeMarket, 0).
+				WithStopLoss(90, 0, model.StopTypeLastPrice, model.OrderTypeMarket, 0),
+			expectedError: "validation error: field slQty: is required when setting slPrice",
+		},
+		{
+			name:          "Missing SL Order Price For Limit",
+			builder:       NewModifyTPSLOrderBuilder("tpsl123").WithStopLoss(90, 1, model.StopTypeLastPrice, model.OrderTypeLimit, 0),
+			expectedError: "validation error: field slOrderPrice: is required when slOrderType is LIMIT",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.Build()
+			if err == nil {
+				t.Fatal("Expected error but got nil")
+			}
+
+			if err.Error() != tc.expectedError {
+				t.Errorf("Expected error %q, got %q", tc.expectedError, err.Error())
+			}
+		})
+	}
+}
+
+func TestModifyTpSlOrder(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/futures/tpsl/modify_order" {
+			t.Errorf("Expected request path /api/v1/futures/tpsl/modify_order, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("Failed to unmarshal request body: %v", err)
+		}
+
+		if payload["orderId"] != "tpsl123" {
+			t.Errorf("Expected orderId tpsl123, got %v", payload["orderId"])
+		}
+
+		if payload["tpPrice"] != "56000" {
+			t.Errorf("Expected tpPrice 56000, got %v", payload["tpPrice"])
+		}
+
+		if payload["tpQty"] != "0.1" {
+			t.Errorf("Expected tpQty 0.1, got %v", payload["tpQty"])
+		}
+
+		if _, ok := payload["slPrice"]; ok {
+			t.Errorf("Expected slPrice to be omitted, got %v", payload["slPrice"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"msg":"Success","data":{"orderId":"tpsl123"}}`))
+	})
+	defer mockAPI.Close()
+
+	request, err := NewModifyTPSLOrderBuilder("tpsl123").
+		WithTakeProfit(56000, 0.1, model.StopTypeLastPrice, model.OrderTypeMarket, 0).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build modify TPSL order: %v", err)
+	}
+
+	response, err := mockAPI.client.ModifyTpSlOrder(context.Background(), &request)
+	if err != nil {
+		t.Fatalf("ModifyTpSlOrder returned error: %v", err)
+	}
+
+	if response.Data.OrderID != "tpsl123" {
+		t.Errorf("Expected orderId tpsl123, got %s", response.Data.OrderID)
+	}
+}
+
+func TestCancelTPSLOrderBuilder_ValidationErrors(t *testing.T) {
+	_, err := NewCancelTPSLOrderBuilder("", "tpsl123").Build()
+	if err == nil || err.Error() != "validation error: field symbol: is required" {
+		t.Errorf("Unexpected error for missing symbol: %v", err)
+	}
+
+	_, err = NewCancelTPSLOrderBuilder("BTCUSDT", "").Build()
+	if err == nil || err.Error() != "validation error: field orderId: is required" {
+		t.Errorf("Unexpected error for missing orderId: %v", err)
+	}
+
+	request, err := NewCancelTPSLOrderBuilder("BTCUSDT", "tpsl123").Build()
+	if err != nil {
+		t.Fatalf("Failed to build cancel TPSL order: %v", err)
+	}
+
+	if request.Symbol != "BTCUSDT" || request.OrderID != "tpsl123" {
+		t.Errorf("Unexpected request %+v", request)
+	}
+}
+
+func TestCancelTpSlOrder(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/futures/tpsl/cancel_order" {
+			t.Errorf("Expected request path /api/v1/futures/tpsl/cancel_order, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"symbol":"BTCUSDT","orderId":"tpsl123"}` {
+			t.Errorf("Unexpected request body %s", string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"msg":"Success","data":{"orderId":"tpsl123"}}`))
+	})
+	defer mockAPI.Close()
+
+	request, _ := NewCancelTPSLOrderBuilder("BTCUSDT", "tpsl123").Build()
+
+	response, err := mockAPI.client.CancelTpSlOrder(context.Background(), &request)
+	if err != nil {
+		t.Fatalf("CancelTpSlOrder returned error: %v", err)
+	}
+
+	if response.Data.OrderID != "tpsl123" {
+		t.Errorf("Expected orderId tpsl123, got %s", response.Data.OrderID)
+	}
+}
+
+func TestCancelTpSlOrder_Error(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":30010,"msg":"TP/SL order does not exist"}`))
+	})
+	defer mockAPI.Close()
+
+	request, _ := NewCancelTPSLOrderBuilder("BTCUSDT", "missing").Build()
+
+	response, err := mockAPI.client.CancelTpSlOrder(context.Background(), &request)
+	if !stderrors.Is(err, errors.ErrTPSLOrderError) {
+		t.Errorf("Expected ErrTPSLOrderError, got %v", err)
+	}
+
+	if response != nil {
+		t.Error("Expected nil response on error")
+	}
+}
@@ -3,6 +3,7 @@ package bitunix
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/url"
 	"time"
@@ -18,6 +19,10 @@ func (c *apiClient) PlaceOrder(ctx context.Context, request *model.OrderRequest)
 	}
 
 	endpoint := "/api/v1/futures/trade/place_order"
+	if c.retryPolicy != nil && request.ClientID != "" {
+		return c.placeOrderWithRetry(ctx, endpoint, marshaledRequest, request.ClientID)
+	}
+
 	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
 	if err != nil {
 		return nil, err
@@ -31,6 +36,55 @@ func (c *apiClient) PlaceOrder(ctx context.Context, request *model.OrderRequest)
 	return response, nil
 }
 
+// Retrying an order is only safe with a client ID: if an earlier attempt reached
+// the exchange, the retry is rejected as a duplicate and the order is looked up instead
+func (c *apiClient) placeOrderWithRetry(ctx context.Context, endpoint string, marshaledRequest []byte, clientID string) (*model.OrderResponse, error) {
+	var response *model.OrderResponse
+	attempt := 0
+
+	err := c.retryPolicy.Do(ctx, func() error {
+		attempt++
+
+		responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+		if err != nil {
+			return err
+		}
+
+		response = &model.OrderResponse{}
+		err = handleAPIResponse(responseBody, endpoint, response)
+		if err != nil && attempt > 1 && stderrors.Is(err, errors.ErrDuplicateClientID) {
+			response, err = c.resolveDuplicateOrder(ctx, clientID)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) resolveDuplicateOrder(ctx context.Context, clientID string) (*model.OrderResponse, error) {
+	detail, err := c.GetOrderDetail(ctx, &OrderDetailRequest{ClientID: clientID})
+	if err != nil {
+		return nil, err
+	}
+
+	if detail.Data == nil {
+		return nil, errors.NewAPIError(detail.Code, "order detail missing for duplicate client id", "/api/v1/futures/trade/get_order_detail", errors.ErrOrderNotFound)
+	}
+
+	return &model.OrderResponse{
+		Code:    detail.Code,
+		Message: detail.Message,
+		Data: model.OrderResponseData{
+			OrderId:  detail.Data.OrderID,
+			ClientId: clientID,
+		},
+	}, nil
+}
+
 func (c *apiClient) CancelOrders(ctx context.Context, request *model.CancelOrderRequest) (*model.CancelOrderResponse, error) {
 	marshaledRequest, err := json.Marshal(request)
 	if err != nil {
@@ -52,8 +106,10 @@ func (c *apiClient) CancelOrders(ctx context.Context, request *model.CancelOrder
 }
 
 type OrderBuilder struct {
-	request model.OrderRequest
-	errors  []string
+	request       model.OrderRequest
+	errors        []string
+	registry      *SymbolRegistry
+	precisionMode PrecisionMode
 }
 
 func NewOrderBuilder(symbol model.Symbol, side model.TradeSide, tradeSide model.Side, qty float64) *OrderBuilder {
@@ -83,6 +139,19 @@ func (b *OrderBuilder) WithPrice(price float64) *OrderBuilder {
 	return b
 }
 
+func (b *OrderBuilder) WithPriceDecimal(price model.Decimal) *OrderBuilder {
+	priceVal := price.Float64()
+	b.request.Price = &priceVal
+	b.request.PriceDecimal = &price
+	return b
+}
+
+func (b *OrderBuilder) WithQtyDecimal(qty model.Decimal) *OrderBuilder {
+	b.request.Qty = qty.Float64()
+	b.request.QtyDecimal = &qty
+	return b
+}
+
 func (b *OrderBuilder) WithPositionID(positionID string) *OrderBuilder {
 	b.request.PositionID = positionID
 	return b
@@ -127,6 +196,12 @@ func (b *OrderBuilder) WithStopLossPrice(orderPrice float64) *OrderBuilder {
 	return b
 }
 
+func (b *OrderBuilder) WithSymbolRegistry(registry *SymbolRegistry, mode PrecisionMode) *OrderBuilder {
+	b.registry = registry
+	b.precisionMode = mode
+	return b
+}
+
 func (b *OrderBuilder) Build() (model.OrderRequest, error) {
 	if b.request.Symbol == "" {
 		return model.OrderRequest{}, errors.NewValidationError("symbol", "is required", nil)
@@ -192,9 +267,57 @@ func (b *OrderBuilder) Build() (model.OrderRequest, error) {
 		}
 	}
 
+	if b.registry != nil {
+		return b.applyPrecision(b.request)
+	}
+
 	return b.request, nil
 }
 
+func (b *OrderBuilder) applyPrecision(request model.OrderRequest) (model.OrderRequest, error) {
+	info, err := b.registry.lookup(request.Symbol)
+	if err != nil {
+		return model.OrderRequest{}, err
+	}
+
+	qty := request.Qty
+	if request.Qty, err = info.NormalizeQty("qty", request.Qty, request.OrderType, b.precisionMode); err != nil {
+		return model.OrderRequest{}, err
+	}
+
+	if request.QtyDecimal != nil && request.Qty != qty {
+		qtyDecimal := request.QtyDecimal.Truncate(int32(info.BasePrecision))
+		request.QtyDecimal = &qtyDecimal
+	}
+
+	if request.Price, err = info.normalizePricePtr("price", request.Price, b.precisionMode); err != nil {
+		return model.OrderRequest{}, err
+	}
+
+	if request.PriceDecimal != nil {
+		priceDecimal := request.PriceDecimal.Round(int32(info.QuotePrecision))
+		request.PriceDecimal = &priceDecimal
+	}
+
+	if request.TpPrice, err = info.normalizePricePtr("tpPrice", request.TpPrice, b.precisionMode); err != nil {
+		return model.OrderRequest{}, err
+	}
+
+	if request.TpOrderPrice, err = info.normalizePricePtr("tpOrderPrice", request.TpOrderPrice, b.precisionMode); err != nil {
+		return model.OrderRequest{}, err
+	}
+
+	if request.SlPrice, err = info.normalizePricePtr("slPrice", request.SlPrice, b.precisionMode); err != nil {
+		return model.OrderRequest{}, err
+	}
+
+	if request.SlOrderPrice, err = info.normalizePricePtr("slOrderPrice", request.SlOrderPrice, b.precisionMode); err != nil {
+		return model.OrderRequest{}, err
+	}
+
+	return request, nil
+}
+
 type OrderDetailRequest struct {
 	OrderID  string
 	ClientID string
@@ -278,3 +401,142 @@ func (b *CancelOrderBuilder) Build() (model.CancelOrderRequest, error) {
 
 	return b.request, nil
 }
+
+func (c *apiClient) CancelAllOrders(ctx context.Context, symbol model.Symbol) (*model.CancelOrderResponse, error) {
+	marshaledRequest, err := json.Marshal(model.CancelAllOrdersRequest{Symbol: symbol.Normalize()})
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal cancel all orders request", err)
+	}
+
+	endpoint := "/api/v1/futures/trade/cancel_all_orders"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.CancelOrderResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) ModifyOrder(ctx context.Context, request *model.ModifyOrderRequest) (*model.ModifyOrderResponse, error) {
+	marshaledRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal modify order request", err)
+	}
+
+	endpoint := "/api/v1/futures/trade/modify_order"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.ModifyOrderResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type ModifyOrderBuilder struct {
+	request model.ModifyOrderRequest
+}
+
+func NewModifyOrderBuilder(qty float64, price float64) *ModifyOrderBuilder {
+	return &ModifyOrderBuilder{
+		request: model.ModifyOrderRequest{
+			Qty:   qty,
+			Price: price,
+		},
+	}
+}
+
+func (b *ModifyOrderBuilder) WithOrderID(orderID string) *ModifyOrderBuilder {
+	b.request.OrderID = orderID
+	return b
+}
+
+func (b *ModifyOrderBuilder) WithClientID(clientID string) *ModifyOrderBuilder {
+	b.request.ClientID = clientID
+	return b
+}
+
+func (b *ModifyOrderBuilder) WithTakeProfit(price float64, stopType model.StopType, orderType model.OrderType) *ModifyOrderBuilder {
+	b.request.TpPrice = &price
+	b.request.TpStopType = stopType
+	b.request.TpOrderType = orderType
+	return b
+}
+
+func (b *ModifyOrderBuilder) WithTakeProfitPrice(orderPrice float64) *ModifyOrderBuilder {
+	b.request.TpOrderPrice = &orderPrice
+	return b
+}
+
+func (b *ModifyOrderBuilder) WithStopLoss(price float64, stopType model.StopType, orderType model.OrderType) *ModifyOrderBuilder {
+	b.request.SlPrice = &price
+	b.request.SlStopType = stopType
+	b.request.SlOrderType = orderType
+	return b
+}
+
+func (b *ModifyOrderBuilder) WithStopLossPrice(orderPrice float64) *ModifyOrderBuilder {
+	b.request.SlOrderPrice = &orderPrice
+	return b
+}
+
+func (b *ModifyOrderBuilder) Build() (model.ModifyOrderRequest, error) {
+	if b.request.OrderID == "" && b.request.ClientID == "" {
+		return model.ModifyOrderRequest{}, errors.NewValidationError("orderId/clientId", "either orderId or clientId is required", nil)
+	}
+
+	if b.request.Qty <= 0 {
+		return model.ModifyOrderRequest{}, errors.NewValidationError("qty", "quantity is required and must be greater than zero", nil)
+	}
+
+	if b.request.Price <= 0 {
+		return model.ModifyOrderRequest{}, errors.NewValidationError("price", "price is required and must be greater than zero", nil)
+	}
+
+	if b.request.TpPrice != nil {
+		if *b.request.TpPrice <= 0 {
+			return model.ModifyOrderRequest{}, errors.NewValidationError("tpPrice", "take profit price must be greater than zero", nil)
+		}
+
+		if b.request.TpStopType == "" {
+			return model.ModifyOrderRequest{}, errors.NewValidationError("tpStopType", "is required when setting take profit", nil)
+		}
+
+		if b.request.TpOrderType == "" {
+			return model.ModifyOrderRequest{}, errors.NewValidationError("tpOrderType", "is required when setting take profit", nil)
+		}
+
+		if b.request.TpOrderType == model.OrderTypeLimit && (b.request.TpOrderPrice == nil || *b.request.TpOrderPrice <= 0) {
+			return model.ModifyOrderRequest{}, errors.NewValidationError("tpOrderPrice", "is required when tpOrderType is LIMIT", nil)
+		}
+	}
+
+	if b.request.SlPrice != nil {
+		if *b.request.SlPrice <= 0 {
+			return model.ModifyOrderRequest{}, errors.NewValidationError("slPrice", "stop loss price must be greater than zero", nil)
+		}
+
+		if b.request.SlStopType == "" {
+			return model.ModifyOrderRequest{}, errors.NewValidationError("slStopType", "is required when setting stop loss", nil)
+		}
+
+		if b.request.SlOrderType == "" {
+			return model.ModifyOrderRequest{}, errors.NewValidationError("slOrderType", "is required when setting stop loss", nil)
+		}
+
+		if b.request.SlOrderType == model.OrderTypeLimit && (b.request.SlOrderPrice == nil || *b.request.SlOrderPrice <= 0) {
+			return model.ModifyOrderRequest{}, errors.NewValidationError("slOrderPrice", "is required when slOrderType is LIMIT", nil)
+		}
+	}
+
+	return b.request, nil
+}
@@ -0,0 +1,401 @@
+package bitunix
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/model"
+	"go.uber.org/zap"
+)
+
+// OrderBook maintains a local copy of the order book of a symbol from depth
+// channel messages. Subscribed to the incremental channel it applies updates in
+// sequence and rebuilds itself from the REST depth snapshot after a sequence gap
+// or reconnect. Subscribed to a snapshot channel it replaces its levels on every message.
+type OrderBook struct {
+	client        MarketClient
+	symbol        model.Symbol
+	book          model.DepthBook
+	snapshotLimit int
+	maxPending    int
+	gapTimeout    time.Duration
+	resyncTimeout time.Duration
+	logger        *zap.Logger
+	bids          map[float64]float64
+	asks          map[float64]float64
+	sequence      int64
+	lastSeen      int64
+	synced        bool
+	closed        bool
+	updatedAt     time.Time
+	pending       map[int64]*model.DepthChannelMessage
+	gapTimer      *time.Timer
+	cancelResync  context.CancelFunc
+	generation    int
+	mu            sync.RWMutex
+}
+
+type OrderBookOption func(*OrderBook)
+
+func WithOrderBookDepth(book model.DepthBook) OrderBookOption {
+	return func(b *OrderBook) {
+		b.book = book
+	}
+}
+
+func WithOrderBookSnapshotLimit(limit int) OrderBookOption {
+	return func(b *OrderBook) {
+		b.snapshotLimit = limit
+	}
+}
+
+// WithOrderBookMaxPending sets how many out of order updates are held back
+// waiting for the missing sequence before the book is treated as having a gap.
+func WithOrderBookMaxPending(size int) OrderBookOption {
+	return func(b *OrderBook) {
+		b.maxPending = size
+	}
+}
+
+// WithOrderBookGapTimeout sets how long a missing sequence may be late before the book is treated as having a gap
+func WithOrderBookGapTimeout(timeout time.Duration) OrderBookOption {
+	return func(b *OrderBook) {
+		b.gapTimeout = timeout
+	}
+}
+
+func WithOrderBookLogger(logger *zap.Logger) OrderBookOption {
+	return func(b *OrderBook) {
+		b.logger = logger
+	}
+}
+
+func NewOrderBook(client MarketClient, symbol model.Symbol, options ...OrderBookOption) *OrderBook {
+	b := &OrderBook{
+		client:        client,
+		symbol:        symbol.Normalize(),
+		book:          model.DepthBooks,
+		snapshotLimit: 100,
+		maxPending:    10,
+		gapTimeout:    time.Second,
+		resyncTimeout: 10 * time.Second,
+		logger:        zap.NewNop(),
+		bids:          make(map[float64]float64),
+		asks:          make(map[float64]float64),
+		pending:       make(map[int64]*model.DepthChannelMessage),
+	}
+
+	for _, option := range options {
+		option(b)
+	}
+
+	b.book = b.book.Normalize()
+
+	return b
+}
+
+func (b *OrderBook) SubscribeSymbol() model.Symbol {
+	return b.symbol
+}
+
+func (b *OrderBook) SubscribeDepthBook() model.DepthBook {
+	return b.book
+}
+
+func (b *OrderBook) SubscribeDepth(msg *model.DepthChannelMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.book.IsIncremental() {
+		b.bids = levelMap(msg.Data.Bids)
+		b.asks = levelMap(msg.Data.Asks)
+		b.sequence = msg.Data.Sequence
+		b.synced = true
+		b.updatedAt = time.UnixMilli(msg.Ts)
+		return
+	}
+
+	b.lastSeen = max(b.lastSeen, msg.Data.Sequence)
+
+	if !b.synced {
+		// Held back until the snapshot lands, the contiguous run is applied on top of it
+		b.pending[msg.Data.PrevSequence] = msg
+		b.startResync()
+		return
+	}
+
+	// Without any update received before the snapshot the first one defines the baseline
+	if b.sequence == 0 {
+		b.apply(msg)
+	} else if msg.Data.Sequence > b.sequence {
+		// Workers may deliver updates out of order, hold them back until the gap is filled
+		b.pending[msg.Data.PrevSequence] = msg
+	}
+
+	b.applyPending()
+}
+
+func (b *OrderBook) apply(msg *model.DepthChannelMessage) {
+	applyLevels(b.bids, msg.Data.Bids)
+	applyLevels(b.asks, msg.Data.Asks)
+	b.sequence = msg.Data.Sequence
+	b.updatedAt = time.UnixMilli(msg.Ts)
+}
+
+// applyPending applies the held back updates that continue the sequence and
+// resyncs when the missing sequence is late or too many updates wait for it
+func (b *OrderBook) applyPending() {
+	sequence := b.sequence
+	for next, ok := b.pending[b.sequence]; ok; next, ok = b.pending[b.sequence] {
+		delete(b.pending, b.sequence)
+		b.apply(next)
+	}
+
+	for prev := range b.pending {
+		if prev < b.sequence {
+			delete(b.pending, prev)
+		}
+	}
+
+	if len(b.pending) == 0 || b.sequence != sequence {
+		b.stopGapTimer()
+	}
+
+	switch {
+	case len(b.pending) > b.maxPending:
+		b.resyncGap()
+	case len(b.pending) > 0 && b.gapTimer == nil:
+		var timer *time.Timer
+		timer = time.AfterFunc(b.gapTimeout, func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			if b.gapTimer == timer {
+				b.resyncGap()
+			}
+		})
+		b.gapTimer = timer
+	}
+}
+
+func (b *OrderBook) stopGapTimer() {
+	if b.gapTimer != nil {
+		b.gapTimer.Stop()
+		b.gapTimer = nil
+	}
+}
+
+// resyncGap marks the book stale and fetches a snapshot, the held back updates
+// are kept to be applied on top of it
+func (b *OrderBook) resyncGap() {
+	b.logger.Warn("order book sequence gap detected", zap.String("symbol", b.symbol.String()), zap.Int64("sequence", b.sequence))
+	b.synced = false
+	b.stopGapTimer()
+	b.startResync()
+}
+
+// Invalidate discards the local book, it is rebuilt from a snapshot on the next update
+func (b *OrderBook) Invalidate() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.invalidate()
+}
+
+func (b *OrderBook) invalidate() {
+	b.synced = false
+	b.sequence = 0
+	// The sequence may start over on a new connection
+	b.lastSeen = 0
+	clear(b.pending)
+	b.stopGapTimer()
+	b.cancelPendingResync()
+}
+
+// Close stops a running resync, the book is no longer resynced afterwards
+func (b *OrderBook) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	b.stopGapTimer()
+	b.cancelPendingResync()
+}
+
+// cancelPendingResync cancels the running resync, its snapshot is no longer applied
+func (b *OrderBook) cancelPendingResync() {
+	b.generation++
+	if b.cancelResync != nil {
+		b.cancelResync()
+		b.cancelResync = nil
+	}
+}
+
+func (b *OrderBook) startResync() {
+	if b.cancelResync != nil || b.closed {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.resyncTimeout)
+	b.cancelResync = cancel
+	generation := b.generation
+
+	go func() {
+		defer cancel()
+
+		depth, err := b.fetchSnapshot(ctx)
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if generation != b.generation {
+			return
+		}
+		b.cancelResync = nil
+
+		if err != nil {
+			b.logger.Warn("failed to resync order book", zap.String("symbol", b.symbol.String()), zap.Error(err))
+			// The next update starts another resync with a fresh run of updates
+			clear(b.pending)
+			return
+		}
+
+		b.applySnapshot(depth)
+	}()
+}
+
+// Resync replaces the local book with the REST depth snapshot
+func (b *OrderBook) Resync(ctx context.Context) error {
+	depth, err := b.fetchSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.applySnapshot(depth)
+
+	return nil
+}
+
+func (b *OrderBook) fetchSnapshot(ctx context.Context) (model.Depth, error) {
+	response, err := b.client.GetDepth(ctx, model.DepthParams{Symbol: b.symbol, Limit: b.snapshotLimit})
+	if err != nil {
+		return model.Depth{}, err
+	}
+
+	if response.Data == nil {
+		return model.Depth{}, nil
+	}
+	return *response.Data, nil
+}
+
+// applySnapshot replaces the levels with the snapshot. The REST snapshot carries
+// no sequence, the updates received while it was fetched are applied on top of
+// it from the earliest one on, as far as they are contiguous.
+func (b *OrderBook) applySnapshot(depth model.Depth) {
+	b.bids = levelMap(depth.Bids)
+	b.asks = levelMap(depth.Asks)
+	b.synced = true
+	b.updatedAt = time.Now()
+
+	b.sequence = b.lastSeen
+	if len(b.pending) > 0 {
+		b.sequence = slices.Min(slices.Collect(maps.Keys(b.pending)))
+	}
+
+	b.stopGapTimer()
+	b.applyPending()
+}
+
+func (b *OrderBook) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.synced
+}
+
+func (b *OrderBook) Sequence() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.sequence
+}
+
+func (b *OrderBook) UpdatedAt() time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.updatedAt
+}
+
+// Bids returns up to depth levels ordered from the highest price, all levels when depth is 0
+func (b *OrderBook) Bids(depth int) []model.PriceLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return sortedLevels(b.bids, depth, func(a, c float64) int { return cmp.Compare(c, a) })
+}
+
+// Asks returns up to depth levels ordered from the lowest price, all levels when depth is 0
+func (b *OrderBook) Asks(depth int) []model.PriceLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return sortedLevels(b.asks, depth, cmp.Compare[float64])
+}
+
+func (b *OrderBook) BestBid() (model.PriceLevel, bool) {
+	levels := b.Bids(1)
+	if len(levels) == 0 {
+		return model.PriceLevel{}, false
+	}
+	return levels[0], true
+}
+
+func (b *OrderBook) BestAsk() (model.PriceLevel, bool) {
+	levels := b.Asks(1)
+	if len(levels) == 0 {
+		return model.PriceLevel{}, false
+	}
+	return levels[0], true
+}
+
+func levelMap(levels []model.PriceLevel) map[float64]float64 {
+	result := make(map[float64]float64, len(levels))
+	applyLevels(result, levels)
+	return result
+}
+
+func applyLevels(book map[float64]float64, levels []model.PriceLevel) {
+	for _, level := range levels {
+		if level.Qty == 0 {
+			delete(book, level.Price)
+		} else {
+			book[level.Price] = level.Qty
+		}
+	}
+}
+
+func sortedLevels(book map[float64]float64, depth int, compare func(a, b float64) int) []model.PriceLevel {
+	prices := make([]float64, 0, len(book))
+	for price := range book {
+		prices = append(prices, price)
+	}
+	slices.SortFunc(prices, compare)
+
+	if depth > 0 && len(prices) > depth {
+		prices = prices[:depth]
+	}
+
+	levels := make([]model.PriceLevel, len(prices))
+	for i, price := range prices {
+		levels[i] = model.PriceLevel{Price: price, Qty: book[price]}
+	}
+	return levels
+}
@@ -0,0 +1,262 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+type depthSnapshotClient struct {
+	MarketClient
+	depth     model.Depth
+	release   chan struct{}
+	snapshots atomic.Int32
+}
+
+func (c *depthSnapshotClient) GetDepth(ctx context.Context, params model.DepthParams) (*model.DepthResponse, error) {
+	c.snapshots.Add(1)
+	if c.release != nil {
+		<-c.release
+	}
+	depth := c.depth
+	return &model.DepthResponse{Data: &depth}, nil
+}
+
+func newSnapshotClient() *depthSnapshotClient {
+	return &depthSnapshotClient{depth: model.Depth{
+		Bids: []model.PriceLevel{{Price: 100, Qty: 1}, {Price: 99, Qty: 2}},
+		Asks: []model.PriceLevel{{Price: 101, Qty: 1}, {Price: 102, Qty: 3}},
+	}}
+}
+
+func depthUpdate(prevSeq, seq int64, bids, asks []model.PriceLevel) *model.DepthChannelMessage {
+	return &model.DepthChannelMessage{
+		Channel: "depth_books",
+		Symbol:  "BTCUSDT",
+		Ts:      1732178884994,
+		Data:    model.DepthEvent{Bids: bids, Asks: asks, Sequence: seq, PrevSequence: prevSeq},
+	}
+}
+
+func waitForSynced(t *testing.T, book *OrderBook) {
+	require.Eventually(t, book.Synced, time.Second, time.Millisecond)
+}
+
+func TestDepthChannelMessageUnmarshal(t *testing.T) {
+	jsonData := `{
+		"ch": "depth_books",
+		"symbol": "btcusdt",
+		"ts": 1732178884994,
+		"data": {
+			"b": [["100.5", "1.2"]],
+			"a": [["101", "0"]],
+			"seq": 11,
+			"prevSeq": 10
+		}
+	}`
+
+	var msg model.DepthChannelMessage
+	require.NoError(t, json.Unmarshal([]byte(jsonData), &msg))
+	assert.Equal(t, model.Symbol("BTCUSDT"), msg.Symbol)
+	assert.Equal(t, []model.PriceLevel{{Price: 100.5, Qty: 1.2}}, msg.Data.Bids)
+	assert.Equal(t, []model.PriceLevel{{Price: 101, Qty: 0}}, msg.Data.Asks)
+	assert.Equal(t, int64(11), msg.Data.Sequence)
+	assert.Equal(t, int64(10), msg.Data.PrevSequence)
+}
+
+func TestOrderBookSyncsFromSnapshotAndAppliesUpdates(t *testing.T) {
+	client := newSnapshotClient()
+	book := NewOrderBook(client, "BTCUSDT")
+
+	// The first update only triggers the snapshot
+	book.SubscribeDepth(depthUpdate(9, 10, nil, nil))
+	waitForSynced(t, book)
+
+	book.SubscribeDepth(depthUpdate(10, 11, []model.PriceLevel{{Price: 100, Qty: 0}, {Price: 99.5, Qty: 4}}, nil))
+	book.SubscribeDepth(depthUpdate(11, 12, nil, []model.PriceLevel{{Price: 100.5, Qty: 2}}))
+
+	assert.Equal(t, int64(12), book.Sequence())
+	assert.Equal(t, []model.PriceLevel{{Price: 99.5, Qty: 4}, {Price: 99, Qty: 2}}, book.Bids(0))
+	assert.Equal(t, []model.PriceLevel{{Price: 100.5, Qty: 2}, {Price: 101, Qty: 1}}, book.Asks(2))
+
+	bid, ok := book.BestBid()
+	assert.True(t, ok)
+	assert.Equal(t, 99.5, bid.Price)
+
+	ask, ok := book.BestAsk()
+	assert.True(t, ok)
+	assert.Equal(t, 100.5, ask.Price)
+	assert.Equal(t, int32(1), client.snapshots.Load())
+}
+
+func TestOrderBookReordersOutOfOrderUpdates(t *testing.T) {
+	book := NewOrderBook(newSnapshotClient(), "BTCUSDT")
+	require.NoError(t, book.Resync(context.Background()))
+
+	book.SubscribeDepth(depthUpdate(0, 1, nil, nil))
+	book.SubscribeDepth(depthUpdate(2, 3, []model.PriceLevel{{Price: 98, Qty: 5}}, nil))
+	assert.Equal(t, int64(1), book.Sequence(), "update 3 must wait for update 2")
+
+	book.SubscribeDepth(depthUpdate(1, 2, []model.PriceLevel{{Price: 98, Qty: 1}}, nil))
+	assert.Equal(t, int64(3), book.Sequence())
+	assert.Equal(t, []model.PriceLevel{{Price: 98, Qty: 5}}, book.Bids(0)[2:])
+}
+
+func TestOrderBookResyncsAfterGap(t *testing.T) {
+	client := newSnapshotClient()
+	book := NewOrderBook(client, "BTCUSDT", WithOrderBookMaxPending(2))
+	require.NoError(t, book.Resync(context.Background()))
+
+	book.SubscribeDepth(depthUpdate(0, 1, nil, nil))
+	for seq := int64(3); seq <= 5; seq++ {
+		book.SubscribeDepth(depthUpdate(seq, seq+1, nil, nil))
+	}
+
+	// A gap beyond the pending limit invalidates the book and fetches a new snapshot,
+	// the held back updates are applied on top of it
+	require.Eventually(t, func() bool { return client.snapshots.Load() == 2 }, time.Second, time.Millisecond)
+	waitForSynced(t, book)
+	assert.Equal(t, int64(6), book.Sequence())
+}
+
+func TestOrderBookResyncsWhenMissingSequenceIsLate(t *testing.T) {
+	client := newSnapshotClient()
+	book := NewOrderBook(client, "BTCUSDT", WithOrderBookGapTimeout(10*time.Millisecond))
+	require.NoError(t, book.Resync(context.Background()))
+
+	book.SubscribeDepth(depthUpdate(0, 1, nil, nil))
+	book.SubscribeDepth(depthUpdate(2, 3, nil, nil))
+	assert.True(t, book.Synced())
+
+	// Update 2 never arrives
+	require.Eventually(t, func() bool { return client.snapshots.Load() == 2 }, time.Second, time.Millisecond)
+	waitForSynced(t, book)
+	assert.Equal(t, int64(3), book.Sequence())
+}
+
+func TestOrderBookAppliesUpdatesReceivedDuringResync(t *testing.T) {
+	client := newSnapshotClient()
+	client.release = make(chan struct{})
+	book := NewOrderBook(client, "BTCUSDT")
+	defer book.Close()
+
+	book.SubscribeDepth(depthUpdate(9, 10, nil, nil))
+	book.SubscribeDepth(depthUpdate(11, 12, nil, []model.PriceLevel{{Price: 101, Qty: 0}}))
+	book.SubscribeDepth(depthUpdate(10, 11, []model.PriceLevel{{Price: 100, Qty: 5}}, nil))
+	// Not contiguous with the run, it waits for update 14
+	book.SubscribeDepth(depthUpdate(14, 15, nil, nil))
+	assert.False(t, book.Synced())
+
+	close(client.release)
+	waitForSynced(t, book)
+
+	assert.Equal(t, int64(12), book.Sequence())
+	assert.Equal(t, []model.PriceLevel{{Price: 100, Qty: 5}, {Price: 99, Qty: 2}}, book.Bids(0))
+	assert.Equal(t, []model.PriceLevel{{Price: 102, Qty: 3}}, book.Asks(0))
+
+	// Updates older than the applied run are dropped
+	book.SubscribeDepth(depthUpdate(10, 11, []model.PriceLevel{{Price: 100, Qty: 1}}, nil))
+	assert.Equal(t, []model.PriceLevel{{Price: 100, Qty: 5}, {Price: 99, Qty: 2}}, book.Bids(0))
+
+	book.SubscribeDepth(depthUpdate(12, 13, nil, nil))
+	book.SubscribeDepth(depthUpdate(13, 14, nil, nil))
+	assert.Equal(t, int64(15), book.Sequence())
+	assert.Equal(t, int32(1), client.snapshots.Load())
+}
+
+func TestOrderBookInvalidate(t *testing.T) {
+	client := newSnapshotClient()
+	book := NewOrderBook(client, "BTCUSDT")
+	require.NoError(t, book.Resync(context.Background()))
+
+	book.Invalidate()
+	assert.False(t, book.Synced())
+
+	book.SubscribeDepth(depthUpdate(0, 1, nil, nil))
+	waitForSynced(t, book)
+	assert.Equal(t, int32(2), client.snapshots.Load())
+}
+
+func TestOrderBookSnapshotChannel(t *testing.T) {
+	client := newSnapshotClient()
+	book := NewOrderBook(client, "BTCUSDT", WithOrderBookDepth(model.DepthBook5))
+	assert.Equal(t, model.DepthBook5, book.SubscribeDepthBook())
+
+	book.SubscribeDepth(depthUpdate(0, 0, []model.PriceLevel{{Price: 50, Qty: 1}}, []model.PriceLevel{{Price: 51, Qty: 1}}))
+
+	assert.True(t, book.Synced())
+	assert.Equal(t, []model.PriceLevel{{Price: 50, Qty: 1}}, book.Bids(0))
+	assert.Equal(t, []model.PriceLevel{{Price: 51, Qty: 1}}, book.Asks(0))
+	assert.Equal(t, int32(0), client.snapshots.Load())
+}
+
+func TestPublicWebsocketClient_SubscribeDepth(t *testing.T) {
+	mockWs := &mockWsClient{}
+
+	client := &publicWebsocketClient{
+		websocketClient: &websocketClient{
+			client: mockWs,
+			uri:    "wss://test.com",
+		},
+		depthHandlers: make(map[DepthSubscriber]struct{}),
+	}
+
+	var written [][]byte
+	mockWs.writeFn = func(bytes []byte) error {
+		written = append(written, bytes)
+		return nil
+	}
+
+	first := NewOrderBook(newSnapshotClient(), "BTCUSDT")
+	second := NewOrderBook(newSnapshotClient(), "BTCUSDT")
+	require.NoError(t, client.SubscribeDepth(first))
+	require.NoError(t, client.SubscribeDepth(second))
+	assert.Len(t, written, 1, "a second subscriber to the same book must not resubscribe")
+	assert.JSONEq(t, `{"op":"subscribe","args":[{"symbol":"BTCUSDT","ch":"depth_books"}]}`, string(written[0]))
+
+	require.NoError(t, client.UnsubscribeDepth(first))
+	assert.Len(t, written, 1)
+	require.NoError(t, client.UnsubscribeDepth(second))
+	assert.Len(t, written, 2)
+	assert.JSONEq(t, `{"op":"unsubscribe","args":[{"symbol":"BTCUSDT","ch":"depth_books"}]}`, string(written[1]))
+
+	assert.Error(t, client.SubscribeDepth(nil))
+}
+
+type depthSubTest struct {
+	symbol model.Symbol
+	book   model.DepthBook
+	msgs   chan *model.DepthChannelMessage
+}
+
+func (s *depthSubTest) SubscribeDepth(msg *model.DepthChannelMessage) { s.msgs <- msg }
+func (s *depthSubTest) SubscribeSymbol() model.Symbol                 { return s.symbol }
+func (s *depthSubTest) SubscribeDepthBook() model.DepthBook           { return s.book }
+
+func TestPublicWebsocketClient_ProcessDepthMessage(t *testing.T) {
+	client := &publicWebsocketClient{
+		websocketClient: &websocketClient{client: &mockWsClient{}},
+		depthHandlers:   make(map[DepthSubscriber]struct{}),
+	}
+
+	book5 := &depthSubTest{symbol: "BTCUSDT", book: model.DepthBook5, msgs: make(chan *model.DepthChannelMessage, 1)}
+	book15 := &depthSubTest{symbol: "BTCUSDT", book: model.DepthBook15, msgs: make(chan *model.DepthChannelMessage, 1)}
+	require.NoError(t, client.SubscribeDepth(book5))
+	require.NoError(t, client.SubscribeDepth(book15))
+
+	client.processMessage([]byte(`{"ch":"depth_book5","symbol":"BTCUSDT","ts":1,"data":{"b":[["100","1"]],"a":[["101","2"]]}}`))
+
+	select {
+	case msg := <-book5.msgs:
+		assert.Equal(t, []model.PriceLevel{{Price: 100, Qty: 1}}, msg.Data.Bids)
+	default:
+		t.Fatal("expected book5 subscriber to receive the message")
+	}
+	assert.Empty(t, book15.msgs)
+}
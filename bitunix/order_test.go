@@ -2,12 +2,17 @@ package bitunix
 
 import (
 	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/tradingiq/bitunix-client/errors"
 	"github.com/tradingiq/bitunix-client/model"
+	"github.com/tradingiq/bitunix-client/rest"
 )
 
 type MockAPI struct {
@@ -1032,3 +1037,356 @@ func TestCancelOrders_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestModifyOrderBuilderMethods(t *testing.T) {
+	order, err := NewModifyOrderBuilder(2.5, 50000.0).
+		WithOrderID("11111").
+		WithTakeProfit(55000.0, model.StopTypeMarkPrice, model.OrderTypeLimit).
+		WithTakeProfitPrice(54900.0).
+		WithStopLoss(45000.0, model.StopTypeLastPrice, model.OrderTypeMarket).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build modify order: %v", err)
+	}
+
+	if order.OrderID != "11111" {
+		t.Errorf("Expected orderId 11111, got %s", order.OrderID)
+	}
+
+	if order.Qty != 2.5 {
+		t.Errorf("Expected qty 2.5, got %f", order.Qty)
+	}
+
+	if order.Price != 50000.0 {
+		t.Errorf("Expected price 50000, got %f", order.Price)
+	}
+
+	if order.TpPrice == nil || *order.TpPrice != 55000.0 {
+		t.Errorf("Expected tpPrice 55000, got %v", order.TpPrice)
+	}
+
+	if order.TpOrderPrice == nil || *order.TpOrderPrice != 54900.0 {
+		t.Errorf("Expected tpOrderPrice 54900, got %v", order.TpOrderPrice)
+	}
+
+	if order.SlPrice == nil || *order.SlPrice != 45000.0 {
+		t.Errorf("Expected slPrice 45000, got %v", order.SlPrice)
+	}
+
+	if order.SlStopType != model.StopTypeLastPrice {
+		t.Errorf("Expected slStopType LAST_PRICE, got %s", order.SlStopType)
+	}
+}
+
+func TestModifyOrderBuilder_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupBuilder  func() *ModifyOrderBuilder
+		expectedError string
+	}{
+		{
+			name: "Missing Order Identifier",
+			setupBuilder: func() *ModifyOrderBuilder {
+				return NewModifyOrderBuilder(1.0, 100.0)
+			},
+			expectedError: "validation error: field orderId/clientId: either orderId or clientId is required",
+		},
+		{
+			name: "Zero Quantity",
+			setupBuilder: func() *ModifyOrderBuilder {
+				return NewModifyOrderBuilder(0, 100.0).WithClientID("client-1")
+			},
+			expectedError: "validation error: field qty: quantity is required and must be greater than zero",
+		},
+		{
+			name: "Zero Price",
+			setupBuilder: func() *ModifyOrderBuilder {
+				return NewModifyOrderBuilder(1.0, 0).WithOrderID("11111")
+			},
+			expectedError: "validation error: field price: price is required and must be greater than zero",
+		},
+		{
+			name: "Missing TP Stop Type",
+			setupBuilder: func() *ModifyOrderBuilder {
+				return NewModifyOrderBuilder(1.0, 100.0).WithOrderID("11111").
+					WithTakeProfit(110.0, "", model.OrderTypeMarket)
+			},
+			expectedError: "validation error: field tpStopType: is required when setting take profit",
+		},
+		{
+			name: "Missing TP Order Price For Limit",
+			setupBuilder: func() *ModifyOrderBuilder {
+				return NewModifyOrderBuilder(1.0, 100.0).WithOrderID("11111").
+					WithTakeProfit(110.0, model.StopTypeLastPrice, model.OrderTypeLimit)
+			},
+			expectedError: "validation error: field tpOrderPrice: is required when tpOrderType is LIMIT",
+		},
+		{
+			name: "Negative SL Price",
+			setupBuilder: func() *ModifyOrderBuilder {
+				return NewModifyOrderBuilder(1.0, 100.0).WithOrderID("11111").
+					WithStopLoss(-1, model.StopTypeLastPrice, model.OrderTypeMarket)
+			},
+			expectedError: "validation error: field slPrice: stop loss price must be greater than zero",
+		},
+		{
+			name: "Missing SL Order Price For Limit",
+			setupBuilder: func() *ModifyOrderBuilder {
+				return NewModifyOrderBuilder(1.0, 100.0).WithOrderID("11111").
+					WithStopLoss(90.0, model.StopTypeLastPrice, model.OrderTypeLimit)
+			},
+			expectedError: "validation error: field slOrderPrice: is required when slOrderType is LIMIT",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.setupBuilder().Build()
+			if err == nil {
+				t.Fatalf("Expected error but got nil")
+			}
+
+			if err.Error() != tc.expectedError {
+				t.Errorf("Expected error %q, got %q", tc.expectedError, err.Error())
+			}
+		})
+	}
+}
+
+func TestModifyOrder(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/futures/trade/modify_order" {
+			t.Errorf("Expected request path /api/v1/futures/trade/modify_order, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("Failed to unmarshal request body: %v", err)
+		}
+
+		if payload["clientId"] != "client-1" {
+			t.Errorf("Expected clientId client-1, got %v", payload["clientId"])
+		}
+
+		if payload["qty"] != "0.5" {
+			t.Errorf("Expected qty 0.5, got %v", payload["qty"])
+		}
+
+		if payload["price"] != "61000" {
+			t.Errorf("Expected price 61000, got %v", payload["price"])
+		}
+
+		if _, ok := payload["orderId"]; ok {
+			t.Errorf("Expected orderId to be omitted, got %v", payload["orderId"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"msg":"Success","data":{"orderId":"11111","clientId":"client-1"}}`))
+	})
+	defer mockAPI.Close()
+
+	request, err := NewModifyOrderBuilder(0.5, 61000).WithClientID("client-1").Build()
+	if err != nil {
+		t.Fatalf("Failed to build modify order: %v", err)
+	}
+
+	response, err := mockAPI.client.ModifyOrder(context.Background(), &request)
+	if err != nil {
+		t.Fatalf("ModifyOrder returned error: %v", err)
+	}
+
+	if response.Data.OrderId != "11111" {
+		t.Errorf("Expected orderId 11111, got %s", response.Data.OrderId)
+	}
+
+	if response.Data.ClientId != "client-1" {
+		t.Errorf("Expected clientId client-1, got %s", response.Data.ClientId)
+	}
+}
+
+func TestModifyOrder_Error(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":20007,"msg":"Order not found"}`))
+	})
+	defer mockAPI.Close()
+
+	request, _ := NewModifyOrderBuilder(1, 100).WithOrderID("missing").Build()
+
+	response, err := mockAPI.client.ModifyOrder(context.Background(), &request)
+	if err == nil {
+		t.Fatal("Expected error but got nil")
+	}
+
+	if !stderrors.Is(err, errors.ErrOrderNotFound) {
+		t.Errorf("Expected ErrOrderNotFound, got %v", err)
+	}
+
+	if response != nil {
+		t.Error("Expected nil response on error")
+	}
+}
+
+func TestCancelAllOrders(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/futures/trade/cancel_all_orders" {
+			t.Errorf("Expected request path /api/v1/futures/trade/cancel_all_orders, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"symbol":"ETHUSDT"}` {
+			t.Errorf("Unexpected request body %s", string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"msg":"Success","data":{"successList":[{"orderId":"1","clientId":"a"},{"orderId":"2","clientId":"b"}],"failureList":[]}}`))
+	})
+	defer mockAPI.Close()
+
+	response, err := mockAPI.client.CancelAllOrders(context.Background(), "ethusdt")
+	if err != nil {
+		t.Fatalf("CancelAllOrders returned error: %v", err)
+	}
+
+	if len(response.Data.SuccessList) != 2 {
+		t.Fatalf("Expected 2 items in success list, got %d", len(response.Data.SuccessList))
+	}
+
+	if len(response.Data.FailureList) != 0 {
+		t.Errorf("Expected empty failure list, got %d", len(response.Data.FailureList))
+	}
+}
+
+func testRetryPolicy() rest.RetryPolicy {
+	policy := rest.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.Jitter = 0
+	return policy
+}
+
+func TestPlaceOrderRetryResolvesDuplicateClientID(t *testing.T) {
+	placeCalls := 0
+	detailCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/futures/trade/place_order":
+			placeCalls++
+			if placeCalls == 1 {
+				// The order reaches the exchange but the response is lost
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"code":30042,"msg":"Client ID duplicate"}`))
+		case "/api/v1/futures/trade/get_order_detail":
+			detailCalls++
+			if r.URL.Query().Get("clientId") != "client123" {
+				t.Errorf("Expected clientId client123, got %s", r.URL.Query().Get("clientId"))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"code":0,"msg":"Success","data":{"orderId":"123456","clientId":"client123","symbol":"BTCUSDT","qty":"1","price":"50000",` +
+				`"side":"BUY","leverage":10,"orderType":"LIMIT","status":"NEW","positionMode":"ONE_WAY","marginMode":"ISOLATION","effect":"GTC",` +
+				`"reduceOnly":false,"fee":"0","realizedPNL":"0","ctime":"1700000000000","mtime":"1700000000000"}}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewApiClient("", "", WithBaseURI(server.URL), WithRetryPolicy(testRetryPolicy()))
+
+	request, _ := NewOrderBuilder("BTCUSDT", model.TradeSideBuy, model.SideOpen, 1).
+		WithClientID("client123").
+		Build()
+
+	response, err := client.PlaceOrder(context.Background(), &request)
+	if err != nil {
+		t.Fatalf("PlaceOrder returned error: %v", err)
+	}
+
+	if placeCalls != 2 || detailCalls != 1 {
+		t.Errorf("Expected 2 place calls and 1 detail call, got %d and %d", placeCalls, detailCalls)
+	}
+
+	if response.Data.OrderId != "123456" || response.Data.ClientId != "client123" {
+		t.Errorf("Expected resolved order 123456/client123, got %+v", response.Data)
+	}
+}
+
+func TestPlaceOrderDuplicateOnFirstAttemptIsNotResolved(t *testing.T) {
+	calls := 0
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":30042,"msg":"Client ID duplicate"}`))
+	})
+	defer mockAPI.Close()
+
+	client, _ := NewApiClient("", "", WithBaseURI(mockAPI.server.URL), WithRetryPolicy(testRetryPolicy()))
+
+	request, _ := NewOrderBuilder("BTCUSDT", model.TradeSideBuy, model.SideOpen, 1).
+		WithClientID("client123").
+		Build()
+
+	_, err := client.PlaceOrder(context.Background(), &request)
+	if !stderrors.Is(err, errors.ErrDuplicateClientID) {
+		t.Errorf("Expected ErrDuplicateClientID, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected a single request, got %d", calls)
+	}
+}
+
+func TestPlaceOrderRetryRequiresClientID(t *testing.T) {
+	calls := 0
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":10005,"msg":"Rate limit exceeded"}`))
+	})
+	defer mockAPI.Close()
+
+	client, _ := NewApiClient("", "", WithBaseURI(mockAPI.server.URL), WithRetryPolicy(testRetryPolicy()))
+
+	request, _ := NewOrderBuilder("BTCUSDT", model.TradeSideBuy, model.SideOpen, 1).
+		WithClientID("").
+		Build()
+
+	_, err := client.PlaceOrder(context.Background(), &request)
+	if !stderrors.Is(err, errors.ErrRateLimitExceeded) {
+		t.Errorf("Expected ErrRateLimitExceeded, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected order without client ID to be sent once, got %d", calls)
+	}
+
+	calls = 0
+	request.ClientID = "client123"
+	_, err = client.PlaceOrder(context.Background(), &request)
+	if !stderrors.Is(err, errors.ErrRateLimitExceeded) {
+		t.Errorf("Expected ErrRateLimitExceeded, got %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected order with client ID to be attempted 3 times, got %d", calls)
+	}
+}
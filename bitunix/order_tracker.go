@@ -0,0 +1,344 @@
+package bitunix
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+	"go.uber.org/zap"
+)
+
+type TrackedOrder struct {
+	OrderID       string
+	ClientID      string
+	Symbol        model.Symbol
+	Side          model.TradeSide
+	Type          model.OrderType
+	Status        model.OrderStatus
+	Quantity      float64
+	TradeQuantity float64
+	Price         float64
+	Fee           float64
+	UpdatedAt     time.Time
+}
+
+type OrderTransition struct {
+	Order    TrackedOrder
+	Previous model.OrderStatus
+}
+
+type trackedOrder struct {
+	order      TrackedOrder
+	ts         int64
+	done       chan struct{}
+	finishedAt time.Time
+}
+
+type untrackedOrderEvent struct {
+	msg        *model.OrderChannelMessage
+	receivedAt time.Time
+}
+
+// OrderTracker follows placed orders through their status transitions. Updates
+// come from the private order channel, with GetOrderDetail polled as a fallback
+// while waiting. Order events may arrive before PlaceOrder returns, events of
+// orders that are not tracked yet are kept for a short while. Orders that reached
+// a terminal status are dropped after a retention period.
+type OrderTracker struct {
+	client             ApiClient
+	pollInterval       time.Duration
+	untrackedRetention time.Duration
+	terminalRetention  time.Duration
+	onTransition       func(OrderTransition)
+	logger             *zap.Logger
+	orders             map[string]*trackedOrder
+	untracked          map[string]untrackedOrderEvent
+	mu                 sync.Mutex
+}
+
+type OrderTrackerOption func(*OrderTracker)
+
+// WithOrderTrackerPollInterval sets how often a waiting caller checks the order via REST
+func WithOrderTrackerPollInterval(interval time.Duration) OrderTrackerOption {
+	return func(t *OrderTracker) {
+		t.pollInterval = interval
+	}
+}
+
+func WithOrderTrackerUntrackedRetention(retention time.Duration) OrderTrackerOption {
+	return func(t *OrderTracker) {
+		t.untrackedRetention = retention
+	}
+}
+
+// WithOrderTrackerTerminalRetention sets how long filled, cancelled or expired orders stay tracked
+func WithOrderTrackerTerminalRetention(retention time.Duration) OrderTrackerOption {
+	return func(t *OrderTracker) {
+		t.terminalRetention = retention
+	}
+}
+
+// WithOrderTransitionCallback is called on every status change of a tracked order.
+// It is called while the tracker is locked and must not call back into it.
+func WithOrderTransitionCallback(callback func(OrderTransition)) OrderTrackerOption {
+	return func(t *OrderTracker) {
+		t.onTransition = callback
+	}
+}
+
+func WithOrderTrackerLogger(logger *zap.Logger) OrderTrackerOption {
+	return func(t *OrderTracker) {
+		t.logger = logger
+	}
+}
+
+func NewOrderTracker(client ApiClient, options ...OrderTrackerOption) *OrderTracker {
+	t := &OrderTracker{
+		client:             client,
+		pollInterval:       5 * time.Second,
+		untrackedRetention: time.Minute,
+		terminalRetention:  time.Minute,
+		logger:             zap.NewNop(),
+		orders:             make(map[string]*trackedOrder),
+		untracked:          make(map[string]untrackedOrderEvent),
+	}
+
+	for _, option := range options {
+		option(t)
+	}
+
+	return t
+}
+
+// Track registers an order returned by PlaceOrder
+func (t *OrderTracker) Track(order model.OrderResponseData) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.track(order.OrderId, order.ClientId)
+}
+
+func (t *OrderTracker) track(orderID, clientID string) *trackedOrder {
+	t.evictTerminal()
+
+	if entry, ok := t.orders[orderID]; ok {
+		return entry
+	}
+
+	entry := &trackedOrder{
+		order: TrackedOrder{OrderID: orderID, ClientID: clientID},
+		done:  make(chan struct{}),
+	}
+	t.orders[orderID] = entry
+
+	if event, ok := t.untracked[orderID]; ok {
+		delete(t.untracked, orderID)
+		t.applyEvent(entry, event.msg)
+	}
+
+	return entry
+}
+
+// Forget stops tracking an order, callers waiting for it fail
+func (t *OrderTracker) Forget(orderID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.orders[orderID]
+	if !ok {
+		return
+	}
+
+	delete(t.orders, orderID)
+	if !entry.order.Status.IsTerminal() {
+		close(entry.done)
+	}
+}
+
+// evictTerminal drops orders that reached a terminal status longer than the retention ago,
+// their waiters were released when the status was reached
+func (t *OrderTracker) evictTerminal() {
+	now := time.Now()
+	for orderID, entry := range t.orders {
+		if entry.order.Status.IsTerminal() && now.Sub(entry.finishedAt) > t.terminalRetention {
+			delete(t.orders, orderID)
+		}
+	}
+}
+
+func (t *OrderTracker) Order(orderID string) (TrackedOrder, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.orders[orderID]
+	if !ok {
+		return TrackedOrder{}, false
+	}
+	return entry.order, true
+}
+
+func (t *OrderTracker) SubscribeOrder(msg *model.OrderChannelMessage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.evictTerminal()
+
+	entry, ok := t.orders[msg.Data.OrderID]
+	if !ok {
+		t.keepUntracked(msg)
+		return
+	}
+
+	t.applyEvent(entry, msg)
+}
+
+func (t *OrderTracker) keepUntracked(msg *model.OrderChannelMessage) {
+	now := time.Now()
+	for orderID, event := range t.untracked {
+		if now.Sub(event.receivedAt) > t.untrackedRetention {
+			delete(t.untracked, orderID)
+		}
+	}
+
+	if event, ok := t.untracked[msg.Data.OrderID]; ok && event.msg.TimeStamp > msg.TimeStamp {
+		return
+	}
+	t.untracked[msg.Data.OrderID] = untrackedOrderEvent{msg: msg, receivedAt: now}
+}
+
+func (t *OrderTracker) applyEvent(entry *trackedOrder, msg *model.OrderChannelMessage) {
+	event := msg.Data
+	order := entry.order
+	order.Symbol = event.Symbol
+	order.Side = event.Side
+	order.Type = event.Type
+	order.Status = event.OrderStatus
+	order.Quantity = event.Quantity
+	order.Price = event.Price
+	order.Fee = event.Fee
+	order.UpdatedAt = time.UnixMilli(msg.TimeStamp)
+	if order.Status == model.OrderStatusFilled {
+		order.TradeQuantity = order.Quantity
+	}
+
+	t.apply(entry, order, msg.TimeStamp)
+}
+
+func (t *OrderTracker) applyDetail(entry *trackedOrder, detail *model.OrderDetail) {
+	order := entry.order
+	order.ClientID = detail.ClientID
+	order.Symbol = detail.Symbol
+	order.Side = detail.Side
+	order.Type = detail.OrderType
+	order.Status = detail.Status
+	order.Quantity = detail.Quantity
+	order.TradeQuantity = detail.TradeQuantity
+	order.Price = detail.Price
+	order.Fee = detail.Fee
+	// Orders that were never modified can come without a modify time
+	order.UpdatedAt = detail.ModifyTime
+	if order.UpdatedAt.IsZero() {
+		order.UpdatedAt = detail.CreateTime
+	}
+	if order.UpdatedAt.IsZero() {
+		order.UpdatedAt = time.Now()
+	}
+
+	t.apply(entry, order, order.UpdatedAt.UnixMilli())
+}
+
+// apply replaces the tracked state unless it is older than the current one or
+// the order has already reached a terminal status
+func (t *OrderTracker) apply(entry *trackedOrder, order TrackedOrder, ts int64) {
+	if entry.order.Status.IsTerminal() || ts < entry.ts {
+		return
+	}
+
+	previous := entry.order.Status
+	entry.order = order
+	entry.ts = ts
+
+	if order.Status == previous {
+		return
+	}
+
+	if t.onTransition != nil {
+		t.onTransition(OrderTransition{Order: order, Previous: previous})
+	}
+
+	if order.Status.IsTerminal() {
+		entry.finishedAt = time.Now()
+		close(entry.done)
+	}
+}
+
+// Refresh updates a tracked order from GetOrderDetail, orders that are not tracked are ignored
+func (t *OrderTracker) Refresh(ctx context.Context, orderID string) error {
+	response, err := t.client.GetOrderDetail(ctx, &OrderDetailRequest{OrderID: orderID})
+	if err != nil {
+		return err
+	}
+
+	if response.Data == nil {
+		return errors.NewAPIError(response.Code, "order detail missing", "/api/v1/futures/trade/get_order_detail", errors.ErrOrderNotFound)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The order may have been forgotten while the request was in flight
+	if entry, ok := t.orders[orderID]; ok {
+		t.applyDetail(entry, response.Data)
+	}
+
+	return nil
+}
+
+// WaitForTerminal blocks until the order is filled, cancelled or expired. Orders
+// that are not tracked yet are registered, forgetting the order fails the wait.
+func (t *OrderTracker) WaitForTerminal(ctx context.Context, orderID string) (TrackedOrder, error) {
+	t.mu.Lock()
+	entry := t.track(orderID, "")
+	t.mu.Unlock()
+
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-entry.done:
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if !entry.order.Status.IsTerminal() {
+				return entry.order, errors.NewInternalError(fmt.Sprintf("order %s was forgotten while waiting", orderID), nil)
+			}
+			return entry.order, nil
+		case <-ctx.Done():
+			order, _ := t.Order(orderID)
+			return order, ctx.Err()
+		case <-ticker.C:
+			// Order events are lost around reconnects
+			if err := t.Refresh(ctx, orderID); err != nil && ctx.Err() == nil {
+				t.logger.Warn("failed to refresh tracked order", zap.String("orderId", orderID), zap.Error(err))
+			}
+		}
+	}
+}
+
+// WaitForFill blocks until the order is filled. It fails with ErrOrderNotFilled
+// when the order ends in any other terminal status.
+func (t *OrderTracker) WaitForFill(ctx context.Context, orderID string) (TrackedOrder, error) {
+	order, err := t.WaitForTerminal(ctx, orderID)
+	if err != nil {
+		return order, err
+	}
+
+	if order.Status != model.OrderStatusFilled {
+		return order, errors.NewInternalError(fmt.Sprintf("order %s ended with status %s", orderID, order.Status), errors.ErrOrderNotFilled)
+	}
+
+	return order, nil
+}
@@ -0,0 +1,198 @@
+package bitunix
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+type orderDetailClient struct {
+	ApiClient
+	mu     sync.Mutex
+	detail *model.OrderDetail
+}
+
+func (c *orderDetailClient) GetOrderDetail(ctx context.Context, request *OrderDetailRequest) (*model.OrderDetailResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.detail == nil {
+		return nil, errors.NewAPIError(20007, "Order not found", "/api/v1/futures/trade/get_order_detail", errors.ErrOrderNotFound)
+	}
+	detail := *c.detail
+	return &model.OrderDetailResponse{Data: &detail}, nil
+}
+
+func (c *orderDetailClient) setDetail(detail *model.OrderDetail) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.detail = detail
+}
+
+func TestOrderTrackerFollowsTransitions(t *testing.T) {
+	var transitions []OrderTransition
+	tracker := NewOrderTracker(&orderDetailClient{}, WithOrderTransitionCallback(func(transition OrderTransition) {
+		transitions = append(transitions, transition)
+	}))
+	tracker.Track(model.OrderResponseData{OrderId: "order1", ClientId: "client1"})
+
+	tracker.SubscribeOrder(orderUpdate(100, "order1", model.OrderEventCreate, model.OrderStatusNew, 1))
+	tracker.SubscribeOrder(orderUpdate(300, "order1", model.OrderEventUpdate, model.OrderStatusFilled, 1))
+	// Late updates cannot move the order out of a terminal status
+	tracker.SubscribeOrder(orderUpdate(200, "order1", model.OrderEventUpdate, model.OrderStatusPartFilled, 1))
+	tracker.SubscribeOrder(orderUpdate(400, "order1", model.OrderEventUpdate, model.OrderStatusCanceled, 1))
+
+	order, err := tracker.WaitForFill(context.Background(), "order1")
+	require.NoError(t, err)
+	assert.Equal(t, "client1", order.ClientID)
+	assert.Equal(t, model.OrderStatusFilled, order.Status)
+	assert.Equal(t, 1.0, order.TradeQuantity)
+
+	require.Len(t, transitions, 2)
+	assert.Equal(t, model.OrderStatus(""), transitions[0].Previous)
+	assert.Equal(t, model.OrderStatusNew, transitions[0].Order.Status)
+	assert.Equal(t, model.OrderStatusNew, transitions[1].Previous)
+	assert.Equal(t, model.OrderStatusFilled, transitions[1].Order.Status)
+}
+
+func TestOrderTrackerKeepsEventsReceivedBeforeTrack(t *testing.T) {
+	tracker := NewOrderTracker(&orderDetailClient{})
+
+	// The websocket can report the fill before PlaceOrder returns
+	tracker.SubscribeOrder(orderUpdate(100, "order1", model.OrderEventCreate, model.OrderStatusNew, 1))
+	tracker.SubscribeOrder(orderUpdate(200, "order1", model.OrderEventClose, model.OrderStatusFilled, 1))
+	tracker.Track(model.OrderResponseData{OrderId: "order1"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	order, err := tracker.WaitForTerminal(ctx, "order1")
+	require.NoError(t, err)
+	assert.Equal(t, model.OrderStatusFilled, order.Status)
+}
+
+func TestOrderTrackerWaitForFillFailsOnCancel(t *testing.T) {
+	tracker := NewOrderTracker(&orderDetailClient{})
+	tracker.Track(model.OrderResponseData{OrderId: "order1"})
+
+	go tracker.SubscribeOrder(orderUpdate(100, "order1", model.OrderEventClose, model.OrderStatusCanceled, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	order, err := tracker.WaitForFill(ctx, "order1")
+	assert.True(t, stderrors.Is(err, errors.ErrOrderNotFilled), "got %v", err)
+	assert.Equal(t, model.OrderStatusCanceled, order.Status)
+}
+
+func TestOrderTrackerFallsBackToOrderDetail(t *testing.T) {
+	client := &orderDetailClient{}
+	tracker := NewOrderTracker(client, WithOrderTrackerPollInterval(5*time.Millisecond))
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		client.setDetail(&model.OrderDetail{
+			OrderID:       "order1",
+			ClientID:      "client1",
+			Status:        model.OrderStatusFilled,
+			Quantity:      2,
+			TradeQuantity: 2,
+			ModifyTime:    time.UnixMilli(500),
+		})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	order, err := tracker.WaitForFill(ctx, "order1")
+	require.NoError(t, err)
+	assert.Equal(t, "client1", order.ClientID)
+	assert.Equal(t, 2.0, order.TradeQuantity)
+}
+
+func TestOrderTrackerRefreshWithoutModifyTime(t *testing.T) {
+	client := &orderDetailClient{}
+	tracker := NewOrderTracker(client)
+	tracker.Track(model.OrderResponseData{OrderId: "order1"})
+	tracker.SubscribeOrder(orderUpdate(100, "order1", model.OrderEventCreate, model.OrderStatusNew, 1))
+
+	client.setDetail(&model.OrderDetail{OrderID: "order1", Status: model.OrderStatusPartFilled, Quantity: 1, TradeQuantity: 0.5, CreateTime: time.UnixMilli(200)})
+	require.NoError(t, tracker.Refresh(context.Background(), "order1"))
+
+	order, _ := tracker.Order("order1")
+	assert.Equal(t, model.OrderStatusPartFilled, order.Status)
+	assert.Equal(t, time.UnixMilli(200), order.UpdatedAt)
+
+	// Without any time the detail is as recent as the refresh
+	client.setDetail(&model.OrderDetail{OrderID: "order1", Status: model.OrderStatusFilled, Quantity: 1, TradeQuantity: 1})
+	require.NoError(t, tracker.Refresh(context.Background(), "order1"))
+
+	order, _ = tracker.Order("order1")
+	assert.Equal(t, model.OrderStatusFilled, order.Status)
+}
+
+func TestOrderTrackerWaitRespectsContext(t *testing.T) {
+	tracker := NewOrderTracker(&orderDetailClient{}, WithOrderTrackerPollInterval(time.Hour))
+	tracker.Track(model.OrderResponseData{OrderId: "order1"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := tracker.WaitForTerminal(ctx, "order1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestOrderTrackerForgetReleasesWaiters(t *testing.T) {
+	client := &orderDetailClient{}
+	tracker := NewOrderTracker(client, WithOrderTrackerPollInterval(time.Millisecond))
+	tracker.Track(model.OrderResponseData{OrderId: "order1"})
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := tracker.WaitForTerminal(context.Background(), "order1")
+		result <- err
+	}()
+
+	client.setDetail(&model.OrderDetail{OrderID: "order1", Status: model.OrderStatusNew, ModifyTime: time.UnixMilli(100)})
+	time.Sleep(10 * time.Millisecond)
+	tracker.Forget("order1")
+
+	select {
+	case err := <-result:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not released by Forget")
+	}
+
+	// A refresh in flight while forgetting must not track the order again
+	require.NoError(t, tracker.Refresh(context.Background(), "order1"))
+	_, ok := tracker.Order("order1")
+	assert.False(t, ok)
+}
+
+func TestOrderTrackerEvictsTerminalOrders(t *testing.T) {
+	tracker := NewOrderTracker(&orderDetailClient{}, WithOrderTrackerTerminalRetention(time.Millisecond))
+	tracker.Track(model.OrderResponseData{OrderId: "order1"})
+	tracker.Track(model.OrderResponseData{OrderId: "order2"})
+	tracker.SubscribeOrder(orderUpdate(100, "order1", model.OrderEventClose, model.OrderStatusFilled, 1))
+
+	order, err := tracker.WaitForTerminal(context.Background(), "order1")
+	require.NoError(t, err)
+	assert.Equal(t, model.OrderStatusFilled, order.Status)
+
+	time.Sleep(5 * time.Millisecond)
+	tracker.SubscribeOrder(orderUpdate(200, "order2", model.OrderEventUpdate, model.OrderStatusNew, 1))
+
+	_, ok := tracker.Order("order1")
+	assert.False(t, ok)
+	_, ok = tracker.Order("order2")
+	assert.True(t, ok)
+}
@@ -0,0 +1,1188 @@
+package bitunix
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/internal/paper"
+	"github.com/tradingiq/bitunix-client/model"
+	"go.uber.org/zap"
+)
+
+// PaperClient is an ApiClient that trades a simulated account instead of sending
+// orders. Market orders fill at the last price fed through SetPrice, KLineFeed or
+// TickerFeed; resting limit orders and tp/sl orders are matched on every price
+// update. Mark and last price stop types both trigger on the fed price, triggered
+// market orders fill at the fed price but no better than the trigger price.
+// Positions are not liquidated.
+//
+// Changes are published to subscribers like the private websocket does. Events
+// are delivered on the goroutine that caused them, after the account is unlocked,
+// so subscribers may call back into the client.
+type PaperClient struct {
+	marginCoin model.MarginCoin
+
+	mu      sync.Mutex
+	engine  *paper.Engine
+	pending []func()
+
+	subscriberMtx        sync.Mutex
+	balanceSubscribers   map[BalanceSubscriber]struct{}
+	positionSubscribers  map[PositionSubscriber]struct{}
+	orderSubscribers     map[OrderSubscriber]struct{}
+	tpSlOrderSubscribers map[TpSlOrderSubscriber]struct{}
+}
+
+type PaperClientOption func(*PaperClient)
+
+func WithPaperBalance(balance float64) PaperClientOption {
+	return func(c *PaperClient) {
+		c.engine.Balance = balance
+	}
+}
+
+func WithPaperMarginCoin(coin model.MarginCoin) PaperClientOption {
+	return func(c *PaperClient) {
+		c.marginCoin = coin.Normalize()
+	}
+}
+
+// WithPaperFees sets the fee rates charged on the filled value, maker for resting limit orders and taker otherwise
+func WithPaperFees(maker, taker float64) PaperClientOption {
+	return WithPaperFeeModel(RateFees(maker, taker))
+}
+
+func WithPaperFeeModel(fees FeeModel) PaperClientOption {
+	return func(c *PaperClient) {
+		c.engine.Fees = fees
+	}
+}
+
+// WithPaperSlippage moves the price of taker fills, by default they fill at the last price
+func WithPaperSlippage(slippage SlippageModel) PaperClientOption {
+	return func(c *PaperClient) {
+		c.engine.Slippage = slippage
+	}
+}
+
+// WithPaperLeverage is used for symbols without a leverage set through ChangeLeverage
+func WithPaperLeverage(leverage int) PaperClientOption {
+	return func(c *PaperClient) {
+		c.engine.DefaultLeverage = leverage
+	}
+}
+
+func WithPaperPositionMode(mode model.PositionMode) PaperClientOption {
+	return func(c *PaperClient) {
+		c.engine.PositionMode = mode.Normalize()
+	}
+}
+
+// WithPaperClock replaces time.Now, e.g. with the time of replayed market data
+func WithPaperClock(now func() time.Time) PaperClientOption {
+	return func(c *PaperClient) {
+		c.engine.Now = now
+	}
+}
+
+func WithPaperLogger(logger *zap.Logger) PaperClientOption {
+	return func(c *PaperClient) {
+		c.engine.Logger = logger
+	}
+}
+
+func NewPaperClient(options ...PaperClientOption) *PaperClient {
+	c := &PaperClient{
+		marginCoin:           "USDT",
+		balanceSubscribers:   make(map[BalanceSubscriber]struct{}),
+		positionSubscribers:  make(map[PositionSubscriber]struct{}),
+		orderSubscribers:     make(map[OrderSubscriber]struct{}),
+		tpSlOrderSubscribers: make(map[TpSlOrderSubscriber]struct{}),
+	}
+	c.engine = paper.NewEngine(paperEvents{client: c})
+	c.engine.Fees = RateFees(0.0002, 0.0006)
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// update runs fn with the account locked and delivers the events it queued afterwards
+func (c *PaperClient) update(fn func() error) error {
+	c.mu.Lock()
+	err := fn()
+	events := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	for _, deliver := range events {
+		deliver()
+	}
+	return err
+}
+
+// SetPrice records a trade at price and matches open orders against it
+func (c *PaperClient) SetPrice(symbol model.Symbol, price float64) {
+	c.MoveTo(symbol, price, price, price)
+}
+
+// MoveTo records that the price of symbol went through low and high before settling at last
+func (c *PaperClient) MoveTo(symbol model.Symbol, low, high, last float64) {
+	_ = c.update(func() error {
+		c.engine.Move(symbol, low, high, last)
+		return nil
+	})
+}
+
+// Price is the last price fed for symbol
+func (c *PaperClient) Price(symbol model.Symbol) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	price, ok := c.engine.Prices[symbol.Normalize()]
+	return price, ok
+}
+
+// Balance is the wallet balance, realized PNL minus fees included
+func (c *PaperClient) Balance() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.engine.Balance
+}
+
+type paperKLineFeed struct {
+	client   *PaperClient
+	symbol   model.Symbol
+	interval model.Interval
+	mu       sync.Mutex
+	low      float64
+	high     float64
+}
+
+// KLineFeed returns a subscriber for PublicWebsocketClient.SubscribeKLine that
+// feeds the market price candles of symbol into the client
+func (c *PaperClient) KLineFeed(symbol model.Symbol, interval model.Interval) KLineSubscriber {
+	return &paperKLineFeed{client: c, symbol: symbol.Normalize(), interval: interval}
+}
+
+func (f *paperKLineFeed) SubscribeKLine(msg *model.KLineChannelMessage) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	k := msg.Data
+	low, high := k.ClosePrice, k.ClosePrice
+
+	// The running candle is pushed repeatedly, only new extremes are movements
+	// since the last push. A range that shrank belongs to the next candle.
+	newCandle := f.high == 0 || k.HighPrice < f.high || k.LowPrice > f.low
+	if newCandle || k.LowPrice < f.low {
+		low = k.LowPrice
+	}
+	if newCandle || k.HighPrice > f.high {
+		high = k.HighPrice
+	}
+	f.low, f.high = k.LowPrice, k.HighPrice
+
+	f.client.MoveTo(f.symbol, low, high, k.ClosePrice)
+}
+
+func (f *paperKLineFeed) SubscribeInterval() model.Interval {
+	return f.interval
+}
+
+func (f *paperKLineFeed) SubscribeSymbol() model.Symbol {
+	return f.symbol
+}
+
+func (f *paperKLineFeed) SubscribePriceType() model.PriceType {
+	return model.PriceTypeMarket
+}
+
+type paperTickerFeed struct {
+	client *PaperClient
+	symbol model.Symbol
+}
+
+// TickerFeed returns a subscriber for PublicWebsocketClient.SubscribeTicker that
+// feeds the last price of symbol into the client
+func (c *PaperClient) TickerFeed(symbol model.Symbol) TickerSubscriber {
+	return &paperTickerFeed{client: c, symbol: symbol.Normalize()}
+}
+
+func (f *paperTickerFeed) SubscribeTicker(msg *model.TickerChannelMessage) {
+	f.client.SetPrice(f.symbol, msg.Data.LastPrice)
+}
+
+func (f *paperTickerFeed) SubscribeSymbol() model.Symbol {
+	return f.symbol
+}
+
+func (c *PaperClient) SubscribeBalance(subscriber BalanceSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "balance subscriber cannot be nil", nil)
+	}
+
+	c.subscriberMtx.Lock()
+	defer c.subscriberMtx.Unlock()
+
+	c.balanceSubscribers[subscriber] = struct{}{}
+	return nil
+}
+
+func (c *PaperClient) UnsubscribeBalance(subscriber BalanceSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "balance subscriber cannot be nil", nil)
+	}
+
+	c.subscriberMtx.Lock()
+	defer c.subscriberMtx.Unlock()
+
+	delete(c.balanceSubscribers, subscriber)
+	return nil
+}
+
+func (c *PaperClient) SubscribePositions(subscriber PositionSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "position subscriber cannot be nil", nil)
+	}
+
+	c.subscriberMtx.Lock()
+	defer c.subscriberMtx.Unlock()
+
+	c.positionSubscribers[subscriber] = struct{}{}
+	return nil
+}
+
+func (c *PaperClient) UnsubscribePositions(subscriber PositionSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "position subscriber cannot be nil", nil)
+	}
+
+	c.subscriberMtx.Lock()
+	defer c.subscriberMtx.Unlock()
+
+	delete(c.positionSubscribers, subscriber)
+	return nil
+}
+
+func (c *PaperClient) SubscribeOrders(subscriber OrderSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "order subscriber cannot be nil", nil)
+	}
+
+	c.subscriberMtx.Lock()
+	defer c.subscriberMtx.Unlock()
+
+	c.orderSubscribers[subscriber] = struct{}{}
+	return nil
+}
+
+func (c *PaperClient) UnsubscribeOrders(subscriber OrderSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "order subscriber cannot be nil", nil)
+	}
+
+	c.subscriberMtx.Lock()
+	defer c.subscriberMtx.Unlock()
+
+	delete(c.orderSubscribers, subscriber)
+	return nil
+}
+
+func (c *PaperClient) SubscribeTpSlOrders(subscriber TpSlOrderSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "tp/sl order subscriber cannot be nil", nil)
+	}
+
+	c.subscriberMtx.Lock()
+	defer c.subscriberMtx.Unlock()
+
+	c.tpSlOrderSubscribers[subscriber] = struct{}{}
+	return nil
+}
+
+func (c *PaperClient) UnsubscribeTpSlOrders(subscriber TpSlOrderSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "tp/sl order subscriber cannot be nil", nil)
+	}
+
+	c.subscriberMtx.Lock()
+	defer c.subscriberMtx.Unlock()
+
+	delete(c.tpSlOrderSubscribers, subscriber)
+	return nil
+}
+
+func paperSubscribers[S comparable](mtx *sync.Mutex, subscribers map[S]struct{}) []S {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	list := make([]S, 0, len(subscribers))
+	for subscriber := range subscribers {
+		list = append(list, subscriber)
+	}
+	return list
+}
+
+var paperSuccess = model.BaseResponse{Code: 0, Message: "Success"}
+
+func (c *PaperClient) PlaceOrder(_ context.Context, request *model.OrderRequest) (*model.OrderResponse, error) {
+	var o *paper.Order
+	err := c.update(func() (err error) {
+		o, err = c.engine.PlaceOrder(request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.OrderResponse{
+		Code:    paperSuccess.Code,
+		Message: paperSuccess.Message,
+		Data:    model.OrderResponseData{OrderId: o.ID, ClientId: o.ClientID},
+	}, nil
+}
+
+func (c *PaperClient) BatchPlaceOrders(_ context.Context, requests []model.OrderRequest) (*model.BatchOrderResponse, error) {
+	response := &model.BatchOrderResponse{BaseResponse: paperSuccess}
+	response.Data.SuccessList = []model.BatchOrderResult{}
+	response.Data.FailureList = []model.BatchOrderFailure{}
+
+	_ = c.update(func() error {
+		for i := range requests {
+			o, err := c.engine.PlaceOrder(&requests[i])
+			if err != nil {
+				response.Data.FailureList = append(response.Data.FailureList, paperBatchFailure(requests[i].ClientID, err))
+				continue
+			}
+			response.Data.SuccessList = append(response.Data.SuccessList, model.BatchOrderResult{OrderId: o.ID, ClientId: o.ClientID})
+		}
+		return nil
+	})
+
+	return response, nil
+}
+
+func paperBatchFailure(clientID string, err error) model.BatchOrderFailure {
+	failure := model.BatchOrderFailure{ClientId: clientID, ErrorMsg: err.Error()}
+	if apiErr, ok := err.(*errors.APIError); ok {
+		failure.ErrorMsg = apiErr.Message
+		failure.ErrorCode = strconv.Itoa(apiErr.Code)
+	}
+	return failure
+}
+
+func (c *PaperClient) CancelOrders(_ context.Context, request *model.CancelOrderRequest) (*model.CancelOrderResponse, error) {
+	if request.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	response := &model.CancelOrderResponse{BaseResponse: paperSuccess}
+	response.Data.SuccessList = []model.CancelOrderResult{}
+	response.Data.FailureList = []model.CancelOrderFailure{}
+
+	_ = c.update(func() error {
+		symbol := request.Symbol.Normalize()
+		for _, param := range request.OrderList {
+			o := c.engine.FindOrder(param.OrderID, param.ClientID)
+			if o == nil || o.Symbol != symbol {
+				response.Data.FailureList = append(response.Data.FailureList, model.CancelOrderFailure{
+					OrderId:   param.OrderID,
+					ClientId:  param.ClientID,
+					ErrorMsg:  "Order not found",
+					ErrorCode: strconv.Itoa(paper.ErrorCodes[errors.ErrOrderNotFound]),
+				})
+				continue
+			}
+
+			c.engine.CancelOrder(o)
+			response.Data.SuccessList = append(response.Data.SuccessList, model.CancelOrderResult{OrderId: o.ID, ClientId: o.ClientID})
+		}
+		return nil
+	})
+
+	return response, nil
+}
+
+func (c *PaperClient) CancelAllOrders(_ context.Context, symbol model.Symbol) (*model.CancelOrderResponse, error) {
+	response := &model.CancelOrderResponse{BaseResponse: paperSuccess}
+	response.Data.SuccessList = []model.CancelOrderResult{}
+	response.Data.FailureList = []model.CancelOrderFailure{}
+
+	_ = c.update(func() error {
+		symbol = symbol.Normalize()
+		for _, o := range c.engine.OpenOrders() {
+			if symbol != "" && o.Symbol != symbol {
+				continue
+			}
+
+			c.engine.CancelOrder(o)
+			response.Data.SuccessList = append(response.Data.SuccessList, model.CancelOrderResult{OrderId: o.ID, ClientId: o.ClientID})
+		}
+		return nil
+	})
+
+	return response, nil
+}
+
+func (c *PaperClient) ModifyOrder(_ context.Context, request *model.ModifyOrderRequest) (*model.ModifyOrderResponse, error) {
+	if request.OrderID == "" && request.ClientID == "" {
+		return nil, errors.NewValidationError("orderId", "either orderId or clientId is required", nil)
+	}
+
+	var o *paper.Order
+	err := c.update(func() error {
+		o = c.engine.FindOrder(request.OrderID, request.ClientID)
+		if o == nil {
+			return paper.Reject(errors.ErrOrderNotFound, "Order not found")
+		}
+
+		return c.engine.ModifyOrder(o, request.Qty, request.Price,
+			paper.Trigger{Price: request.TpPrice, StopType: request.TpStopType, OrderType: request.TpOrderType, OrderPrice: request.TpOrderPrice},
+			paper.Trigger{Price: request.SlPrice, StopType: request.SlStopType, OrderType: request.SlOrderType, OrderPrice: request.SlOrderPrice})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ModifyOrderResponse{BaseResponse: paperSuccess, Data: model.OrderResponseData{OrderId: o.ID, ClientId: o.ClientID}}, nil
+}
+
+func (c *PaperClient) GetTradeHistory(_ context.Context, params model.TradeHistoryParams) (*model.TradeHistoryResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	symbol := params.Symbol.Normalize()
+	var matched []*paper.Trade
+	for _, t := range slices.Backward(c.engine.Trades) {
+		if (symbol == "" || t.Order.Symbol == symbol) &&
+			(params.OrderID == "" || t.Order.ID == params.OrderID) &&
+			(params.PositionID == "" || t.PositionID == params.PositionID) &&
+			paper.InRange(t.CreateTime, params.StartTime, params.EndTime) {
+			matched = append(matched, t)
+		}
+	}
+
+	response := &model.TradeHistoryResponse{BaseResponse: paperSuccess}
+	response.Data.Trades = []model.HistoricalTrade{}
+	response.Data.Total = strconv.Itoa(len(matched))
+	for _, t := range paperPage(matched, params.Skip, params.Limit) {
+		response.Data.Trades = append(response.Data.Trades, paperHistoricalTrade(t))
+	}
+	return response, nil
+}
+
+type paperOrderFilter struct {
+	symbol    model.Symbol
+	orderID   string
+	clientID  string
+	status    model.OrderStatus
+	orderType model.OrderType
+	start     *time.Time
+	end       *time.Time
+}
+
+func (f paperOrderFilter) matches(o *paper.Order) bool {
+	return (f.symbol == "" || o.Symbol == f.symbol) &&
+		(f.orderID == "" || o.ID == f.orderID) &&
+		(f.clientID == "" || o.ClientID == f.clientID) &&
+		(f.status == "" || o.Status == f.status) &&
+		(f.orderType == "" || o.OrderType == f.orderType) &&
+		paper.InRange(o.CreateTime, f.start, f.end)
+}
+
+func filterPaperOrders(orders []*paper.Order, filter paperOrderFilter) []*paper.Order {
+	var matched []*paper.Order
+	for _, o := range orders {
+		if filter.matches(o) {
+			matched = append(matched, o)
+		}
+	}
+	return paper.NewestFirst(matched, func(o *paper.Order) string { return o.ID })
+}
+
+func (c *PaperClient) GetOrderHistory(_ context.Context, params model.OrderHistoryParams) (*model.OrderHistoryResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	matched := filterPaperOrders(c.engine.OrderHistory, paperOrderFilter{
+		symbol:    params.Symbol.Normalize(),
+		orderID:   params.OrderID,
+		clientID:  params.ClientID,
+		status:    params.Status.Normalize(),
+		orderType: params.Type.Normalize(),
+		start:     params.StartTime,
+		end:       params.EndTime,
+	})
+
+	response := &model.OrderHistoryResponse{BaseResponse: paperSuccess}
+	response.Data.Orders = []model.HistoricalOrder{}
+	response.Data.Total = strconv.Itoa(len(matched))
+	for _, o := range paperPage(matched, params.Skip, params.Limit) {
+		response.Data.Orders = append(response.Data.Orders, paperHistoricalOrder(o))
+	}
+	return response, nil
+}
+
+func (c *PaperClient) GetPendingOrder(_ context.Context, params model.PendingOrderParams) (*model.PendingOrderResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	matched := filterPaperOrders(c.engine.OpenOrders(), paperOrderFilter{
+		symbol:   params.Symbol.Normalize(),
+		orderID:  params.OrderID,
+		clientID: params.ClientID,
+		status:   params.Status.Normalize(),
+		start:    params.StartTime,
+		end:      params.EndTime,
+	})
+
+	response := &model.PendingOrderResponse{BaseResponse: paperSuccess}
+	response.Data.OrderList = []model.PendingOrder{}
+	response.Data.Total = int64(len(matched))
+	for _, o := range paperPage(matched, params.Skip, params.Limit) {
+		response.Data.OrderList = append(response.Data.OrderList, paperPendingOrder(o))
+	}
+	return response, nil
+}
+
+func (c *PaperClient) GetOrderDetail(_ context.Context, request *OrderDetailRequest) (*model.OrderDetailResponse, error) {
+	if request.OrderID == "" && request.ClientID == "" {
+		return nil, errors.NewValidationError("orderId", "either orderId or clientId is required", nil)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o := c.engine.LookupOrder(request.OrderID, request.ClientID)
+	if o == nil {
+		return nil, paper.Reject(errors.ErrOrderNotFound, "Order not found")
+	}
+
+	detail := paperOrderDetail(o)
+	return &model.OrderDetailResponse{BaseResponse: paperSuccess, Data: &detail}, nil
+}
+
+func (c *PaperClient) GetPositionHistory(_ context.Context, params model.PositionHistoryParams) (*model.PositionHistoryResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	symbol := params.Symbol.Normalize()
+	var matched []*paper.Position
+	for _, p := range slices.Backward(c.engine.PositionHistory) {
+		if (symbol == "" || p.Symbol == symbol) &&
+			(params.PositionID == "" || p.ID == params.PositionID) &&
+			paper.InRange(p.ModifyTime, params.StartTime, params.EndTime) {
+			matched = append(matched, p)
+		}
+	}
+
+	response := &model.PositionHistoryResponse{BaseResponse: paperSuccess}
+	response.Data.Positions = []model.HistoricalPosition{}
+	response.Data.Total = strconv.Itoa(len(matched))
+	for _, p := range paperPage(matched, params.Skip, params.Limit) {
+		response.Data.Positions = append(response.Data.Positions, paperHistoricalPosition(p))
+	}
+	return response, nil
+}
+
+func (c *PaperClient) GetPendingPositions(_ context.Context, params model.PendingPositionParams) (*model.PendingPositionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	symbol := params.Symbol.Normalize()
+	response := &model.PendingPositionResponse{BaseResponse: paperSuccess, Data: []model.PendingPosition{}}
+	for _, p := range c.engine.SortedPositions() {
+		if (symbol == "" || p.Symbol == symbol) && (params.PositionID == "" || p.ID == params.PositionID) {
+			response.Data = append(response.Data, paperPendingPosition(p, c.engine.Prices[p.Symbol]))
+		}
+	}
+	return response, nil
+}
+
+func (c *PaperClient) FlashClosePosition(_ context.Context, positionID string) (*model.FlashClosePositionResponse, error) {
+	if positionID == "" {
+		return nil, errors.NewValidationError("positionId", "is required", nil)
+	}
+
+	var o *paper.Order
+	err := c.update(func() (err error) {
+		p, ok := c.engine.Positions[positionID]
+		if !ok {
+			return paper.Reject(errors.ErrPositionNotExist, "Position not exist")
+		}
+		o, err = c.engine.ClosePosition(p)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.FlashClosePositionResponse{BaseResponse: paperSuccess, Data: model.ClosePositionResult{PositionID: positionID, OrderID: o.ID}}, nil
+}
+
+func (c *PaperClient) CloseAllPositions(_ context.Context, symbol model.Symbol) (*model.CloseAllPositionsResponse, error) {
+	response := &model.CloseAllPositionsResponse{BaseResponse: paperSuccess}
+	response.Data.SuccessList = []model.ClosePositionResult{}
+	response.Data.FailureList = []model.ClosePositionFailure{}
+
+	_ = c.update(func() error {
+		symbol = symbol.Normalize()
+		for _, p := range c.engine.SortedPositions() {
+			if symbol != "" && p.Symbol != symbol {
+				continue
+			}
+
+			o, err := c.engine.ClosePosition(p)
+			if err != nil {
+				failure := model.ClosePositionFailure{PositionID: p.ID, ErrorMsg: err.Error()}
+				if apiErr, ok := err.(*errors.APIError); ok {
+					failure.ErrorMsg = apiErr.Message
+					failure.ErrorCode = strconv.Itoa(apiErr.Code)
+				}
+				response.Data.FailureList = append(response.Data.FailureList, failure)
+				continue
+			}
+			response.Data.SuccessList = append(response.Data.SuccessList, model.ClosePositionResult{PositionID: p.ID, OrderID: o.ID})
+		}
+		return nil
+	})
+
+	return response, nil
+}
+
+func (c *PaperClient) GetAccountBalance(_ context.Context, params model.AccountBalanceParams) (*model.AccountBalanceResponse, error) {
+	if params.MarginCoin == "" {
+		return nil, errors.NewValidationError("marginCoin", "is required", nil)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if params.MarginCoin.Normalize() != c.marginCoin {
+		return nil, paper.Reject(errors.ErrParameterError, "unsupported margin coin %s", params.MarginCoin)
+	}
+
+	b := c.engine.Balances()
+	return &model.AccountBalanceResponse{BaseResponse: paperSuccess, Data: &model.AccountBalanceEntry{
+		MarginCoin:             c.marginCoin,
+		Available:              b.Available,
+		Frozen:                 b.Frozen,
+		Margin:                 b.Margin,
+		Transfer:               b.Available,
+		PositionMode:           c.engine.PositionMode,
+		CrossUnrealizedPNL:     b.CrossUnrealizedPNL,
+		IsolationUnrealizedPNL: b.IsolationUnrealizedPNL,
+	}}, nil
+}
+
+func (c *PaperClient) ChangeLeverage(_ context.Context, request *model.ChangeLeverageRequest) (*model.ChangeLeverageResponse, error) {
+	if request.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.engine.ChangeLeverage(request.Symbol, request.Leverage); err != nil {
+		return nil, err
+	}
+
+	return &model.ChangeLeverageResponse{BaseResponse: paperSuccess, Data: []model.LeverageEntry{
+		{Symbol: request.Symbol.Normalize(), MarginCoin: c.marginCoin, Leverage: request.Leverage},
+	}}, nil
+}
+
+func (c *PaperClient) ChangeMarginMode(_ context.Context, request *model.ChangeMarginModeRequest) (*model.ChangeMarginModeResponse, error) {
+	if request.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.engine.ChangeMarginMode(request.Symbol, request.MarginMode); err != nil {
+		return nil, err
+	}
+
+	return &model.ChangeMarginModeResponse{BaseResponse: paperSuccess, Data: []model.MarginModeEntry{
+		{Symbol: request.Symbol.Normalize(), MarginCoin: c.marginCoin, MarginMode: request.MarginMode.Normalize()},
+	}}, nil
+}
+
+func (c *PaperClient) GetLeverageAndMarginMode(_ context.Context, params model.LeverageAndMarginModeParams) (*model.LeverageAndMarginModeResponse, error) {
+	if params.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	symbol := params.Symbol.Normalize()
+	return &model.LeverageAndMarginModeResponse{BaseResponse: paperSuccess, Data: &model.LeverageAndMarginMode{
+		Symbol:     symbol,
+		MarginCoin: c.marginCoin,
+		Leverage:   c.engine.LeverageOf(symbol),
+		MarginMode: c.engine.MarginModeOf(symbol),
+	}}, nil
+}
+
+func (c *PaperClient) ChangePositionMode(_ context.Context, request *model.ChangePositionModeRequest) (*model.ChangePositionModeResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.engine.ChangePositionMode(request.PositionMode); err != nil {
+		return nil, err
+	}
+
+	return &model.ChangePositionModeResponse{BaseResponse: paperSuccess, Data: []model.PositionModeEntry{{PositionMode: c.engine.PositionMode}}}, nil
+}
+
+// AdjustPositionMargin adds to or, with a negative amount, removes margin from a position
+func (c *PaperClient) AdjustPositionMargin(_ context.Context, request *model.AdjustPositionMarginRequest) (*model.AdjustPositionMarginResponse, error) {
+	var data model.AdjustPositionMarginData
+	err := c.update(func() error {
+		p, err := c.engine.AdjustMargin(request.PositionID, request.Symbol, request.Side, request.Amount)
+		if err != nil {
+			return err
+		}
+
+		data = model.AdjustPositionMarginData{PositionID: p.ID, Symbol: p.Symbol, MarginCoin: c.marginCoin, Margin: model.NewDecimalFromFloat(p.Margin())}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AdjustPositionMarginResponse{BaseResponse: paperSuccess, Data: data}, nil
+}
+
+func (c *PaperClient) PlaceTpSlOrder(_ context.Context, request *model.TPSLOrderRequest) (*model.TpSlOrderResponse, error) {
+	var t *paper.TpSlOrder
+	err := c.update(func() (err error) {
+		t, err = c.engine.PlaceTpSl(request.PositionID,
+			paper.Trigger{Price: request.TpPrice, StopType: request.TpStopType, OrderType: request.TpOrderType, OrderPrice: request.TpOrderPrice},
+			paper.Trigger{Price: request.SlPrice, StopType: request.SlStopType, OrderType: request.SlOrderType, OrderPrice: request.SlOrderPrice},
+			request.TpQty, request.SlQty)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TpSlOrderResponse{BaseResponse: paperSuccess, Data: []model.TPSLOrderResponseData{{OrderID: t.ID}}}, nil
+}
+
+func (c *PaperClient) ModifyTpSlOrder(_ context.Context, request *model.ModifyTPSLOrderRequest) (*model.ModifyTpSlOrderResponse, error) {
+	err := c.update(func() error {
+		return c.engine.ModifyTpSl(request.OrderID,
+			paper.Trigger{Price: request.TpPrice, StopType: request.TpStopType, OrderType: request.TpOrderType, OrderPrice: request.TpOrderPrice},
+			paper.Trigger{Price: request.SlPrice, StopType: request.SlStopType, OrderType: request.SlOrderType, OrderPrice: request.SlOrderPrice},
+			request.TpQty, request.SlQty)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ModifyTpSlOrderResponse{BaseResponse: paperSuccess, Data: model.TPSLOrderResponseData{OrderID: request.OrderID}}, nil
+}
+
+func (c *PaperClient) CancelTpSlOrder(_ context.Context, request *model.CancelTPSLOrderRequest) (*model.CancelTpSlOrderResponse, error) {
+	err := c.update(func() error {
+		return c.engine.CancelTpSl(request.OrderID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CancelTpSlOrderResponse{BaseResponse: paperSuccess, Data: model.TPSLOrderResponseData{OrderID: request.OrderID}}, nil
+}
+
+// PlacePositionTpSl replaces the tp/sl of the whole position if there is one already
+func (c *PaperClient) PlacePositionTpSl(_ context.Context, request *model.PositionTPSLOrderRequest) (*model.PositionTpSlOrderResponse, error) {
+	var t *paper.TpSlOrder
+	err := c.update(func() (err error) {
+		t, err = c.engine.PlacePositionTpSl(request.PositionID,
+			paper.Trigger{Price: request.TpPrice, StopType: request.TpStopType},
+			paper.Trigger{Price: request.SlPrice, StopType: request.SlStopType})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PositionTpSlOrderResponse{BaseResponse: paperSuccess, Data: model.TPSLOrderResponseData{OrderID: t.ID}}, nil
+}
+
+func (c *PaperClient) ModifyPositionTpSl(_ context.Context, request *model.PositionTPSLOrderRequest) (*model.PositionTpSlOrderResponse, error) {
+	var t *paper.TpSlOrder
+	err := c.update(func() error {
+		if err := c.engine.ModifyPositionTpSl(request.PositionID,
+			paper.Trigger{Price: request.TpPrice, StopType: request.TpStopType},
+			paper.Trigger{Price: request.SlPrice, StopType: request.SlStopType}); err != nil {
+			return err
+		}
+		t, _ = c.engine.PositionTpSl(request.PositionID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PositionTpSlOrderResponse{BaseResponse: paperSuccess, Data: model.TPSLOrderResponseData{OrderID: t.ID}}, nil
+}
+
+func filterPaperTpSlOrders(orders []*paper.TpSlOrder, symbol model.Symbol, positionID string, side model.TradeSide, mode model.PositionMode, start, end *time.Time) []*paper.TpSlOrder {
+	var matched []*paper.TpSlOrder
+	for _, t := range orders {
+		if (symbol == "" || t.Symbol == symbol) &&
+			(positionID == "" || t.PositionID == positionID) &&
+			(side == "" || t.Side == side) &&
+			(mode == "" || t.PositionMode == mode) &&
+			paper.InRange(t.CreateTime, start, end) {
+			matched = append(matched, t)
+		}
+	}
+	return paper.NewestFirst(matched, func(t *paper.TpSlOrder) string { return t.ID })
+}
+
+func (c *PaperClient) GetPendingTPSLOrder(_ context.Context, params model.PendingTPSLOrderParams) (*model.PendingTPSLOrderResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	matched := filterPaperTpSlOrders(slices.Collect(maps.Values(c.engine.TpSlOrders)), params.Symbol.Normalize(), params.PositionID, params.Side.Normalize(), params.PositionMode.Normalize(), nil, nil)
+
+	response := &model.PendingTPSLOrderResponse{BaseResponse: paperSuccess, Data: []model.PendingTPSLOrder{}}
+	for _, t := range paperPage(matched, params.Skip, params.Limit) {
+		response.Data = append(response.Data, paperPendingTpSl(t, c.marginCoin))
+	}
+	return response, nil
+}
+
+func (c *PaperClient) GetTPSLOrderHistory(_ context.Context, params model.TPSLOrderHistoryParams) (*model.TPSLOrderHistoryResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var side model.TradeSide
+	switch params.Side.Normalize() {
+	case model.PositionSideLong:
+		side = model.TradeSideBuy
+	case model.PositionSideShort:
+		side = model.TradeSideSell
+	}
+	matched := filterPaperTpSlOrders(c.engine.TpSlHistory, params.Symbol.Normalize(), "", side, params.PositionMode.Normalize(), params.StartTime, params.EndTime)
+
+	response := &model.TPSLOrderHistoryResponse{BaseResponse: paperSuccess}
+	response.Data.OrderList = []model.HistoricalTPSLOrder{}
+	response.Data.Total = int64(len(matched))
+	for _, t := range paperPage(matched, params.Skip, params.Limit) {
+		response.Data.OrderList = append(response.Data.OrderList, paperHistoricalTpSl(t, c.marginCoin))
+	}
+	return response, nil
+}
+
+func paperPage[T any](entries []T, skip, limit int64) []T {
+	if limit <= 0 {
+		limit = 10
+	}
+	limit = min(limit, 100)
+
+	if skip >= int64(len(entries)) {
+		return nil
+	}
+	return entries[skip:min(skip+limit, int64(len(entries)))]
+}
+
+func paperOptional[T comparable](value T) *T {
+	var zero T
+	if value == zero {
+		return nil
+	}
+	return &value
+}
+
+func paperPositionSide(side model.TradeSide) model.PositionSide {
+	if side == model.TradeSideSell {
+		return model.PositionSideShort
+	}
+	return model.PositionSideLong
+}
+
+func paperPendingOrder(o *paper.Order) model.PendingOrder {
+	return model.PendingOrder{
+		OrderID:       o.ID,
+		Symbol:        o.Symbol,
+		Quantity:      o.Qty,
+		TradeQuantity: o.TradeQty,
+		PositionMode:  o.PositionMode,
+		MarginMode:    o.MarginMode,
+		Leverage:      o.Leverage,
+		Price:         o.Price,
+		Side:          o.Side,
+		OrderType:     o.OrderType,
+		Effect:        o.Effect,
+		ClientID:      o.ClientID,
+		ReduceOnly:    o.ReduceOnly,
+		Status:        o.Status,
+		Fee:           o.Fee,
+		RealizedPNL:   o.RealizedPNL,
+		TpPrice:       o.TP.Price,
+		TpStopType:    paperOptional(o.TP.StopType),
+		TpOrderType:   paperOptional(o.TP.OrderType),
+		TpOrderPrice:  o.TP.OrderPrice,
+		SlPrice:       o.SL.Price,
+		SlStopType:    paperOptional(o.SL.StopType),
+		SlOrderType:   paperOptional(o.SL.OrderType),
+		SlOrderPrice:  o.SL.OrderPrice,
+		CreateTime:    o.CreateTime,
+		ModifyTime:    o.ModifyTime,
+	}
+}
+
+func paperOrderDetail(o *paper.Order) model.OrderDetail {
+	p := paperPendingOrder(o)
+	return model.OrderDetail{
+		OrderID:       p.OrderID,
+		Symbol:        p.Symbol,
+		Quantity:      p.Quantity,
+		TradeQuantity: p.TradeQuantity,
+		PositionMode:  p.PositionMode,
+		MarginMode:    p.MarginMode,
+		Leverage:      p.Leverage,
+		Price:         p.Price,
+		Side:          p.Side,
+		OrderType:     p.OrderType,
+		Effect:        p.Effect,
+		ClientID:      p.ClientID,
+		ReduceOnly:    p.ReduceOnly,
+		Status:        p.Status,
+		Fee:           p.Fee,
+		RealizedPNL:   p.RealizedPNL,
+		TpPrice:       p.TpPrice,
+		TpOrderPrice:  p.TpOrderPrice,
+		SlPrice:       p.SlPrice,
+		TpStopType:    p.TpStopType,
+		TpOrderType:   p.TpOrderType,
+		SlStopType:    p.SlStopType,
+		SlOrderType:   p.SlOrderType,
+		SlOrderPrice:  p.SlOrderPrice,
+		CreateTime:    p.CreateTime,
+		ModifyTime:    p.ModifyTime,
+	}
+}
+
+func paperHistoricalOrder(o *paper.Order) model.HistoricalOrder {
+	p := paperPendingOrder(o)
+	return model.HistoricalOrder{
+		OrderID:       p.OrderID,
+		Symbol:        p.Symbol,
+		Quantity:      p.Quantity,
+		TradeQuantity: p.TradeQuantity,
+		PositionMode:  p.PositionMode,
+		MarginMode:    p.MarginMode,
+		Leverage:      p.Leverage,
+		Price:         strconv.FormatFloat(p.Price, 'f', -1, 64),
+		Side:          p.Side,
+		OrderType:     p.OrderType,
+		Effect:        p.Effect,
+		ClientID:      p.ClientID,
+		ReduceOnly:    p.ReduceOnly,
+		Status:        p.Status,
+		Fee:           p.Fee,
+		RealizedPNL:   p.RealizedPNL,
+		TpPrice:       p.TpPrice,
+		TpOrderPrice:  p.TpOrderPrice,
+		SlPrice:       p.SlPrice,
+		TpStopType:    p.TpStopType,
+		TpOrderType:   p.TpOrderType,
+		SlStopType:    p.SlStopType,
+		SlOrderType:   p.SlOrderType,
+		SlOrderPrice:  p.SlOrderPrice,
+		CreateTime:    p.CreateTime,
+		ModifyTime:    p.ModifyTime,
+	}
+}
+
+func paperOrderEvent(o *paper.Order, event model.OrderEventType) model.OrderEvent {
+	return model.OrderEvent{
+		Event:         event,
+		OrderID:       o.ID,
+		Symbol:        o.Symbol,
+		PositionType:  o.MarginMode,
+		PositionMode:  o.PositionMode,
+		Side:          o.Side,
+		Type:          o.OrderType,
+		Quantity:      o.Qty,
+		ReductionOnly: o.ReduceOnly,
+		Price:         o.Price,
+		CreateTime:    o.CreateTime,
+		ModifyTime:    o.ModifyTime,
+		Leverage:      o.Leverage,
+		OrderStatus:   o.Status,
+		Fee:           o.Fee,
+	}
+}
+
+func paperPendingPosition(p *paper.Position, mark float64) model.PendingPosition {
+	return model.PendingPosition{
+		PositionID:    p.ID,
+		Symbol:        p.Symbol,
+		Qty:           p.Qty,
+		EntryValue:    p.EntryValue(),
+		Side:          p.Side,
+		MarginMode:    p.MarginMode,
+		PositionMode:  p.PositionMode,
+		Leverage:      p.Leverage,
+		Fees:          p.Fee,
+		RealizedPNL:   p.RealizedPNL,
+		Margin:        p.Margin(),
+		UnrealizedPNL: p.UnrealizedPNL(mark),
+		AvgOpenPrice:  p.EntryPrice,
+		CreateTime:    p.CreateTime,
+		ModifyTime:    p.ModifyTime,
+	}
+}
+
+func paperHistoricalPosition(p *paper.Position) model.HistoricalPosition {
+	return model.HistoricalPosition{
+		PositionID:   p.ID,
+		Symbol:       p.Symbol,
+		MaxQty:       p.MaxQty,
+		EntryPrice:   p.EntryPrice,
+		ClosePrice:   p.ClosePrice(),
+		Side:         p.Side,
+		PositionMode: p.PositionMode,
+		MarginMode:   p.MarginMode,
+		Leverage:     p.Leverage,
+		Fee:          p.Fee,
+		RealizedPNL:  p.RealizedPNL,
+		Ctime:        p.CreateTime,
+		Mtime:        p.ModifyTime,
+	}
+}
+
+func paperPositionEvent(p *paper.Position, event model.PositionEventType, mark float64) model.PositionEvent {
+	return model.PositionEvent{
+		Event:         event,
+		PositionID:    p.ID,
+		MarginMode:    p.MarginMode,
+		PositionMode:  p.PositionMode,
+		Side:          paperPositionSide(p.Side),
+		Leverage:      p.Leverage,
+		Margin:        p.Margin(),
+		CreateTime:    p.CreateTime,
+		Quantity:      p.Qty,
+		EntryValue:    p.EntryValue(),
+		Symbol:        p.Symbol,
+		RealizedPNL:   p.RealizedPNL,
+		UnrealizedPNL: p.UnrealizedPNL(mark),
+		Fee:           p.Fee,
+	}
+}
+
+func paperHistoricalTrade(t *paper.Trade) model.HistoricalTrade {
+	return model.HistoricalTrade{
+		TradeID:      t.ID,
+		OrderID:      t.Order.ID,
+		Symbol:       t.Order.Symbol,
+		Quantity:     t.Qty,
+		PositionMode: t.Order.PositionMode,
+		MarginMode:   t.Order.MarginMode,
+		Leverage:     t.Order.Leverage,
+		Price:        t.Price,
+		Side:         t.Order.Side,
+		OrderType:    t.Order.OrderType,
+		Effect:       string(t.Order.Effect),
+		ClientID:     t.Order.ClientID,
+		ReduceOnly:   t.Order.ReduceOnly,
+		Fee:          t.Fee,
+		RealizedPNL:  t.RealizedPNL,
+		CreateTime:   t.CreateTime,
+		RoleType:     t.RoleType,
+	}
+}
+
+func paperPendingTpSl(t *paper.TpSlOrder, marginCoin model.MarginCoin) model.PendingTPSLOrder {
+	return model.PendingTPSLOrder{
+		ID:           t.ID,
+		PositionID:   t.PositionID,
+		Symbol:       t.Symbol,
+		Base:         strings.TrimSuffix(t.Symbol.String(), marginCoin.String()),
+		Quote:        marginCoin.String(),
+		TpPrice:      t.TP.Price,
+		TpStopType:   paperOptional(t.TP.StopType),
+		SlPrice:      t.SL.Price,
+		SlStopType:   paperOptional(t.SL.StopType),
+		TpOrderType:  paperOptional(t.TP.OrderType),
+		TpOrderPrice: t.TP.OrderPrice,
+		SlOrderType:  paperOptional(t.SL.OrderType),
+		SlOrderPrice: t.SL.OrderPrice,
+		TpQty:        t.TpQty,
+		SlQty:        t.SlQty,
+	}
+}
+
+func paperHistoricalTpSl(t *paper.TpSlOrder, marginCoin model.MarginCoin) model.HistoricalTPSLOrder {
+	p := paperPendingTpSl(t, marginCoin)
+	return model.HistoricalTPSLOrder{
+		ID:           p.ID,
+		PositionID:   p.PositionID,
+		Symbol:       p.Symbol,
+		Base:         p.Base,
+		Quote:        p.Quote,
+		TpPrice:      p.TpPrice,
+		TpStopType:   p.TpStopType,
+		SlPrice:      p.SlPrice,
+		SlStopType:   p.SlStopType,
+		TpOrderType:  p.TpOrderType,
+		TpOrderPrice: p.TpOrderPrice,
+		SlOrderType:  p.SlOrderType,
+		SlOrderPrice: p.SlOrderPrice,
+		TpQty:        p.TpQty,
+		SlQty:        p.SlQty,
+		Status:       string(t.Status),
+		Ctime:        t.CreateTime,
+		TriggerTime:  t.TriggerTime,
+	}
+}
+
+func paperTpSlEvent(t *paper.TpSlOrder, event model.TpSlEventType) model.TpSlOrderEvent {
+	e := model.TpSlOrderEvent{
+		Event:        event,
+		PositionID:   t.PositionID,
+		OrderID:      t.ID,
+		Symbol:       t.Symbol,
+		Leverage:     t.Leverage,
+		Side:         t.Side,
+		PositionMode: t.PositionMode,
+		Status:       t.Status,
+		CreateTime:   t.CreateTime,
+		Type:         t.Type,
+		TPStopType:   t.TP.StopType,
+		TPOrderType:  t.TP.OrderType,
+		SLStopType:   t.SL.StopType,
+		SLOrderType:  t.SL.OrderType,
+	}
+	for target, value := range map[*float64]*float64{
+		&e.TPQuantity:   t.TpQty,
+		&e.SLQuantity:   t.SlQty,
+		&e.TPPrice:      t.TP.Price,
+		&e.TPOrderPrice: t.TP.OrderPrice,
+		&e.SLPrice:      t.SL.Price,
+		&e.SLOrderPrice: t.SL.OrderPrice,
+	} {
+		if value != nil {
+			*target = *value
+		}
+	}
+	return e
+}
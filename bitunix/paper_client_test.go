@@ -0,0 +1,354 @@
+package bitunix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+type paperRecorder struct {
+	orders    []model.OrderEvent
+	positions []model.PositionEvent
+	balances  []model.BalanceEvent
+	tpsl      []model.TpSlOrderEvent
+}
+
+func (r *paperRecorder) SubscribeOrder(msg *model.OrderChannelMessage) {
+	r.orders = append(r.orders, msg.Data)
+}
+
+func (r *paperRecorder) SubscribePosition(msg *model.PositionChannelMessage) {
+	r.positions = append(r.positions, msg.Data)
+}
+
+func (r *paperRecorder) SubscribeBalance(msg *model.BalanceChannelMessage) {
+	r.balances = append(r.balances, msg.Data)
+}
+
+func (r *paperRecorder) SubscribeTpSlOrder(msg *model.TpSlOrderChannelMessage) {
+	r.tpsl = append(r.tpsl, msg.Data)
+}
+
+func newRecordedPaperClient(t *testing.T, options ...PaperClientOption) (*PaperClient, *paperRecorder) {
+	t.Helper()
+
+	client := NewPaperClient(options...)
+	recorder := &paperRecorder{}
+	require.NoError(t, client.SubscribeOrders(recorder))
+	require.NoError(t, client.SubscribePositions(recorder))
+	require.NoError(t, client.SubscribeBalance(recorder))
+	require.NoError(t, client.SubscribeTpSlOrders(recorder))
+	return client, recorder
+}
+
+func marketOrder(side model.TradeSide, qty float64) *model.OrderRequest {
+	return &model.OrderRequest{Symbol: "BTCUSDT", TradeSide: side, Side: model.SideOpen, OrderType: model.OrderTypeMarket, Qty: qty}
+}
+
+func TestPaperClientMarketOrderOpensAndClosesPosition(t *testing.T) {
+	var api ApiClient
+	client, recorder := newRecordedPaperClient(t, WithPaperFees(0, 0.001))
+	api = client
+	ctx := context.Background()
+
+	_, err := api.PlaceOrder(ctx, marketOrder(model.TradeSideBuy, 1))
+	assert.ErrorIs(t, err, errors.ErrParameterError, "market orders need a price")
+
+	client.SetPrice("BTCUSDT", 100)
+	resp, err := api.PlaceOrder(ctx, marketOrder(model.TradeSideBuy, 2))
+	require.NoError(t, err)
+
+	positions, err := api.GetPendingPositions(ctx, model.PendingPositionParams{})
+	require.NoError(t, err)
+	require.Len(t, positions.Data, 1)
+	assert.Equal(t, model.TradeSideBuy, positions.Data[0].Side)
+	assert.Equal(t, 2.0, positions.Data[0].Qty)
+	assert.Equal(t, 100.0, positions.Data[0].AvgOpenPrice)
+	assert.InDelta(t, 20, positions.Data[0].Margin, 1e-9)
+
+	client.SetPrice("BTCUSDT", 110)
+	positions, err = api.GetPendingPositions(ctx, model.PendingPositionParams{})
+	require.NoError(t, err)
+	assert.InDelta(t, 20, positions.Data[0].UnrealizedPNL, 1e-9)
+
+	// In one-way mode the opposite side reduces the position
+	_, err = api.PlaceOrder(ctx, marketOrder(model.TradeSideSell, 2))
+	require.NoError(t, err)
+
+	positions, err = api.GetPendingPositions(ctx, model.PendingPositionParams{})
+	require.NoError(t, err)
+	assert.Empty(t, positions.Data)
+
+	history, err := api.GetPositionHistory(ctx, model.PositionHistoryParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	require.Len(t, history.Data.Positions, 1)
+	assert.Equal(t, 110.0, history.Data.Positions[0].ClosePrice)
+	assert.InDelta(t, 20, history.Data.Positions[0].RealizedPNL, 1e-9)
+
+	fees := 2*100*0.001 + 2*110*0.001
+	assert.InDelta(t, 10000+20-fees, client.Balance(), 1e-9)
+
+	detail, err := api.GetOrderDetail(ctx, &OrderDetailRequest{OrderID: resp.Data.OrderId})
+	require.NoError(t, err)
+	assert.Equal(t, model.OrderStatusFilled, detail.Data.Status)
+
+	require.Len(t, recorder.orders, 4)
+	assert.Equal(t, model.OrderEventCreate, recorder.orders[0].Event)
+	assert.Equal(t, model.OrderStatusFilled, recorder.orders[1].OrderStatus)
+	require.Len(t, recorder.positions, 2)
+	assert.Equal(t, model.PositionEventOpen, recorder.positions[0].Event)
+	assert.Equal(t, model.PositionSideLong, recorder.positions[0].Side)
+	assert.Equal(t, model.PositionEventClose, recorder.positions[1].Event)
+	assert.NotEmpty(t, recorder.balances)
+}
+
+func TestPaperClientLimitOrderRestsUntilCrossed(t *testing.T) {
+	client, recorder := newRecordedPaperClient(t, WithPaperFees(0.0002, 0.0006))
+	ctx := context.Background()
+	client.SetPrice("BTCUSDT", 100)
+
+	price := 95.0
+	resp, err := client.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol: "BTCUSDT", TradeSide: model.TradeSideBuy, Side: model.SideOpen,
+		OrderType: model.OrderTypeLimit, Price: &price, Qty: 1, ClientID: "limit-1",
+	})
+	require.NoError(t, err)
+
+	balance, err := client.GetAccountBalance(ctx, model.AccountBalanceParams{MarginCoin: "USDT"})
+	require.NoError(t, err)
+	assert.InDelta(t, 9.5, balance.Data.Frozen, 1e-9)
+
+	client.SetPrice("BTCUSDT", 97)
+	pending, err := client.GetPendingOrder(ctx, model.PendingOrderParams{})
+	require.NoError(t, err)
+	require.Len(t, pending.Data.OrderList, 1)
+	assert.Equal(t, "limit-1", pending.Data.OrderList[0].ClientID)
+
+	// The candle dipped through the limit price before closing above it
+	client.MoveTo("BTCUSDT", 94, 98, 96)
+	pending, err = client.GetPendingOrder(ctx, model.PendingOrderParams{})
+	require.NoError(t, err)
+	assert.Empty(t, pending.Data.OrderList)
+
+	trades, err := client.GetTradeHistory(ctx, model.TradeHistoryParams{OrderID: resp.Data.OrderId})
+	require.NoError(t, err)
+	require.Len(t, trades.Data.Trades, 1)
+	assert.Equal(t, 95.0, trades.Data.Trades[0].Price)
+	assert.Equal(t, model.TradeRoleTypeMaker, trades.Data.Trades[0].RoleType)
+	assert.InDelta(t, 95*0.0002, trades.Data.Trades[0].Fee, 1e-12)
+
+	last := recorder.orders[len(recorder.orders)-1]
+	assert.Equal(t, model.OrderStatusFilled, last.OrderStatus)
+
+	_, err = client.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol: "BTCUSDT", TradeSide: model.TradeSideBuy, Side: model.SideOpen,
+		OrderType: model.OrderTypeLimit, Price: &price, Qty: 1, ClientID: "limit-1",
+	})
+	assert.ErrorIs(t, err, errors.ErrDuplicateClientID)
+
+	_, err = client.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol: "BTCUSDT", TradeSide: model.TradeSideBuy, Side: model.SideOpen,
+		OrderType: model.OrderTypeLimit, Price: &price, Qty: 2000,
+	})
+	assert.ErrorIs(t, err, errors.ErrInsufficientBalance)
+}
+
+func TestPaperClientOppositeOrderClosesWithoutMargin(t *testing.T) {
+	client := NewPaperClient(WithPaperBalance(10), WithPaperLeverage(10), WithPaperFees(0, 0))
+	ctx := context.Background()
+	client.SetPrice("BTCUSDT", 100)
+
+	_, err := client.PlaceOrder(ctx, marketOrder(model.TradeSideBuy, 1))
+	require.NoError(t, err)
+
+	_, err = client.PlaceOrder(ctx, marketOrder(model.TradeSideSell, 2))
+	assert.ErrorIs(t, err, errors.ErrInsufficientBalance, "the second unit opens a short")
+
+	_, err = client.PlaceOrder(ctx, marketOrder(model.TradeSideSell, 1))
+	require.NoError(t, err)
+
+	positions, err := client.GetPendingPositions(ctx, model.PendingPositionParams{})
+	require.NoError(t, err)
+	assert.Empty(t, positions.Data)
+}
+
+func TestPaperClientHedgeMode(t *testing.T) {
+	client := NewPaperClient(WithPaperPositionMode(model.PositionModeHedge), WithPaperFees(0, 0))
+	ctx := context.Background()
+	client.SetPrice("BTCUSDT", 100)
+
+	_, err := client.PlaceOrder(ctx, marketOrder(model.TradeSideBuy, 1))
+	require.NoError(t, err)
+	_, err = client.PlaceOrder(ctx, marketOrder(model.TradeSideSell, 2))
+	require.NoError(t, err)
+
+	positions, err := client.GetPendingPositions(ctx, model.PendingPositionParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	require.Len(t, positions.Data, 2)
+	long := positions.Data[0]
+	assert.Equal(t, model.TradeSideBuy, long.Side)
+
+	client.SetPrice("BTCUSDT", 90)
+	// Closing a long is a BUY with tradeSide CLOSE
+	_, err = client.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol: "BTCUSDT", TradeSide: model.TradeSideBuy, Side: model.SideClose,
+		OrderType: model.OrderTypeMarket, Qty: 1, PositionID: long.PositionID,
+	})
+	require.NoError(t, err)
+
+	positions, err = client.GetPendingPositions(ctx, model.PendingPositionParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	require.Len(t, positions.Data, 1)
+	assert.Equal(t, model.TradeSideSell, positions.Data[0].Side)
+	assert.InDelta(t, 10000-10, client.Balance(), 1e-9)
+
+	_, err = client.ChangePositionMode(ctx, &model.ChangePositionModeRequest{PositionMode: model.PositionModeOneWay})
+	assert.ErrorIs(t, err, errors.ErrPositionsModeChange)
+}
+
+func TestPaperClientTriggersTpSl(t *testing.T) {
+	client, recorder := newRecordedPaperClient(t, WithPaperFees(0, 0))
+	ctx := context.Background()
+	client.SetPrice("BTCUSDT", 100)
+
+	tp, sl := 120.0, 90.0
+	order := marketOrder(model.TradeSideBuy, 1)
+	order.TpPrice, order.TpStopType = &tp, model.StopTypeLastPrice
+	order.SlPrice, order.SlStopType = &sl, model.StopTypeLastPrice
+	_, err := client.PlaceOrder(ctx, order)
+	require.NoError(t, err)
+
+	pending, err := client.GetPendingTPSLOrder(ctx, model.PendingTPSLOrderParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	require.Len(t, pending.Data, 1)
+	assert.Equal(t, "BTC", pending.Data[0].Base)
+	assert.Equal(t, 1.0, *pending.Data[0].SlQty)
+
+	client.SetPrice("BTCUSDT", 95)
+	positions, err := client.GetPendingPositions(ctx, model.PendingPositionParams{})
+	require.NoError(t, err)
+	assert.Len(t, positions.Data, 1)
+
+	client.MoveTo("BTCUSDT", 89, 96, 92)
+	positions, err = client.GetPendingPositions(ctx, model.PendingPositionParams{})
+	require.NoError(t, err)
+	assert.Empty(t, positions.Data)
+	assert.InDelta(t, 10000-10, client.Balance(), 1e-9, "closed at the stop loss price")
+
+	history, err := client.GetTPSLOrderHistory(ctx, model.TPSLOrderHistoryParams{Side: model.PositionSideLong})
+	require.NoError(t, err)
+	require.Len(t, history.Data.OrderList, 1)
+	assert.Equal(t, string(model.OrderStatusFilled), history.Data.OrderList[0].Status)
+	assert.NotNil(t, history.Data.OrderList[0].TriggerTime)
+
+	require.Len(t, recorder.tpsl, 2)
+	assert.Equal(t, model.TPSLEventCreate, recorder.tpsl[0].Event)
+	assert.Equal(t, model.TPSLEventClose, recorder.tpsl[1].Event)
+}
+
+func TestPaperClientPositionTpSlFromKLines(t *testing.T) {
+	client := NewPaperClient(WithPaperFees(0, 0))
+	ctx := context.Background()
+	feed := client.KLineFeed("BTCUSDT", model.Interval1Min)
+
+	candle := func(open, high, low, closePrice float64) *model.KLineChannelMessage {
+		return &model.KLineChannelMessage{Symbol: "BTCUSDT", Data: model.KLineEvent{OpenPrice: open, HighPrice: high, LowPrice: low, ClosePrice: closePrice}}
+	}
+	feed.SubscribeKLine(candle(100, 101, 99, 100))
+
+	_, err := client.PlaceOrder(ctx, marketOrder(model.TradeSideSell, 1))
+	require.NoError(t, err)
+	positions, err := client.GetPendingPositions(ctx, model.PendingPositionParams{})
+	require.NoError(t, err)
+	require.Len(t, positions.Data, 1)
+
+	tp := 98.0
+	_, err = client.PlacePositionTpSl(ctx, &model.PositionTPSLOrderRequest{
+		Symbol: "BTCUSDT", PositionID: positions.Data[0].PositionID, TpPrice: &tp, TpStopType: model.StopTypeMarkPrice,
+	})
+	require.NoError(t, err)
+
+	// The earlier low of the running candle does not trigger the take profit again
+	feed.SubscribeKLine(candle(100, 101, 99, 99.5))
+	positions, err = client.GetPendingPositions(ctx, model.PendingPositionParams{})
+	require.NoError(t, err)
+	require.Len(t, positions.Data, 1)
+
+	feed.SubscribeKLine(candle(100, 101, 97.5, 98.5))
+	positions, err = client.GetPendingPositions(ctx, model.PendingPositionParams{})
+	require.NoError(t, err)
+	assert.Empty(t, positions.Data)
+	assert.InDelta(t, 10000+1.5, client.Balance(), 1e-9, "closed at the close of the candle, above the trigger price")
+}
+
+func TestPaperClientStopLossGapFillsAtMarket(t *testing.T) {
+	client := NewPaperClient(WithPaperFees(0, 0), WithPaperSlippage(FixedSlippage(0.5)))
+	ctx := context.Background()
+	client.SetPrice("BTCUSDT", 100)
+
+	sl := 90.0
+	order := marketOrder(model.TradeSideBuy, 1)
+	order.SlPrice, order.SlStopType = &sl, model.StopTypeLastPrice
+	_, err := client.PlaceOrder(ctx, order)
+	require.NoError(t, err)
+	balance := client.Balance()
+
+	// The price gaps through the stop loss
+	client.SetPrice("BTCUSDT", 85)
+	positions, err := client.GetPendingPositions(ctx, model.PendingPositionParams{})
+	require.NoError(t, err)
+	assert.Empty(t, positions.Data)
+	assert.InDelta(t, balance-(100.5-84.5), client.Balance(), 1e-9, "closed at the market price with slippage")
+}
+
+func TestPaperClientDrivesOrderTracker(t *testing.T) {
+	client := NewPaperClient()
+	tracker := NewOrderTracker(client, WithOrderTrackerPollInterval(time.Hour))
+	require.NoError(t, client.SubscribeOrders(tracker))
+	ctx := context.Background()
+	client.SetPrice("ETHUSDT", 3000)
+
+	price := 2900.0
+	resp, err := client.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol: "ETHUSDT", TradeSide: model.TradeSideBuy, Side: model.SideOpen,
+		OrderType: model.OrderTypeLimit, Price: &price, Qty: 1,
+	})
+	require.NoError(t, err)
+	tracker.Track(resp.Data)
+
+	client.SetPrice("ETHUSDT", 2899)
+
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	order, err := tracker.WaitForFill(waitCtx, resp.Data.OrderId)
+	require.NoError(t, err)
+	assert.Equal(t, model.OrderStatusFilled, order.Status)
+	assert.Equal(t, 1.0, order.TradeQuantity)
+}
+
+func TestPaperClientSlippageStopsAtLimitPrice(t *testing.T) {
+	client := NewPaperClient(WithPaperFees(0, 0), WithPaperSlippage(RateSlippage(0.01)))
+	ctx := context.Background()
+	client.SetPrice("BTCUSDT", 100)
+
+	_, err := client.PlaceOrder(ctx, marketOrder(model.TradeSideBuy, 1))
+	require.NoError(t, err)
+
+	price := 100.5
+	_, err = client.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol: "BTCUSDT", TradeSide: model.TradeSideBuy, Side: model.SideOpen,
+		OrderType: model.OrderTypeLimit, Price: &price, Qty: 1,
+	})
+	require.NoError(t, err)
+
+	trades, err := client.GetTradeHistory(ctx, model.TradeHistoryParams{})
+	require.NoError(t, err)
+	require.Len(t, trades.Data.Trades, 2)
+	assert.InDelta(t, 100.5, trades.Data.Trades[0].Price, 1e-9)
+	assert.InDelta(t, 101, trades.Data.Trades[1].Price, 1e-9)
+}
@@ -0,0 +1,87 @@
+package bitunix
+
+import (
+	"github.com/tradingiq/bitunix-client/internal/paper"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+// FeeModel returns the fee charged for filling qty at price
+type FeeModel = paper.FeeModel
+
+type FeeModelFunc = paper.FeeModelFunc
+
+// RateFees charges a share of the filled value, maker for resting limit orders and taker otherwise
+func RateFees(maker, taker float64) FeeModel {
+	return paper.RateFees(maker, taker)
+}
+
+// SlippageModel returns the price a taker fill of qty on side executes at when the market is at price
+type SlippageModel = paper.SlippageModel
+
+type SlippageModelFunc = paper.SlippageModelFunc
+
+// FixedSlippage fills buys amount above and sells amount below the market
+func FixedSlippage(amount float64) SlippageModel {
+	return paper.FixedSlippage(amount)
+}
+
+// RateSlippage moves fills against the taker by a share of the price, 0.0005 is 5 basis points
+func RateSlippage(rate float64) SlippageModel {
+	return paper.RateSlippage(rate)
+}
+
+// paperEvents turns the changes of the engine into websocket messages. They are
+// queued while the account is locked and delivered by update.
+type paperEvents struct {
+	client *PaperClient
+}
+
+func (e paperEvents) OrderChanged(o *paper.Order, event model.OrderEventType) {
+	c := e.client
+	msg := &model.OrderChannelMessage{Channel: model.ChannelOrder, TimeStamp: c.engine.Now().UnixMilli(), Data: paperOrderEvent(o, event)}
+	c.pending = append(c.pending, func() {
+		for _, subscriber := range paperSubscribers(&c.subscriberMtx, c.orderSubscribers) {
+			subscriber.SubscribeOrder(msg)
+		}
+	})
+}
+
+func (e paperEvents) PositionChanged(p *paper.Position, event model.PositionEventType) {
+	c := e.client
+	msg := &model.PositionChannelMessage{Channel: model.ChannelPosition, TimeStamp: c.engine.Now().UnixMilli(), Data: paperPositionEvent(p, event, c.engine.Prices[p.Symbol])}
+	c.pending = append(c.pending, func() {
+		for _, subscriber := range paperSubscribers(&c.subscriberMtx, c.positionSubscribers) {
+			subscriber.SubscribePosition(msg)
+		}
+	})
+}
+
+func (e paperEvents) TpSlChanged(t *paper.TpSlOrder, event model.TpSlEventType) {
+	c := e.client
+	msg := &model.TpSlOrderChannelMessage{Channel: model.ChannelTpSl, Timestamp: c.engine.Now().UnixMilli(), Data: paperTpSlEvent(t, event)}
+	c.pending = append(c.pending, func() {
+		for _, subscriber := range paperSubscribers(&c.subscriberMtx, c.tpSlOrderSubscribers) {
+			subscriber.SubscribeTpSlOrder(msg)
+		}
+	})
+}
+
+func (e paperEvents) BalanceChanged() {
+	c := e.client
+	b := c.engine.Balances()
+	msg := &model.BalanceChannelMessage{Ch: model.ChannelBalance, Ts: c.engine.Now().UnixMilli(), Data: model.BalanceEvent{
+		Coin:            c.marginCoin.String(),
+		Available:       b.Available,
+		Frozen:          b.Frozen,
+		IsolationFrozen: b.IsolationFrozen,
+		CrossFrozen:     b.CrossFrozen,
+		Margin:          b.Margin,
+		IsolationMargin: b.IsolationMargin,
+		CrossMargin:     b.CrossMargin,
+	}}
+	c.pending = append(c.pending, func() {
+		for _, subscriber := range paperSubscribers(&c.subscriberMtx, c.balanceSubscribers) {
+			subscriber.SubscribeBalance(msg)
+		}
+	})
+}
@@ -2,8 +2,10 @@ package bitunix
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 
+	"github.com/tradingiq/bitunix-client/errors"
 	"github.com/tradingiq/bitunix-client/model"
 )
 
@@ -31,3 +33,83 @@ func (c *apiClient) GetPendingPositions(ctx context.Context, params model.Pendin
 
 	return response, nil
 }
+
+func (c *apiClient) AdjustPositionMargin(ctx context.Context, request *model.AdjustPositionMarginRequest) (*model.AdjustPositionMarginResponse, error) {
+	if request.Symbol == "" {
+		return nil, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	if request.MarginCoin == "" {
+		return nil, errors.NewValidationError("marginCoin", "is required", nil)
+	}
+
+	if request.Amount == 0 {
+		return nil, errors.NewValidationError("amount", "must be positive to add or negative to reduce margin", nil)
+	}
+
+	if request.Side != "" && !request.Side.IsValid() {
+		return nil, errors.NewValidationError("side", "must be LONG or SHORT", nil)
+	}
+
+	marshaledRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal adjust position margin request", err)
+	}
+
+	endpoint := "/api/v1/futures/account/adjust_position_margin"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.AdjustPositionMarginResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) FlashClosePosition(ctx context.Context, positionID string) (*model.FlashClosePositionResponse, error) {
+	if positionID == "" {
+		return nil, errors.NewValidationError("positionId", "is required", nil)
+	}
+
+	marshaledRequest, err := json.Marshal(model.FlashClosePositionRequest{PositionID: positionID})
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal flash close position request", err)
+	}
+
+	endpoint := "/api/v1/futures/trade/flash_close_position"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.FlashClosePositionResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *apiClient) CloseAllPositions(ctx context.Context, symbol model.Symbol) (*model.CloseAllPositionsResponse, error) {
+	marshaledRequest, err := json.Marshal(model.CloseAllPositionsRequest{Symbol: symbol.Normalize()})
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal close all positions request", err)
+	}
+
+	endpoint := "/api/v1/futures/trade/close_all_position"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.CloseAllPositionsResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
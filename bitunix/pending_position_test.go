@@ -2,12 +2,17 @@ package bitunix
 
 import (
 	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
 	"net/http"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/tradingiq/bitunix-client/errors"
 	"github.com/tradingiq/bitunix-client/model"
+	"github.com/tradingiq/bitunix-client/rest"
 )
 
 func TestGetPendingPositions(t *testing.T) {
@@ -340,3 +345,296 @@ func TestPendingPositionUnmarshalErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestAdjustPositionMargin(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"data":{"positionId":"12345678","symbol":"BTCUSDT","marginCoin":"USDT","margin":"87.5"},"msg":"Success"}`))
+
+		if r.URL.Path != "/api/v1/futures/account/adjust_position_margin" {
+			t.Errorf("Expected request path /api/v1/futures/account/adjust_position_margin, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("Failed to unmarshal request body: %v", err)
+			return
+		}
+
+		if payload["symbol"] != "BTCUSDT" {
+			t.Errorf("Expected symbol BTCUSDT, got %v", payload["symbol"])
+		}
+
+		if payload["amount"] != "-12.5" {
+			t.Errorf("Expected amount -12.5, got %v", payload["amount"])
+		}
+
+		if payload["positionId"] != "12345678" {
+			t.Errorf("Expected positionId 12345678, got %v", payload["positionId"])
+		}
+	})
+	defer mockAPI.Close()
+
+	client := mockAPI.client
+
+	t.Run("Success", func(t *testing.T) {
+		resp, err := client.AdjustPositionMargin(context.Background(), &model.AdjustPositionMarginRequest{
+			Symbol:     "BTCUSDT",
+			MarginCoin: "USDT",
+			Amount:     -12.5,
+			PositionID: "12345678",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if resp.Code != 0 {
+			t.Errorf("Expected code 0, got %d", resp.Code)
+		}
+
+		if resp.Data.PositionID != "12345678" || resp.Data.Symbol != "BTCUSDT" || resp.Data.MarginCoin != "USDT" {
+			t.Errorf("Unexpected position %+v", resp.Data)
+		}
+
+		if !resp.Data.Margin.Equal(model.MustParseDecimal("87.5")) {
+			t.Errorf("Expected margin 87.5, got %s", resp.Data.Margin)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			request model.AdjustPositionMarginRequest
+			errMsg  string
+		}{
+			{
+				name:    "Missing symbol",
+				request: model.AdjustPositionMarginRequest{MarginCoin: "USDT", Amount: 1},
+				errMsg:  "field symbol",
+			},
+			{
+				name:    "Missing margin coin",
+				request: model.AdjustPositionMarginRequest{Symbol: "BTCUSDT", Amount: 1},
+				errMsg:  "field marginCoin",
+			},
+			{
+				name:    "Zero amount",
+				request: model.AdjustPositionMarginRequest{Symbol: "BTCUSDT", MarginCoin: "USDT"},
+				errMsg:  "field amount",
+			},
+			{
+				name:    "Invalid side",
+				request: model.AdjustPositionMarginRequest{Symbol: "BTCUSDT", MarginCoin: "USDT", Amount: 1, Side: "BOTH"},
+				errMsg:  "field side",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := client.AdjustPositionMargin(context.Background(), &tt.request)
+				if err == nil {
+					t.Fatal("Expected error, got none")
+				}
+				if !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing '%s', got '%s'", tt.errMsg, err.Error())
+				}
+			})
+		}
+	})
+
+	t.Run("Position not exist", func(t *testing.T) {
+		errorAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"code": 30004, "msg": "Position not exist"}`))
+		})
+		defer errorAPI.Close()
+
+		resp, err := errorAPI.client.AdjustPositionMargin(context.Background(), &model.AdjustPositionMarginRequest{
+			Symbol:     "BTCUSDT",
+			MarginCoin: "USDT",
+			Amount:     5,
+		})
+		if !stderrors.Is(err, errors.ErrPositionNotExist) {
+			t.Errorf("Expected ErrPositionNotExist, got %v", err)
+		}
+		if resp != nil {
+			t.Error("Expected nil response on error")
+		}
+	})
+}
+
+func TestAdjustPositionMarginRequestMarshal(t *testing.T) {
+	request := model.AdjustPositionMarginRequest{
+		Symbol:     "ETHUSDT",
+		MarginCoin: "USDT",
+		Amount:     0.0001,
+		Side:       model.PositionSideLong,
+	}
+
+	data, err := json.Marshal(&request)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	expected := `{"amount":"0.0001","symbol":"ETHUSDT","marginCoin":"USDT","side":"LONG"}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, string(data))
+	}
+}
+
+func TestFlashClosePosition(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"data":{"positionId":"12345678","orderId":"11111"},"msg":"Success"}`))
+
+		if r.URL.Path != "/api/v1/futures/trade/flash_close_position" {
+			t.Errorf("Expected request path /api/v1/futures/trade/flash_close_position, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"positionId":"12345678"}` {
+			t.Errorf("Unexpected request body %s", string(body))
+		}
+	})
+	defer mockAPI.Close()
+
+	client := mockAPI.client
+
+	t.Run("Success", func(t *testing.T) {
+		resp, err := client.FlashClosePosition(context.Background(), "12345678")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if resp.Code != 0 {
+			t.Errorf("Expected code 0, got %d", resp.Code)
+		}
+
+		if resp.Message != "Success" {
+			t.Errorf("Expected message Success, got %s", resp.Message)
+		}
+
+		if resp.Data.PositionID != "12345678" || resp.Data.OrderID != "11111" {
+			t.Errorf("Unexpected close result %+v", resp.Data)
+		}
+	})
+
+	t.Run("Null data", func(t *testing.T) {
+		nullAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"code":0,"data":null,"msg":"Success"}`))
+		})
+		defer nullAPI.Close()
+
+		resp, err := nullAPI.client.FlashClosePosition(context.Background(), "12345678")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if resp.Data != (model.ClosePositionResult{}) {
+			t.Errorf("Expected empty close result, got %+v", resp.Data)
+		}
+	})
+
+	t.Run("Missing position ID", func(t *testing.T) {
+		_, err := client.FlashClosePosition(context.Background(), "")
+		if !stderrors.Is(err, errors.ErrValidation) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+}
+
+func TestCloseAllPositions(t *testing.T) {
+	var receivedBody string
+
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"data":{"successList":[{"positionId":"12345678","orderId":"11111"}],"failureList":[{"positionId":"87654321","errorMsg":"Position not exist","errorCode":"30004"}]},"msg":"Success"}`))
+
+		if r.URL.Path != "/api/v1/futures/trade/close_all_position" {
+			t.Errorf("Expected request path /api/v1/futures/trade/close_all_position, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		receivedBody = string(body)
+	})
+	defer mockAPI.Close()
+
+	client := mockAPI.client
+
+	t.Run("Single symbol", func(t *testing.T) {
+		resp, err := client.CloseAllPositions(context.Background(), "btcusdt")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if receivedBody != `{"symbol":"BTCUSDT"}` {
+			t.Errorf("Unexpected request body %s", receivedBody)
+		}
+
+		if len(resp.Data.SuccessList) != 1 || resp.Data.SuccessList[0].OrderID != "11111" {
+			t.Errorf("Unexpected success list %+v", resp.Data.SuccessList)
+		}
+
+		if len(resp.Data.FailureList) != 1 || resp.Data.FailureList[0].ErrorCode != "30004" {
+			t.Errorf("Unexpected failure list %+v", resp.Data.FailureList)
+		}
+	})
+
+	t.Run("All symbols", func(t *testing.T) {
+		_, err := client.CloseAllPositions(context.Background(), "")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if receivedBody != `{}` {
+			t.Errorf("Unexpected request body %s", receivedBody)
+		}
+	})
+}
+
+func TestGetPendingPositionsRetriesRateLimit(t *testing.T) {
+	calls := 0
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if calls == 1 {
+			w.Write([]byte(`{"code":10006,"msg":"Too many requests"}`))
+			return
+		}
+		w.Write([]byte(`{"code":0,"msg":"Success","data":[]}`))
+	})
+	defer mockAPI.Close()
+
+	policy := rest.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	client, _ := NewApiClient("", "", WithBaseURI(mockAPI.server.URL), WithRetryPolicy(policy))
+
+	response, err := client.GetPendingPositions(context.Background(), model.PendingPositionParams{})
+	if err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+
+	if calls != 2 || response.Code != 0 {
+		t.Errorf("Expected success after 2 calls, got code %d after %d", response.Code, calls)
+	}
+}
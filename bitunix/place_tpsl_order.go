@@ -3,13 +3,16 @@ package bitunix
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/tradingiq/bitunix-client/errors"
 	"github.com/tradingiq/bitunix-client/model"
 )
 
 type TPSLOrderBuilder struct {
-	request model.TPSLOrderRequest
+	request       model.TPSLOrderRequest
+	registry      *SymbolRegistry
+	precisionMode PrecisionMode
 }
 
 func NewTPSLOrderBuilder(symbol model.Symbol, positionID string) *TPSLOrderBuilder {
@@ -39,6 +42,12 @@ func (b *TPSLOrderBuilder) WithStopLoss(price float64, qty float64, stopType mod
 	return b
 }
 
+func (b *TPSLOrderBuilder) WithSymbolRegistry(registry *SymbolRegistry, mode PrecisionMode) *TPSLOrderBuilder {
+	b.registry = registry
+	b.precisionMode = mode
+	return b
+}
+
 func (b *TPSLOrderBuilder) Build() (model.TPSLOrderRequest, error) {
 	if b.request.Symbol == "" {
 		return model.TPSLOrderRequest{}, errors.NewValidationError("symbol", "is required", nil)
@@ -48,53 +57,93 @@ func (b *TPSLOrderBuilder) Build() (model.TPSLOrderRequest, error) {
 		return model.TPSLOrderRequest{}, errors.NewValidationError("positionId", "is required", nil)
 	}
 
-	tpSet := b.request.TpPrice != nil
-	slSet := b.request.SlPrice != nil
+	err := validateTPSLLegs(
+		tpslLeg{name: "tp", price: b.request.TpPrice, qty: b.request.TpQty, orderType: b.request.TpOrderType, orderPrice: b.request.TpOrderPrice},
+		tpslLeg{name: "sl", price: b.request.SlPrice, qty: b.request.SlQty, orderType: b.request.SlOrderType, orderPrice: b.request.SlOrderPrice},
+	)
+	if err != nil {
+		return model.TPSLOrderRequest{}, err
+	}
 
-	if !tpSet && !slSet {
-		return model.TPSLOrderRequest{}, errors.NewValidationError("tpPrice/slPrice", "at least one of tpPrice or slPrice must be set", nil)
+	if b.registry != nil {
+		return b.applyPrecision(b.request)
 	}
 
-	tpQtySet := b.request.TpQty != nil && *b.request.TpQty > 0
-	slQtySet := b.request.SlQty != nil && *b.request.SlQty > 0
+	return b.request, nil
+}
 
-	if !tpQtySet && !slQtySet {
-		return model.TPSLOrderRequest{}, errors.NewValidationError("tpQty/slQty", "at least one of tpQty or slQty must be set", nil)
+func (b *TPSLOrderBuilder) applyPrecision(request model.TPSLOrderRequest) (model.TPSLOrderRequest, error) {
+	info, err := b.registry.lookup(request.Symbol)
+	if err != nil {
+		return model.TPSLOrderRequest{}, err
 	}
 
-	if tpSet {
-		if *b.request.TpPrice <= 0 {
-			return model.TPSLOrderRequest{}, errors.NewValidationError("tpPrice", "must be greater than zero", nil)
-		}
+	if request.TpPrice, err = info.normalizePricePtr("tpPrice", request.TpPrice, b.precisionMode); err != nil {
+		return model.TPSLOrderRequest{}, err
+	}
 
-		if !tpQtySet {
-			return model.TPSLOrderRequest{}, errors.NewValidationError("tpQty", "is required when setting tpPrice", nil)
-		}
+	if request.TpOrderPrice, err = info.normalizePricePtr("tpOrderPrice", request.TpOrderPrice, b.precisionMode); err != nil {
+		return model.TPSLOrderRequest{}, err
+	}
 
-		if b.request.TpOrderType == model.OrderTypeLimit {
-			if b.request.TpOrderPrice == nil || *b.request.TpOrderPrice <= 0 {
-				return model.TPSLOrderRequest{}, errors.NewValidationError("tpOrderPrice", "is required when tpOrderType is LIMIT", nil)
-			}
-		}
+	if request.TpQty, err = info.normalizeQtyPtr("tpQty", request.TpQty, request.TpOrderType, b.precisionMode); err != nil {
+		return model.TPSLOrderRequest{}, err
+	}
+
+	if request.SlPrice, err = info.normalizePricePtr("slPrice", request.SlPrice, b.precisionMode); err != nil {
+		return model.TPSLOrderRequest{}, err
 	}
 
-	if slSet {
-		if *b.request.SlPrice <= 0 {
-			return model.TPSLOrderRequest{}, errors.NewValidationError("slPrice", "must be greater than zero", nil)
+	if request.SlOrderPrice, err = info.normalizePricePtr("slOrderPrice", request.SlOrderPrice, b.precisionMode); err != nil {
+		return model.TPSLOrderRequest{}, err
+	}
+
+	if request.SlQty, err = info.normalizeQtyPtr("slQty", request.SlQty, request.SlOrderType, b.precisionMode); err != nil {
+		return model.TPSLOrderRequest{}, err
+	}
+
+	return request, nil
+}
+
+type tpslLeg struct {
+	name       string
+	price      *float64
+	qty        *float64
+	orderType  model.OrderType
+	orderPrice *float64
+}
+
+func validateTPSLLegs(tp, sl tpslLeg) error {
+	if tp.price == nil && sl.price == nil {
+		return errors.NewValidationError("tpPrice/slPrice", "at least one of tpPrice or slPrice must be set", nil)
+	}
+
+	tpQtySet := tp.qty != nil && *tp.qty > 0
+	slQtySet := sl.qty != nil && *sl.qty > 0
+
+	if !tpQtySet && !slQtySet {
+		return errors.NewValidationError("tpQty/slQty", "at least one of tpQty or slQty must be set", nil)
+	}
+
+	for _, leg := range []tpslLeg{tp, sl} {
+		if leg.price == nil {
+			continue
+		}
+
+		if *leg.price <= 0 {
+			return errors.NewValidationError(leg.name+"Price", "must be greater than zero", nil)
 		}
 
-		if !slQtySet {
-			return model.TPSLOrderRequest{}, errors.NewValidationError("slQty", "is required when setting slPrice", nil)
+		if leg.qty == nil || *leg.qty <= 0 {
+			return errors.NewValidationError(leg.name+"Qty", fmt.Sprintf("is required when setting %sPrice", leg.name), nil)
 		}
 
-		if b.request.SlOrderType == model.OrderTypeLimit {
-			if b.request.SlOrderPrice == nil || *b.request.SlOrderPrice <= 0 {
-				return model.TPSLOrderRequest{}, errors.NewValidationError("slOrderPrice", "is required when slOrderType is LIMIT", nil)
-			}
+		if leg.orderType == model.OrderTypeLimit && (leg.orderPrice == nil || *leg.orderPrice <= 0) {
+			return errors.NewValidationError(leg.name+"OrderPrice", fmt.Sprintf("is required when %sOrderType is LIMIT", leg.name), nil)
 		}
 	}
 
-	return b.request, nil
+	return nil
 }
 
 func (c *apiClient) PlaceTpSlOrder(ctx context.Context, request *model.TPSLOrderRequest) (*model.TpSlOrderResponse, error) {
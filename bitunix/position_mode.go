@@ -0,0 +1,80 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+func (c *apiClient) ChangePositionMode(ctx context.Context, request *model.ChangePositionModeRequest) (*model.ChangePositionModeResponse, error) {
+	if !request.PositionMode.IsValid() {
+		return nil, errors.NewValidationError("positionMode", "must be ONE_WAY or HEDGE", nil)
+	}
+
+	marshaledRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal change position mode request", err)
+	}
+
+	endpoint := "/api/v1/futures/account/change_position_mode"
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.ChangePositionModeResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type PositionModeChangeCheck struct {
+	OpenPositions     []model.PendingPosition
+	PendingOrders     []model.PendingOrder
+	PendingOrderTotal int64
+}
+
+func (c *PositionModeChangeCheck) Allowed() bool {
+	return len(c.OpenPositions) == 0 && c.PendingOrderTotal == 0 && len(c.PendingOrders) == 0
+}
+
+func (c *PositionModeChangeCheck) Err() error {
+	if c.Allowed() {
+		return nil
+	}
+
+	orders := c.PendingOrderTotal
+	if orders < int64(len(c.PendingOrders)) {
+		orders = int64(len(c.PendingOrders))
+	}
+
+	return errors.NewValidationError(
+		"positionMode",
+		fmt.Sprintf("cannot be changed with %d open positions and %d pending orders", len(c.OpenPositions), orders),
+		errors.ErrPositionsModeChange,
+	)
+}
+
+func CheckPositionModeChange(ctx context.Context, client ApiClient) (*PositionModeChangeCheck, error) {
+	// The exchange rejects position mode changes (code 20009) while positions or orders are open
+	positions, err := client.GetPendingPositions(ctx, model.PendingPositionParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := client.GetPendingOrder(ctx, model.PendingOrderParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PositionModeChangeCheck{
+		OpenPositions:     positions.Data,
+		PendingOrders:     orders.Data.OrderList,
+		PendingOrderTotal: orders.Data.Total,
+	}, nil
+}
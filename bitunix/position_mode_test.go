@@ -0,0 +1,180 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+func TestChangePositionMode(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/futures/account/change_position_mode" {
+			t.Errorf("Expected request path /api/v1/futures/account/change_position_mode, got %s", r.URL.Path)
+		}
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected request method POST, got %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("Failed to unmarshal request body: %v", err)
+		}
+
+		if payload["positionMode"] != "HEDGE" {
+			t.Errorf("Expected positionMode HEDGE, got %v", payload["positionMode"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"data":[{"positionMode":"HEDGE"}],"msg":"Success"}`))
+	})
+	defer mockAPI.Close()
+
+	resp, err := mockAPI.client.ChangePositionMode(context.Background(), &model.ChangePositionModeRequest{
+		PositionMode: model.PositionModeHedge,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Data) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(resp.Data))
+	}
+
+	if resp.Data[0].PositionMode != model.PositionModeHedge {
+		t.Errorf("Expected position mode HEDGE, got %s", resp.Data[0].PositionMode)
+	}
+}
+
+func TestChangePositionModeValidation(t *testing.T) {
+	client, _ := NewApiClient("test-api-key", "test-api-secret", WithBaseURI("http://example.com"))
+
+	_, err := client.ChangePositionMode(context.Background(), &model.ChangePositionModeRequest{})
+	if err == nil {
+		t.Fatal("Expected validation error, got none")
+	}
+
+	if !stderrors.Is(err, errors.ErrValidation) {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+}
+
+func TestChangePositionModeRejected(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":20009,"msg":"Positions mode cannot be updated"}`))
+	})
+	defer mockAPI.Close()
+
+	_, err := mockAPI.client.ChangePositionMode(context.Background(), &model.ChangePositionModeRequest{
+		PositionMode: model.PositionModeOneWay,
+	})
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	if !stderrors.Is(err, errors.ErrPositionsModeChange) {
+		t.Errorf("Expected ErrPositionsModeChange, got %v", err)
+	}
+}
+
+func TestCheckPositionModeChange(t *testing.T) {
+	tests := []struct {
+		name          string
+		positions     string
+		orders        string
+		expectAllowed bool
+	}{
+		{
+			name:          "nothing open",
+			positions:     `{"code":0,"data":[]}`,
+			orders:        `{"code":0,"data":{"orderList":[],"total":"0"}}`,
+			expectAllowed: true,
+		},
+		{
+			name:          "open position",
+			positions:     `{"code":0,"data":[{"positionId":"1","symbol":"BTCUSDT","qty":"1","side":"BUY","marginMode":"CROSS","positionMode":"ONE_WAY"}]}`,
+			orders:        `{"code":0,"data":{"orderList":[],"total":"0"}}`,
+			expectAllowed: false,
+		},
+		{
+			name:      "pending order",
+			positions: `{"code":0,"data":[]}`,
+			orders: `{"code":0,"data":{"orderList":[{"orderId":"11","symbol":"BTCUSDT","qty":"1","tradeQty":"0","price":"100",` +
+				`"side":"BUY","orderType":"LIMIT","effect":"GTC","status":"NEW","positionMode":"ONE_WAY","marginMode":"CROSS",` +
+				`"ctime":"1","mtime":"1","fee":"0","realizedPNL":"0"}],"total":"1"}}`,
+			expectAllowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+
+				switch r.URL.Path {
+				case "/api/v1/futures/position/get_pending_positions":
+					w.Write([]byte(tt.positions))
+				case "/api/v1/futures/trade/get_pending_orders":
+					w.Write([]byte(tt.orders))
+				default:
+					t.Errorf("Unexpected request path %s", r.URL.Path)
+				}
+			})
+			defer mockAPI.Close()
+
+			check, err := CheckPositionModeChange(context.Background(), mockAPI.client)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if check.Allowed() != tt.expectAllowed {
+				t.Errorf("Expected allowed %v, got %v", tt.expectAllowed, check.Allowed())
+			}
+
+			err = check.Err()
+			if tt.expectAllowed {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			if !stderrors.Is(err, errors.ErrPositionsModeChange) {
+				t.Errorf("Expected ErrPositionsModeChange, got %v", err)
+			}
+
+			if !stderrors.Is(err, errors.ErrValidation) {
+				t.Errorf("Expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckPositionModeChangeError(t *testing.T) {
+	mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":10003,"msg":"Authentication failed"}`))
+	})
+	defer mockAPI.Close()
+
+	check, err := CheckPositionModeChange(context.Background(), mockAPI.client)
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	if check != nil {
+		t.Error("Expected nil check on error")
+	}
+}
@@ -0,0 +1,85 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+type PositionTPSLOrderBuilder struct {
+	request model.PositionTPSLOrderRequest
+}
+
+func NewPositionTPSLOrderBuilder(symbol model.Symbol, positionID string) *PositionTPSLOrderBuilder {
+	return &PositionTPSLOrderBuilder{
+		request: model.PositionTPSLOrderRequest{
+			Symbol:     symbol,
+			PositionID: positionID,
+		},
+	}
+}
+
+func (b *PositionTPSLOrderBuilder) WithTakeProfit(price float64, stopType model.StopType) *PositionTPSLOrderBuilder {
+	b.request.TpPrice = &price
+	b.request.TpStopType = stopType
+	return b
+}
+
+func (b *PositionTPSLOrderBuilder) WithStopLoss(price float64, stopType model.StopType) *PositionTPSLOrderBuilder {
+	b.request.SlPrice = &price
+	b.request.SlStopType = stopType
+	return b
+}
+
+func (b *PositionTPSLOrderBuilder) Build() (model.PositionTPSLOrderRequest, error) {
+	if b.request.Symbol == "" {
+		return model.PositionTPSLOrderRequest{}, errors.NewValidationError("symbol", "is required", nil)
+	}
+
+	if b.request.PositionID == "" {
+		return model.PositionTPSLOrderRequest{}, errors.NewValidationError("positionId", "is required", nil)
+	}
+
+	if b.request.TpPrice == nil && b.request.SlPrice == nil {
+		return model.PositionTPSLOrderRequest{}, errors.NewValidationError("tpPrice/slPrice", "at least one of tpPrice or slPrice must be set", nil)
+	}
+
+	if b.request.TpPrice != nil && *b.request.TpPrice <= 0 {
+		return model.PositionTPSLOrderRequest{}, errors.NewValidationError("tpPrice", "must be greater than zero", nil)
+	}
+
+	if b.request.SlPrice != nil && *b.request.SlPrice <= 0 {
+		return model.PositionTPSLOrderRequest{}, errors.NewValidationError("slPrice", "must be greater than zero", nil)
+	}
+
+	return b.request, nil
+}
+
+func (c *apiClient) PlacePositionTpSl(ctx context.Context, request *model.PositionTPSLOrderRequest) (*model.PositionTpSlOrderResponse, error) {
+	return c.postPositionTpSl(ctx, "/api/v1/futures/tpsl/position/place_order", request)
+}
+
+func (c *apiClient) ModifyPositionTpSl(ctx context.Context, request *model.PositionTPSLOrderRequest) (*model.PositionTpSlOrderResponse, error) {
+	return c.postPositionTpSl(ctx, "/api/v1/futures/tpsl/position/modify_order", request)
+}
+
+func (c *apiClient) postPositionTpSl(ctx context.Context, endpoint string, request *model.PositionTPSLOrderRequest) (*model.PositionTpSlOrderResponse, error) {
+	marshaledRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to marshal position tpsl order request", err)
+	}
+
+	responseBody, err := c.restClient.Post(ctx, endpoint, nil, marshaledRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.PositionTpSlOrderResponse{}
+	if err := handleAPIResponse(responseBody, endpoint, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
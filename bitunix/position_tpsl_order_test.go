@@ -0,0 +1,160 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+func TestPositionTPSLOrderBuilderMethods(t *testing.T) {
+	order, err := NewPositionTPSLOrderBuilder("BTCUSDT", "position123").
+		WithTakeProfit(60000, model.StopTypeMarkPrice).
+		WithStopLoss(40000, model.StopTypeLastPrice).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build position TPSL order: %v", err)
+	}
+
+	if order.Symbol != "BTCUSDT" {
+		t.Errorf("Expected symbol BTCUSDT, got %s", order.Symbol)
+	}
+
+	if order.PositionID != "position123" {
+		t.Errorf("Expected positionId position123, got %s", order.PositionID)
+	}
+
+	if *order.TpPrice != 60000 || order.TpStopType != model.StopTypeMarkPrice {
+		t.Errorf("Unexpected take profit %f %s", *order.TpPrice, order.TpStopType)
+	}
+
+	if *order.SlPrice != 40000 || order.SlStopType != model.StopTypeLastPrice {
+		t.Errorf("Unexpected stop loss %f %s", *order.SlPrice, order.SlStopType)
+	}
+}
+
+func TestPositionTPSLOrderBuilder_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		builder       *PositionTPSLOrderBuilder
+		expectedError string
+	}{
+		{
+			name:          "Missing Symbol",
+			builder:       NewPositionTPSLOrderBuilder("", "position123").WithTakeProfit(100, model.StopTypeLastPrice),
+			expectedError: "validation error: field symbol: is required",
+		},
+		{
+			name:          "Missing Position ID",
+			builder:       NewPositionTPSLOrderBuilder("BTCUSDT", "").WithTakeProfit(100, model.StopTypeLastPrice),
+			expectedError: "validation error: field positionId: is required",
+		},
+		{
+			name:          "Neither TP Nor SL",
+			builder:       NewPositionTPSLOrderBuilder("BTCUSDT", "position123"),
+			expectedError: "validation error: field tpPrice/slPrice: at least one of tpPrice or slPrice must be set",
+		},
+		{
+			name:          "Zero TP Price",
+			builder:       NewPositionTPSLOrderBuilder("BTCUSDT", "position123").WithTakeProfit(0, model.StopTypeLastPrice),
+			expectedError: "validation error: field tpPrice: must be greater than zero",
+		},
+		{
+			name:          "Negative SL Price",
+			builder:       NewPositionTPSLOrderBuilder("BTCUSDT", "position123").WithStopLoss(-5, model.StopTypeLastPrice),
+			expectedError: "validation error: field slPrice: must be greater than zero",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.Build()
+			if err == nil {
+				t.Fatal("Expected error but got nil")
+			}
+
+			if err.Error() != tc.expectedError {
+				t.Errorf("Expected error %q, got %q", tc.expectedError, err.Error())
+			}
+		})
+	}
+}
+
+func TestPlaceAndModifyPositionTpSl(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		callFunc func(ApiClient, *model.PositionTPSLOrderRequest) (*model.PositionTpSlOrderResponse, error)
+	}{
+		{
+			name: "Place",
+			path: "/api/v1/futures/tpsl/position/place_order",
+			callFunc: func(client ApiClient, request *model.PositionTPSLOrderRequest) (*model.PositionTpSlOrderResponse, error) {
+				return client.PlacePositionTpSl(context.Background(), request)
+			},
+		},
+		{
+			name: "Modify",
+			path: "/api/v1/futures/tpsl/position/modify_order",
+			callFunc: func(client ApiClient, request *model.PositionTPSLOrderRequest) (*model.PositionTpSlOrderResponse, error) {
+				return client.ModifyPositionTpSl(context.Background(), request)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAPI := NewMockAPI(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tc.path {
+					t.Errorf("Expected request path %s, got %s", tc.path, r.URL.Path)
+				}
+
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected request method POST, got %s", r.Method)
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				var payload map[string]interface{}
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Errorf("Failed to unmarshal request body: %v", err)
+				}
+
+				if payload["positionId"] != "position123" {
+					t.Errorf("Expected positionId position123, got %v", payload["positionId"])
+				}
+
+				if payload["slPrice"] != "41000.5" {
+					t.Errorf("Expected slPrice 41000.5, got %v", payload["slPrice"])
+				}
+
+				if _, ok := payload["tpPrice"]; ok {
+					t.Errorf("Expected tpPrice to be omitted, got %v", payload["tpPrice"])
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"code":0,"msg":"Success","data":{"orderId":"pos-tpsl-1"}}`))
+			})
+			defer mockAPI.Close()
+
+			request, err := NewPositionTPSLOrderBuilder("BTCUSDT", "position123").
+				WithStopLoss(41000.5, model.StopTypeMarkPrice).
+				Build()
+			if err != nil {
+				t.Fatalf("Failed to build position TPSL order: %v", err)
+			}
+
+			response, err := tc.callFunc(mockAPI.client, &request)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if response.Data.OrderID != "pos-tpsl-1" {
+				t.Errorf("Expected orderId pos-tpsl-1, got %s", response.Data.OrderID)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package bitunix
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+	"go.uber.org/zap"
+)
+
+type DriftEntity string
+
+const (
+	DriftEntityOrder    DriftEntity = "ORDER"
+	DriftEntityPosition DriftEntity = "POSITION"
+)
+
+type DriftKind string
+
+const (
+	// DriftMissing is open on the exchange but not tracked locally
+	DriftMissing DriftKind = "MISSING"
+	// DriftExtra is tracked locally but no longer open on the exchange
+	DriftExtra DriftKind = "EXTRA"
+	// DriftMismatch is tracked on both sides with differing fields
+	DriftMismatch DriftKind = "MISMATCH"
+)
+
+type FieldDrift struct {
+	Field  string
+	Local  any
+	Remote any
+}
+
+type Drift struct {
+	Entity DriftEntity
+	Kind   DriftKind
+	ID     string
+	Symbol model.Symbol
+	Fields []FieldDrift
+}
+
+type DriftReport struct {
+	Time      time.Time
+	Drifts    []Drift
+	Corrected bool
+}
+
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Drifts) > 0
+}
+
+// Reconciler periodically compares the orders and positions of an AccountState
+// with the pending orders and positions served by REST. Websocket updates lost
+// around reconnects or carrying broken fields show up as drift.
+type Reconciler struct {
+	state       *AccountState
+	interval    time.Duration
+	autoCorrect bool
+	callback    func(*DriftReport)
+	logger      *zap.Logger
+}
+
+type ReconcilerOption func(*Reconciler)
+
+func WithReconcileInterval(interval time.Duration) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.interval = interval
+	}
+}
+
+// WithReconcileAutoCorrect replaces the drifted local entries with the REST view
+func WithReconcileAutoCorrect(enabled bool) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.autoCorrect = enabled
+	}
+}
+
+// WithReconcileCallback is notified of every report that contains drift
+func WithReconcileCallback(callback func(*DriftReport)) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.callback = callback
+	}
+}
+
+func WithReconcileLogger(logger *zap.Logger) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.logger = logger
+	}
+}
+
+func NewReconciler(state *AccountState, options ...ReconcilerOption) *Reconciler {
+	r := &Reconciler{
+		state:    state,
+		interval: time.Minute,
+		logger:   zap.NewNop(),
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// Start reconciles in the background until ctx is cancelled
+func (r *Reconciler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := r.Reconcile(ctx); err != nil && ctx.Err() == nil {
+					r.logger.Warn("failed to reconcile account state", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Reconcile fetches the pending orders and positions and compares them with
+// the local state. Entries updated by the websocket while fetching are skipped.
+func (r *Reconciler) Reconcile(ctx context.Context) (*DriftReport, error) {
+	if !r.state.Synced() {
+		return nil, errors.NewInternalError("account state is not synced", nil)
+	}
+
+	// Compared with the websocket timestamps of the state, so taken from the exchange clock
+	fetchedAt := r.state.exchangeTime()
+	orders, err := r.state.fetchOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := r.state.fetchPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := r.state.reconcile(orders, positions, fetchedAt, r.autoCorrect)
+	report.Time = time.UnixMilli(fetchedAt)
+
+	if report.HasDrift() {
+		r.logger.Warn("account state drift detected", zap.Int("drifts", len(report.Drifts)), zap.Bool("corrected", report.Corrected))
+		if r.callback != nil {
+			r.callback(report)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *AccountState) reconcile(orders map[string]AccountOrder, positions map[string]AccountPosition, fetchedAt int64, correct bool) *DriftReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := &DriftReport{}
+	report.Drifts = append(report.Drifts, diffEntities(DriftEntityOrder, s.orders, orders, s.versionsOf("order:"), fetchedAt, orderFieldDrift,
+		func(o AccountOrder) model.Symbol { return o.Symbol })...)
+	report.Drifts = append(report.Drifts, diffEntities(DriftEntityPosition, s.positions, positions, s.versionsOf("position:"), fetchedAt, positionFieldDrift,
+		func(p AccountPosition) model.Symbol { return p.Symbol })...)
+
+	if !correct || !report.HasDrift() {
+		return report
+	}
+
+	for _, drift := range report.Drifts {
+		switch drift.Entity {
+		case DriftEntityOrder:
+			correctEntity(s.orders, orders, drift, func(local, remote AccountOrder) AccountOrder {
+				remote.ClientID = cmp.Or(remote.ClientID, local.ClientID)
+				return remote
+			})
+			s.versions["order:"+drift.ID] = fetchedAt
+		case DriftEntityPosition:
+			correctEntity(s.positions, positions, drift, func(_, remote AccountPosition) AccountPosition { return remote })
+			s.versions["position:"+drift.ID] = fetchedAt
+		}
+	}
+	report.Corrected = true
+
+	return report
+}
+
+func (s *AccountState) versionsOf(prefix string) func(id string) int64 {
+	return func(id string) int64 {
+		return s.versions[prefix+id]
+	}
+}
+
+func diffEntities[T any](entity DriftEntity, local, remote map[string]T, version func(string) int64, fetchedAt int64, fields func(local, remote T) []FieldDrift, symbolOf func(T) model.Symbol) []Drift {
+	var drifts []Drift
+
+	for id, localEntry := range local {
+		if version(id) > fetchedAt {
+			continue
+		}
+
+		remoteEntry, ok := remote[id]
+		if !ok {
+			drifts = append(drifts, Drift{Entity: entity, Kind: DriftExtra, ID: id, Symbol: symbolOf(localEntry)})
+			continue
+		}
+
+		if diff := fields(localEntry, remoteEntry); len(diff) > 0 {
+			drifts = append(drifts, Drift{Entity: entity, Kind: DriftMismatch, ID: id, Symbol: symbolOf(localEntry), Fields: diff})
+		}
+	}
+
+	for id, remoteEntry := range remote {
+		if _, ok := local[id]; ok || version(id) > fetchedAt {
+			continue
+		}
+		drifts = append(drifts, Drift{Entity: entity, Kind: DriftMissing, ID: id, Symbol: symbolOf(remoteEntry)})
+	}
+
+	slices.SortFunc(drifts, func(a, b Drift) int { return cmp.Compare(a.ID, b.ID) })
+
+	return drifts
+}
+
+func correctEntity[T any](local, remote map[string]T, drift Drift, merge func(local, remote T) T) {
+	switch drift.Kind {
+	case DriftExtra:
+		delete(local, drift.ID)
+	case DriftMissing:
+		local[drift.ID] = remote[drift.ID]
+	case DriftMismatch:
+		local[drift.ID] = merge(local[drift.ID], remote[drift.ID])
+	}
+}
+
+type fieldDiff []FieldDrift
+
+func (d *fieldDiff) compare(field string, local, remote any) {
+	if local != remote {
+		*d = append(*d, FieldDrift{Field: field, Local: local, Remote: remote})
+	}
+}
+
+func (d *fieldDiff) compareFloat(field string, local, remote float64) {
+	if math.Abs(local-remote) > 1e-9*max(1, math.Abs(local), math.Abs(remote)) {
+		*d = append(*d, FieldDrift{Field: field, Local: local, Remote: remote})
+	}
+}
+
+func orderFieldDrift(local, remote AccountOrder) []FieldDrift {
+	var diff fieldDiff
+	diff.compare("Symbol", local.Symbol, remote.Symbol)
+	diff.compare("Side", local.Side, remote.Side)
+	diff.compare("Type", local.Type, remote.Type)
+	diff.compare("Status", local.Status, remote.Status)
+	diff.compare("ReduceOnly", local.ReduceOnly, remote.ReduceOnly)
+	diff.compare("Leverage", local.Leverage, remote.Leverage)
+	diff.compareFloat("Quantity", local.Quantity, remote.Quantity)
+	diff.compareFloat("Price", local.Price, remote.Price)
+	return diff
+}
+
+func positionFieldDrift(local, remote AccountPosition) []FieldDrift {
+	var diff fieldDiff
+	diff.compare("Symbol", local.Symbol, remote.Symbol)
+	diff.compare("Side", local.Side, remote.Side)
+	diff.compare("MarginMode", local.MarginMode, remote.MarginMode)
+	diff.compare("Leverage", local.Leverage, remote.Leverage)
+	diff.compareFloat("Quantity", local.Quantity, remote.Quantity)
+	return diff
+}
@@ -0,0 +1,115 @@
+package bitunix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+// driftedAccount seeds a state and then changes the exchange view behind its back:
+// order2 is gone, order3 was partially filled and order5 was never streamed.
+func driftedAccount(t *testing.T) (*AccountState, *accountSnapshotClient) {
+	client := newAccountSnapshotClient()
+	state := NewAccountState(client)
+	require.NoError(t, state.Seed(context.Background()))
+
+	client.orders = []model.PendingOrder{
+		client.orders[0],
+		{OrderID: "order3", Symbol: "BTCUSDT", Status: model.OrderStatusPartFilled, Quantity: 0.5, CreateTime: time.UnixMilli(3000)},
+		{OrderID: "order5", Symbol: "SOLUSDT", Status: model.OrderStatusNew, CreateTime: time.UnixMilli(5000)},
+	}
+
+	return state, client
+}
+
+func TestReconcilerReportsDrift(t *testing.T) {
+	state, _ := driftedAccount(t)
+
+	var notified []*DriftReport
+	reconciler := NewReconciler(state, WithReconcileCallback(func(report *DriftReport) { notified = append(notified, report) }))
+
+	report, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Len(t, notified, 1)
+	assert.Same(t, report, notified[0])
+	assert.False(t, report.Corrected)
+
+	assert.Equal(t, []Drift{
+		{Entity: DriftEntityOrder, Kind: DriftExtra, ID: "order2", Symbol: "ETHUSDT"},
+		{Entity: DriftEntityOrder, Kind: DriftMismatch, ID: "order3", Symbol: "BTCUSDT", Fields: []FieldDrift{{Field: "Quantity", Local: 0.0, Remote: 0.5}}},
+		{Entity: DriftEntityOrder, Kind: DriftMissing, ID: "order5", Symbol: "SOLUSDT"},
+	}, report.Drifts)
+
+	_, ok := state.Order("order2")
+	assert.True(t, ok, "the local view is left untouched without auto correction")
+}
+
+func TestReconcilerAutoCorrects(t *testing.T) {
+	state, _ := driftedAccount(t)
+	reconciler := NewReconciler(state, WithReconcileAutoCorrect(true))
+
+	report, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.Corrected)
+
+	ids := make([]string, 0)
+	for _, order := range state.OpenOrders("") {
+		ids = append(ids, order.OrderID)
+	}
+	assert.Equal(t, []string{"order1", "order3", "order5"}, ids)
+
+	order, _ := state.Order("order3")
+	assert.Equal(t, 0.5, order.Quantity)
+
+	report, err = reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.False(t, report.HasDrift())
+}
+
+func TestReconcilerSkipsEntriesUpdatedWhileFetching(t *testing.T) {
+	state, client := driftedAccount(t)
+	client.orders = client.orders[1:]
+
+	// order1 was updated after the REST request started
+	state.SubscribeOrder(orderUpdate(time.Now().Add(time.Hour).UnixMilli(), "order1", model.OrderEventUpdate, model.OrderStatusNew, 0))
+
+	report, err := NewReconciler(state).Reconcile(context.Background())
+	require.NoError(t, err)
+	for _, drift := range report.Drifts {
+		assert.NotEqual(t, "order1", drift.ID)
+	}
+}
+
+func TestReconcilerComparesWithExchangeClock(t *testing.T) {
+	// The local clock runs an hour behind the exchange
+	timeSync := NewTimeSync()
+	timeSync.offset = time.Hour
+	client := newAccountSnapshotClient()
+	state := NewAccountState(client, WithAccountStateTimeSync(timeSync))
+	require.NoError(t, state.Seed(context.Background()))
+	client.orders = client.orders[1:]
+
+	time.Sleep(5 * time.Millisecond)
+	state.SubscribeOrder(orderUpdate(timeSync.UnixMilli(), "order1", model.OrderEventUpdate, model.OrderStatusNew, 0))
+	time.Sleep(5 * time.Millisecond)
+
+	report, err := NewReconciler(state).Reconcile(context.Background())
+	require.NoError(t, err)
+
+	var ids []string
+	for _, drift := range report.Drifts {
+		ids = append(ids, drift.ID)
+	}
+	assert.Contains(t, ids, "order1")
+}
+
+func TestReconcilerRequiresSyncedState(t *testing.T) {
+	state := NewAccountState(newAccountSnapshotClient())
+
+	_, err := NewReconciler(state).Reconcile(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,259 @@
+package bitunix
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+	"go.uber.org/zap"
+)
+
+type PrecisionMode int
+
+const (
+	PrecisionRound PrecisionMode = iota
+	PrecisionReject
+)
+
+type SymbolInfo struct {
+	Symbol         model.Symbol
+	BasePrecision  int
+	QuotePrecision int
+	TickSize       float64
+	StepSize       float64
+	MinQty         float64
+	MaxLimitQty    float64
+	MaxMarketQty   float64
+	MaxLeverage    int
+	MinLeverage    int
+}
+
+func NewSymbolInfo(pair model.TradingPair) SymbolInfo {
+	return SymbolInfo{
+		Symbol:         pair.Symbol,
+		BasePrecision:  pair.BasePrecision,
+		QuotePrecision: pair.QuotePrecision,
+		TickSize:       math.Pow10(-pair.QuotePrecision),
+		StepSize:       math.Pow10(-pair.BasePrecision),
+		MinQty:         pair.MinTradeVolume,
+		MaxLimitQty:    pair.MaxLimitOrderVolume,
+		MaxMarketQty:   pair.MaxMarketOrderVolume,
+		MaxLeverage:    pair.MaxLeverage,
+		MinLeverage:    pair.MinLeverage,
+	}
+}
+
+func (s SymbolInfo) RoundPrice(price float64) float64 {
+	return roundToPrecision(price, s.QuotePrecision, math.Round)
+}
+
+// Quantities are floored so rounding never increases the requested size
+func (s SymbolInfo) RoundQty(qty float64) float64 {
+	return roundToPrecision(qty, s.BasePrecision, math.Floor)
+}
+
+func (s SymbolInfo) NormalizePrice(field string, price float64, mode PrecisionMode) (float64, error) {
+	rounded := s.RoundPrice(price)
+	if rounded == price {
+		return price, nil
+	}
+
+	if mode == PrecisionReject {
+		return 0, errors.NewValidationError(field, fmt.Sprintf("must be a multiple of tick size %s", formatFloat(s.TickSize)), errors.ErrOrderPriceIssue)
+	}
+
+	if rounded <= 0 {
+		return 0, errors.NewValidationError(field, fmt.Sprintf("rounds to zero with tick size %s", formatFloat(s.TickSize)), errors.ErrOrderPriceIssue)
+	}
+
+	return rounded, nil
+}
+
+func (s SymbolInfo) NormalizeQty(field string, qty float64, orderType model.OrderType, mode PrecisionMode) (float64, error) {
+	rounded := s.RoundQty(qty)
+	if rounded != qty {
+		if mode == PrecisionReject {
+			return 0, errors.NewValidationError(field, fmt.Sprintf("must be a multiple of step size %s", formatFloat(s.StepSize)), errors.ErrOrderQuantityIssue)
+		}
+		qty = rounded
+	}
+
+	if qty < s.MinQty || qty <= 0 {
+		return 0, errors.NewValidationError(field, fmt.Sprintf("must be at least %s", formatFloat(s.MinQty)), errors.ErrOrderQuantityIssue)
+	}
+
+	maxQty := s.MaxLimitQty
+	if orderType == model.OrderTypeMarket {
+		maxQty = s.MaxMarketQty
+	}
+
+	if maxQty > 0 && qty > maxQty {
+		return 0, errors.NewValidationError(field, fmt.Sprintf("must not exceed %s", formatFloat(maxQty)), errors.ErrOrderQuantityIssue)
+	}
+
+	return qty, nil
+}
+
+func (s SymbolInfo) normalizePricePtr(field string, price *float64, mode PrecisionMode) (*float64, error) {
+	if price == nil || *price <= 0 {
+		return price, nil
+	}
+
+	normalized, err := s.NormalizePrice(field, *price, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &normalized, nil
+}
+
+func (s SymbolInfo) normalizeQtyPtr(field string, qty *float64, orderType model.OrderType, mode PrecisionMode) (*float64, error) {
+	if qty == nil || *qty <= 0 {
+		return qty, nil
+	}
+
+	normalized, err := s.NormalizeQty(field, *qty, orderType, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &normalized, nil
+}
+
+func roundToPrecision(value float64, precision int, roundFn func(float64) float64) float64 {
+	pow := math.Pow10(precision)
+	// Nudge away from zero so values like 0.003*1000 = 2.9999999999999996 are not floored a full step
+	scaled := value*pow + math.Copysign(1e-9, value)
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(roundFn(scaled)/pow, 'f', precision, 64), 64)
+	return rounded
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+type SymbolRegistry struct {
+	client          MarketClient
+	refreshInterval time.Duration
+	logger          *zap.Logger
+	symbols         map[model.Symbol]SymbolInfo
+	overrides       map[model.Symbol]SymbolInfo
+	updatedAt       time.Time
+	mu              sync.RWMutex
+}
+
+type SymbolRegistryOption func(*SymbolRegistry)
+
+func WithRefreshInterval(interval time.Duration) SymbolRegistryOption {
+	return func(r *SymbolRegistry) {
+		r.refreshInterval = interval
+	}
+}
+
+func WithSymbolRegistryLogger(logger *zap.Logger) SymbolRegistryOption {
+	return func(r *SymbolRegistry) {
+		r.logger = logger
+	}
+}
+
+func NewSymbolRegistry(client MarketClient, options ...SymbolRegistryOption) *SymbolRegistry {
+	r := &SymbolRegistry{
+		client:          client,
+		refreshInterval: time.Hour,
+		logger:          zap.NewNop(),
+		symbols:         make(map[model.Symbol]SymbolInfo),
+		overrides:       make(map[model.Symbol]SymbolInfo),
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+func (r *SymbolRegistry) Refresh(ctx context.Context) error {
+	response, err := r.client.GetTradingPairs(ctx, model.TradingPairsParams{})
+	if err != nil {
+		return err
+	}
+
+	symbols := make(map[model.Symbol]SymbolInfo, len(response.Data))
+	for _, pair := range response.Data {
+		symbols[pair.Symbol.Normalize()] = NewSymbolInfo(pair)
+	}
+
+	r.mu.Lock()
+	r.symbols = symbols
+	r.updatedAt = time.Now()
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *SymbolRegistry) Start(ctx context.Context) error {
+	if err := r.Refresh(ctx); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(r.refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// A failed refresh keeps serving the last known metadata
+				if err := r.Refresh(ctx); err != nil && ctx.Err() == nil {
+					r.logger.Warn("failed to refresh symbol registry", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Set overrides the metadata of a symbol, it takes precedence over and survives Refresh
+func (r *SymbolRegistry) Set(info SymbolInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.overrides[info.Symbol.Normalize()] = info
+}
+
+func (r *SymbolRegistry) Get(symbol model.Symbol) (SymbolInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	symbol = symbol.Normalize()
+	if info, ok := r.overrides[symbol]; ok {
+		return info, true
+	}
+
+	info, ok := r.symbols[symbol]
+	return info, ok
+}
+
+func (r *SymbolRegistry) UpdatedAt() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.updatedAt
+}
+
+func (r *SymbolRegistry) lookup(symbol model.Symbol) (SymbolInfo, error) {
+	info, ok := r.Get(symbol)
+	if !ok {
+		return SymbolInfo{}, errors.NewValidationError("symbol", fmt.Sprintf("no metadata registered for %s", symbol), errors.ErrMarketNotExists)
+	}
+
+	return info, nil
+}
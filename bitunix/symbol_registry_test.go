@@ -0,0 +1,298 @@
+package bitunix
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+func testSymbolInfo() SymbolInfo {
+	return NewSymbolInfo(model.TradingPair{
+		Symbol:               "BTCUSDT",
+		BasePrecision:        3,
+		QuotePrecision:       1,
+		MinTradeVolume:       0.001,
+		MaxLimitOrderVolume:  100,
+		MaxMarketOrderVolume: 50,
+		MaxLeverage:          125,
+		MinLeverage:          1,
+	})
+}
+
+func testSymbolRegistry() *SymbolRegistry {
+	registry := NewSymbolRegistry(nil)
+	registry.Set(testSymbolInfo())
+	return registry
+}
+
+func TestSymbolInfoRounding(t *testing.T) {
+	info := testSymbolInfo()
+
+	if info.TickSize != 0.1 {
+		t.Errorf("Expected tick size 0.1, got %v", info.TickSize)
+	}
+
+	if info.StepSize != 0.001 {
+		t.Errorf("Expected step size 0.001, got %v", info.StepSize)
+	}
+
+	testCases := []struct {
+		name     string
+		actual   float64
+		expected float64
+	}{
+		{"price rounds down", info.RoundPrice(50000.04), 50000.0},
+		{"price rounds up", info.RoundPrice(50000.05), 50000.1},
+		{"price already on tick", info.RoundPrice(0.3), 0.3},
+		{"qty floors", info.RoundQty(0.0019), 0.001},
+		{"qty on step with float noise", info.RoundQty(0.1 + 0.2), 0.3},
+		{"qty already on step", info.RoundQty(0.003), 0.003},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.actual != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, tc.actual)
+			}
+		})
+	}
+}
+
+func TestSymbolInfoNormalizeQty(t *testing.T) {
+	info := testSymbolInfo()
+
+	qty, err := info.NormalizeQty("qty", 1.23456, model.OrderTypeLimit, PrecisionRound)
+	if err != nil || qty != 1.234 {
+		t.Errorf("Expected 1.234, got %v (%v)", qty, err)
+	}
+
+	_, err = info.NormalizeQty("qty", 1.23456, model.OrderTypeLimit, PrecisionReject)
+	if !stderrors.Is(err, errors.ErrOrderQuantityIssue) {
+		t.Errorf("Expected ErrOrderQuantityIssue, got %v", err)
+	}
+
+	_, err = info.NormalizeQty("qty", 0.0009, model.OrderTypeLimit, PrecisionRound)
+	if err == nil || err.Error() != "validation error: field qty: must be at least 0.001" {
+		t.Errorf("Unexpected error for qty below minimum: %v", err)
+	}
+
+	_, err = info.NormalizeQty("qty", 60, model.OrderTypeMarket, PrecisionRound)
+	if err == nil || err.Error() != "validation error: field qty: must not exceed 50" {
+		t.Errorf("Unexpected error for market qty above maximum: %v", err)
+	}
+
+	if _, err := info.NormalizeQty("qty", 60, model.OrderTypeLimit, PrecisionRound); err != nil {
+		t.Errorf("Expected limit qty 60 to be accepted, got %v", err)
+	}
+}
+
+func TestSymbolInfoNormalizePrice(t *testing.T) {
+	info := testSymbolInfo()
+
+	_, err := info.NormalizePrice("price", 50000.05, PrecisionReject)
+	if !stderrors.Is(err, errors.ErrOrderPriceIssue) || !stderrors.Is(err, errors.ErrValidation) {
+		t.Errorf("Expected validation error wrapping ErrOrderPriceIssue, got %v", err)
+	}
+
+	_, err = info.NormalizePrice("price", 0.04, PrecisionRound)
+	if !stderrors.Is(err, errors.ErrOrderPriceIssue) {
+		t.Errorf("Expected ErrOrderPriceIssue for price rounding to zero, got %v", err)
+	}
+}
+
+func TestOrderBuilderWithSymbolRegistry(t *testing.T) {
+	registry := testSymbolRegistry()
+
+	order, err := NewOrderBuilder("btcusdt", model.TradeSideBuy, model.SideOpen, 0.12345).
+		WithOrderType(model.OrderTypeLimit).
+		WithPrice(50000.06).
+		WithTakeProfit(55000.123, model.StopTypeLastPrice, model.OrderTypeMarket).
+		WithStopLoss(45000.98, model.StopTypeLastPrice, model.OrderTypeMarket).
+		WithSymbolRegistry(registry, PrecisionRound).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build order: %v", err)
+	}
+
+	if order.Qty != 0.123 {
+		t.Errorf("Expected qty 0.123, got %v", order.Qty)
+	}
+
+	if *order.Price != 50000.1 {
+		t.Errorf("Expected price 50000.1, got %v", *order.Price)
+	}
+
+	if *order.TpPrice != 55000.1 {
+		t.Errorf("Expected tp price 55000.1, got %v", *order.TpPrice)
+	}
+
+	if *order.SlPrice != 45001.0 {
+		t.Errorf("Expected sl price 45001, got %v", *order.SlPrice)
+	}
+
+	_, err = NewOrderBuilder("BTCUSDT", model.TradeSideBuy, model.SideOpen, 0.12345).
+		WithSymbolRegistry(registry, PrecisionReject).
+		Build()
+	if !stderrors.Is(err, errors.ErrOrderQuantityIssue) {
+		t.Errorf("Expected ErrOrderQuantityIssue, got %v", err)
+	}
+
+	_, err = NewOrderBuilder("ETHUSDT", model.TradeSideBuy, model.SideOpen, 1).
+		WithSymbolRegistry(registry, PrecisionRound).
+		Build()
+	if !stderrors.Is(err, errors.ErrMarketNotExists) {
+		t.Errorf("Expected ErrMarketNotExists for unknown symbol, got %v", err)
+	}
+}
+
+func TestTPSLOrderBuilderWithSymbolRegistry(t *testing.T) {
+	registry := testSymbolRegistry()
+
+	order, err := NewTPSLOrderBuilder("BTCUSDT", "position123").
+		WithTakeProfit(55000.04, 0.5555, model.StopTypeLastPrice, model.OrderTypeLimit, 54999.96).
+		WithSymbolRegistry(registry, PrecisionRound).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build TPSL order: %v", err)
+	}
+
+	if *order.TpPrice != 55000.0 || *order.TpOrderPrice != 55000.0 || *order.TpQty != 0.555 {
+		t.Errorf("Unexpected rounded TPSL order tp=%v orderPrice=%v qty=%v", *order.TpPrice, *order.TpOrderPrice, *order.TpQty)
+	}
+
+	_, err = NewTPSLOrderBuilder("BTCUSDT", "position123").
+		WithStopLoss(45000.05, 1, model.StopTypeLastPrice, model.OrderTypeMarket, 0).
+		WithSymbolRegistry(registry, PrecisionReject).
+		Build()
+	if err == nil || err.Error() != "validation error: field slPrice: must be a multiple of tick size 0.1" {
+		t.Errorf("Unexpected error for off-tick stop loss: %v", err)
+	}
+}
+
+func TestSymbolRegistryRefresh(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/futures/market/trading_pairs" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+
+		precision := "1"
+		if calls.Add(1) > 1 {
+			precision = "2"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"msg":"Success","data":[{"symbol":"BTCUSDT","base":"BTC","quote":"USDT","minTradeVolume":"0.001","basePrecision":3,"quotePrecision":` + precision + `,"maxLeverage":125}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewMarketClient(WithBaseURI(server.URL))
+	if err != nil {
+		t.Fatalf("Failed to create market client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	registry := NewSymbolRegistry(client, WithRefreshInterval(20*time.Millisecond))
+	if err := registry.Start(ctx); err != nil {
+		t.Fatalf("Failed to start registry: %v", err)
+	}
+
+	info, ok := registry.Get("btcusdt")
+	if !ok {
+		t.Fatal("Expected BTCUSDT to be registered")
+	}
+
+	if info.QuotePrecision != 1 || info.MaxLeverage != 125 || info.MinQty != 0.001 {
+		t.Errorf("Unexpected symbol info %+v", info)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if info, _ := registry.Get("BTCUSDT"); info.QuotePrecision == 2 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Error("Expected registry to pick up refreshed metadata")
+}
+
+func TestSymbolRegistryRefreshKeepsOverrides(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"msg":"Success","data":[{"symbol":"BTCUSDT","base":"BTC","quote":"USDT","minTradeVolume":"0.001","basePrecision":3,"quotePrecision":1,"maxLeverage":125}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewMarketClient(WithBaseURI(server.URL))
+	if err != nil {
+		t.Fatalf("Failed to create market client: %v", err)
+	}
+
+	registry := NewSymbolRegistry(client)
+	registry.Set(NewSymbolInfo(model.TradingPair{Symbol: "ethusdt", BasePrecision: 2, QuotePrecision: 2}))
+	registry.Set(NewSymbolInfo(model.TradingPair{Symbol: "BTCUSDT", BasePrecision: 4, QuotePrecision: 0}))
+
+	if err := registry.Refresh(context.Background()); err != nil {
+		t.Fatalf("Failed to refresh registry: %v", err)
+	}
+
+	if info, ok := registry.Get("ETHUSDT"); !ok || info.QuotePrecision != 2 {
+		t.Errorf("Expected ETHUSDT override to survive refresh, got %+v", info)
+	}
+
+	if info, _ := registry.Get("BTCUSDT"); info.BasePrecision != 4 || info.QuotePrecision != 0 {
+		t.Errorf("Expected BTCUSDT override to take precedence over refreshed metadata, got %+v", info)
+	}
+}
+
+func TestSymbolRegistryStartError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":10001,"msg":"Network error"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewMarketClient(WithBaseURI(server.URL))
+	registry := NewSymbolRegistry(client)
+
+	if err := registry.Start(context.Background()); !stderrors.Is(err, errors.ErrNetwork) {
+		t.Errorf("Expected ErrNetwork, got %v", err)
+	}
+
+	if _, ok := registry.Get("BTCUSDT"); ok {
+		t.Error("Expected empty registry after failed load")
+	}
+}
+
+func TestOrderBuilderDecimalWithSymbolRegistry(t *testing.T) {
+	order, err := NewOrderBuilder("BTCUSDT", model.TradeSideBuy, model.SideOpen, 0).
+		WithOrderType(model.OrderTypeLimit).
+		WithQtyDecimal(model.MustParseDecimal("0.12345")).
+		WithPriceDecimal(model.MustParseDecimal("50000.06")).
+		WithSymbolRegistry(testSymbolRegistry(), PrecisionRound).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build order: %v", err)
+	}
+
+	if order.QtyDecimal.String() != "0.123" || order.Qty != 0.123 {
+		t.Errorf("Expected qty 0.123, got %s / %v", order.QtyDecimal, order.Qty)
+	}
+
+	if order.PriceDecimal.String() != "50000.1" || *order.Price != 50000.1 {
+		t.Errorf("Expected price 50000.1, got %s / %v", order.PriceDecimal, *order.Price)
+	}
+}
@@ -0,0 +1,173 @@
+package bitunix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"go.uber.org/zap"
+)
+
+// TimeSync tracks the offset between the local clock and the exchange clock
+type TimeSync struct {
+	uri        string
+	httpClient *http.Client
+	interval   time.Duration
+	smoothing  float64
+	logger     *zap.Logger
+	now        func() time.Time
+	offset     time.Duration
+	synced     bool
+	lastSync   time.Time
+	mu         sync.RWMutex
+}
+
+type TimeSyncOption func(*TimeSync)
+
+func WithTimeSyncURI(uri string) TimeSyncOption {
+	return func(t *TimeSync) {
+		t.uri = uri
+	}
+}
+
+func WithTimeSyncHTTPClient(client *http.Client) TimeSyncOption {
+	return func(t *TimeSync) {
+		t.httpClient = client
+	}
+}
+
+func WithTimeSyncInterval(interval time.Duration) TimeSyncOption {
+	return func(t *TimeSync) {
+		t.interval = interval
+	}
+}
+
+// WithTimeSyncSmoothing sets the weight of a new sample in the offset, between 0 and 1
+func WithTimeSyncSmoothing(smoothing float64) TimeSyncOption {
+	return func(t *TimeSync) {
+		t.smoothing = smoothing
+	}
+}
+
+func WithTimeSyncLogger(logger *zap.Logger) TimeSyncOption {
+	return func(t *TimeSync) {
+		t.logger = logger
+	}
+}
+
+func NewTimeSync(options ...TimeSyncOption) *TimeSync {
+	t := &TimeSync{
+		uri:        "https://fapi.bitunix.com/",
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		interval:   time.Minute,
+		smoothing:  0.2,
+		logger:     zap.NewNop(),
+		now:        time.Now,
+	}
+
+	for _, option := range options {
+		option(t)
+	}
+
+	if t.smoothing <= 0 || t.smoothing > 1 {
+		t.smoothing = 1
+	}
+
+	return t
+}
+
+// Sync measures the exchange clock from the Date header of a response
+func (t *TimeSync) Sync(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, t.uri, nil)
+	if err != nil {
+		return errors.NewInternalError("creating time sync request", err)
+	}
+
+	sent := t.now()
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return errors.NewNetworkError("time sync", fmt.Sprintf("request to %s failed", t.uri), err)
+	}
+	resp.Body.Close()
+	received := t.now()
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return errors.NewInternalError(fmt.Sprintf("invalid Date header %q", resp.Header.Get("Date")), err)
+	}
+
+	// The Date header has second resolution, so the server time lies anywhere in the following second
+	serverTime = serverTime.Add(500 * time.Millisecond)
+	midpoint := sent.Add(received.Sub(sent) / 2)
+	sample := serverTime.Sub(midpoint)
+
+	t.mu.Lock()
+	if t.synced {
+		t.offset += time.Duration(t.smoothing * float64(sample-t.offset))
+	} else {
+		t.offset = sample
+		t.synced = true
+	}
+	t.lastSync = received
+	offset := t.offset
+	t.mu.Unlock()
+
+	t.logger.Debug("synchronized server time", zap.Duration("sample", sample), zap.Duration("offset", offset))
+
+	return nil
+}
+
+func (t *TimeSync) Start(ctx context.Context) error {
+	if err := t.Sync(ctx); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// A failed sync keeps the last measured offset
+				if err := t.Sync(ctx); err != nil && ctx.Err() == nil {
+					t.logger.Warn("failed to synchronize server time", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Offset is positive when the local clock is behind the exchange
+func (t *TimeSync) Offset() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.offset
+}
+
+func (t *TimeSync) LastSync() time.Time {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.lastSync
+}
+
+func (t *TimeSync) Now() time.Time {
+	return t.now().Add(t.Offset())
+}
+
+func (t *TimeSync) UnixMilli() int64 {
+	return t.Now().UnixMilli()
+}
+
+func (t *TimeSync) Unix() int64 {
+	return t.Now().Unix()
+}
@@ -0,0 +1,122 @@
+package bitunix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+func newDateServer(serverTime *time.Time) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestTimeSyncMeasuresOffset(t *testing.T) {
+	local := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	serverTime := local.Add(5 * time.Second)
+	server := newDateServer(&serverTime)
+	defer server.Close()
+
+	ts := NewTimeSync(WithTimeSyncURI(server.URL), WithTimeSyncSmoothing(0.5))
+	ts.now = func() time.Time { return local }
+
+	if err := ts.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+
+	// The Date header is truncated to the second, half a second is added to center the estimate
+	if ts.Offset() != 5500*time.Millisecond {
+		t.Errorf("Expected offset 5.5s, got %v", ts.Offset())
+	}
+
+	if !ts.LastSync().Equal(local) {
+		t.Errorf("Expected last sync %v, got %v", local, ts.LastSync())
+	}
+
+	if ts.UnixMilli() != local.Add(5500*time.Millisecond).UnixMilli() {
+		t.Errorf("Expected corrected timestamp, got %d", ts.UnixMilli())
+	}
+
+	serverTime = local.Add(-2 * time.Second)
+	if err := ts.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+
+	// Smoothed halfway between the previous offset of 5.5s and the new sample of -1.5s
+	if ts.Offset() != 2*time.Second {
+		t.Errorf("Expected smoothed offset 2s, got %v", ts.Offset())
+	}
+}
+
+func TestTimeSyncInvalidDateHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["Date"] = nil
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ts := NewTimeSync(WithTimeSyncURI(server.URL))
+	if err := ts.Sync(context.Background()); err == nil {
+		t.Error("Expected error for missing Date header")
+	}
+
+	if ts.Offset() != 0 || !ts.LastSync().IsZero() {
+		t.Errorf("Expected offset to stay unset, got %v", ts.Offset())
+	}
+}
+
+func TestApiClientSignsWithTimeSync(t *testing.T) {
+	serverTime := time.Now().Add(-time.Hour)
+	dateServer := newDateServer(&serverTime)
+	defer dateServer.Close()
+
+	ts := NewTimeSync(WithTimeSyncURI(dateServer.URL))
+	if err := ts.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+
+	var timestamp int64
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp, _ = strconv.ParseInt(r.Header.Get("Timestamp"), 10, 64)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0,"msg":"Success","data":[]}`))
+	}))
+	defer apiServer.Close()
+
+	client, _ := NewApiClient("key", "secret", WithBaseURI(apiServer.URL), WithTimeSync(ts))
+	if _, err := client.GetPendingPositions(context.Background(), model.PendingPositionParams{}); err != nil {
+		t.Fatalf("GetPendingPositions returned error: %v", err)
+	}
+
+	skew := time.Duration(time.Now().UnixMilli()-timestamp) * time.Millisecond
+	if skew < 58*time.Minute || skew > 61*time.Minute {
+		t.Errorf("Expected request timestamp about an hour behind the local clock, got %v", skew)
+	}
+}
+
+func TestWebsocketSignerWithTimestamp(t *testing.T) {
+	signer := WebsocketSignerWithTimestamp("key", "secret", func() int64 { return 1234567890 })
+
+	bytes, err := signer()
+	if err != nil {
+		t.Fatalf("signer returned error: %v", err)
+	}
+
+	var message loginMessage
+	if err := json.Unmarshal(bytes, &message); err != nil {
+		t.Fatalf("failed to unmarshal login message: %v", err)
+	}
+
+	if message.Args[0].Timestamp != 1234567890 {
+		t.Errorf("Expected timestamp 1234567890, got %d", message.Args[0].Timestamp)
+	}
+}
@@ -31,6 +31,23 @@ type websocketClient struct {
 	processFunc      func(bytes []byte)
 	logLevel         model.LogLevel
 	logger           *zap.Logger
+	timeSync         *TimeSync
+	recorder         *WebsocketRecorder
+	replayer         *WebsocketReplayer
+}
+
+// transport puts a configured recorder or replayer in front of the connection
+func (ws *websocketClient) transport(client wsClientInterface) wsClientInterface {
+	if ws.replayer != nil {
+		ws.workerPoolSize = 1
+		return ws.replayer
+	}
+
+	if ws.recorder != nil {
+		return &recordingClient{wsClientInterface: client, recorder: ws.recorder}
+	}
+
+	return client
 }
 
 func (ws *websocketClient) Connect() error {
@@ -92,9 +109,14 @@ func (ws *websocketClient) worker(ctx context.Context) {
 
 type publicWebsocketClient struct {
 	*websocketClient
-	subscriberMtx sync.Mutex
-	klineHandlers map[KLineSubscriber]struct{}
-	logger        *zap.Logger
+	subscriberMtx   sync.Mutex
+	klineHandlers   map[KLineSubscriber]struct{}
+	depthHandlers   map[DepthSubscriber]struct{}
+	tradeHandlers   map[TradeSubscriber]struct{}
+	tickerHandlers  map[TickerSubscriber]struct{}
+	tickersHandlers map[TickersSubscriber]struct{}
+	priceHandlers   map[PriceSubscriber]struct{}
+	logger          *zap.Logger
 }
 
 type WebsocketClientOption func(*websocketClient)
@@ -139,11 +161,11 @@ func NewPublicWebsocket(ctx context.Context, options ...WebsocketClientOption) (
 		wsOptions = append(wsOptions, websocket.WithLogLevel(wsc.logLevel))
 	}
 
-	wsc.client = websocket.New(
+	wsc.client = wsc.transport(websocket.New(
 		ctx,
 		wsc.uri,
 		wsOptions...,
-	)
+	))
 
 	if wsc.workerPoolSize <= 0 {
 		wsc.workerPoolSize = 10
@@ -166,6 +188,11 @@ func NewPublicWebsocket(ctx context.Context, options ...WebsocketClientOption) (
 		websocketClient: wsc,
 		subscriberMtx:   sync.Mutex{},
 		klineHandlers:   make(map[KLineSubscriber]struct{}),
+		depthHandlers:   make(map[DepthSubscriber]struct{}),
+		tradeHandlers:   make(map[TradeSubscriber]struct{}),
+		tickerHandlers:  make(map[TickerSubscriber]struct{}),
+		tickersHandlers: make(map[TickersSubscriber]struct{}),
+		priceHandlers:   make(map[PriceSubscriber]struct{}),
 		logger:          logger,
 	}
 	wsc.processFunc = client.processMessage
@@ -183,6 +210,11 @@ func (ws *publicWebsocketClient) Connect() error {
 	}
 
 	ws.klineHandlers = make(map[KLineSubscriber]struct{})
+	ws.depthHandlers = make(map[DepthSubscriber]struct{})
+	ws.tradeHandlers = make(map[TradeSubscriber]struct{})
+	ws.tickerHandlers = make(map[TickerSubscriber]struct{})
+	ws.tickersHandlers = make(map[TickersSubscriber]struct{})
+	ws.priceHandlers = make(map[PriceSubscriber]struct{})
 
 	return nil
 }
@@ -287,9 +319,397 @@ func (ws *publicWebsocketClient) UnsubscribeKLine(subscriber KLineSubscriber) er
 	return nil
 }
 
+func (ws *publicWebsocketClient) writeSubscription(op, symbol, channel string) error {
+	req := SubscribeRequest{
+		Op: op,
+		Args: []interface{}{
+			SubscribeChannelRequest{
+				Symbol: symbol,
+				Ch:     channel,
+			},
+		},
+	}
+
+	bytes, err := json.Marshal(req)
+	if err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to marshal %s request", op), err)
+	}
+
+	if err := ws.client.Write(bytes); err != nil {
+		return errors.NewWebsocketError(op, fmt.Sprintf("failed to send %s request", op), err)
+	}
+
+	return nil
+}
+
+func (ws *publicWebsocketClient) SubscribeTrade(subscriber TradeSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	symbol := subscriber.SubscribeSymbol().Normalize()
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	for existingSubscriber := range ws.tradeHandlers {
+		if existingSubscriber.SubscribeSymbol().Normalize() == symbol {
+			ws.tradeHandlers[subscriber] = struct{}{}
+			return nil
+		}
+	}
+
+	ws.tradeHandlers[subscriber] = struct{}{}
+
+	return ws.writeSubscription("subscribe", symbol.String(), model.ChannelTrade.String())
+}
+
+func (ws *publicWebsocketClient) UnsubscribeTrade(subscriber TradeSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	symbol := subscriber.SubscribeSymbol().Normalize()
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	delete(ws.tradeHandlers, subscriber)
+
+	for remainingSubscriber := range ws.tradeHandlers {
+		if remainingSubscriber.SubscribeSymbol().Normalize() == symbol {
+			return nil
+		}
+	}
+
+	return ws.writeSubscription("unsubscribe", symbol.String(), model.ChannelTrade.String())
+}
+
+func (ws *publicWebsocketClient) SubscribeTicker(subscriber TickerSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	symbol := subscriber.SubscribeSymbol().Normalize()
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	for existingSubscriber := range ws.tickerHandlers {
+		if existingSubscriber.SubscribeSymbol().Normalize() == symbol {
+			ws.tickerHandlers[subscriber] = struct{}{}
+			return nil
+		}
+	}
+
+	ws.tickerHandlers[subscriber] = struct{}{}
+
+	return ws.writeSubscription("subscribe", symbol.String(), model.ChannelTicker.String())
+}
+
+func (ws *publicWebsocketClient) UnsubscribeTicker(subscriber TickerSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	symbol := subscriber.SubscribeSymbol().Normalize()
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	delete(ws.tickerHandlers, subscriber)
+
+	for remainingSubscriber := range ws.tickerHandlers {
+		if remainingSubscriber.SubscribeSymbol().Normalize() == symbol {
+			return nil
+		}
+	}
+
+	return ws.writeSubscription("unsubscribe", symbol.String(), model.ChannelTicker.String())
+}
+
+func (ws *publicWebsocketClient) SubscribePrice(subscriber PriceSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	symbol := subscriber.SubscribeSymbol().Normalize()
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	for existingSubscriber := range ws.priceHandlers {
+		if existingSubscriber.SubscribeSymbol().Normalize() == symbol {
+			ws.priceHandlers[subscriber] = struct{}{}
+			return nil
+		}
+	}
+
+	ws.priceHandlers[subscriber] = struct{}{}
+
+	return ws.writeSubscription("subscribe", symbol.String(), model.ChannelPrice.String())
+}
+
+func (ws *publicWebsocketClient) UnsubscribePrice(subscriber PriceSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	symbol := subscriber.SubscribeSymbol().Normalize()
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	delete(ws.priceHandlers, subscriber)
+
+	for remainingSubscriber := range ws.priceHandlers {
+		if remainingSubscriber.SubscribeSymbol().Normalize() == symbol {
+			return nil
+		}
+	}
+
+	return ws.writeSubscription("unsubscribe", symbol.String(), model.ChannelPrice.String())
+}
+
+// SubscribeTickers subscribes to the tickers of all symbols
+func (ws *publicWebsocketClient) SubscribeTickers(subscriber TickersSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	needsSubscription := len(ws.tickersHandlers) == 0
+	ws.tickersHandlers[subscriber] = struct{}{}
+
+	if needsSubscription {
+		return ws.writeSubscription("subscribe", "", model.ChannelTickers.String())
+	}
+
+	return nil
+}
+
+func (ws *publicWebsocketClient) UnsubscribeTickers(subscriber TickersSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	delete(ws.tickersHandlers, subscriber)
+
+	if len(ws.tickersHandlers) == 0 {
+		return ws.writeSubscription("unsubscribe", "", model.ChannelTickers.String())
+	}
+
+	return nil
+}
+
+func depthChannelName(book model.DepthBook) string {
+	return fmt.Sprintf("depth_%s", book)
+}
+
+func (ws *publicWebsocketClient) SubscribeDepth(subscriber DepthSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	symbol := subscriber.SubscribeSymbol().Normalize()
+	book := subscriber.SubscribeDepthBook().Normalize()
+	if !book.IsValid() {
+		return errors.NewValidationError("book", fmt.Sprintf("invalid depth book %s", book), nil)
+	}
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	needsSubscription := true
+	for existingSubscriber := range ws.depthHandlers {
+		if existingSubscriber.SubscribeSymbol().Normalize() == symbol &&
+			existingSubscriber.SubscribeDepthBook().Normalize() == book {
+			needsSubscription = false
+			break
+		}
+	}
+
+	ws.depthHandlers[subscriber] = struct{}{}
+
+	if needsSubscription {
+		return ws.writeSubscription("subscribe", symbol.String(), depthChannelName(book))
+	}
+
+	return nil
+}
+
+func (ws *publicWebsocketClient) UnsubscribeDepth(subscriber DepthSubscriber) error {
+	if subscriber == nil {
+		return errors.NewValidationError("subscriber", "cannot be nil", nil)
+	}
+
+	symbol := subscriber.SubscribeSymbol().Normalize()
+	book := subscriber.SubscribeDepthBook().Normalize()
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	delete(ws.depthHandlers, subscriber)
+
+	for remainingSubscriber := range ws.depthHandlers {
+		if remainingSubscriber.SubscribeSymbol().Normalize() == symbol &&
+			remainingSubscriber.SubscribeDepthBook().Normalize() == book {
+			return nil
+		}
+	}
+
+	return ws.writeSubscription("unsubscribe", symbol.String(), depthChannelName(book))
+}
+
+func (ws *publicWebsocketClient) processTradeMessage(bytes []byte, symbol model.Symbol) {
+	var tradeMsg *model.TradeChannelMessage
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+	for subscriber := range ws.tradeHandlers {
+		if subscriber.SubscribeSymbol().Normalize() != symbol {
+			continue
+		}
+
+		if tradeMsg == nil {
+			tradeMsg = &model.TradeChannelMessage{}
+			if err := json.Unmarshal(bytes, tradeMsg); err != nil {
+				if ws.logger != nil {
+					ws.logger.Error("failed to unmarshal trade message", zap.Error(errors.NewInternalError("error unmarshaling trade message", err)))
+				}
+				return
+			}
+		}
+
+		subscriber.SubscribeTrade(tradeMsg)
+	}
+}
+
+func (ws *publicWebsocketClient) processTickerMessage(bytes []byte, symbol model.Symbol) {
+	var tickerMsg *model.TickerChannelMessage
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+	for subscriber := range ws.tickerHandlers {
+		if subscriber.SubscribeSymbol().Normalize() != symbol {
+			continue
+		}
+
+		if tickerMsg == nil {
+			tickerMsg = &model.TickerChannelMessage{}
+			if err := json.Unmarshal(bytes, tickerMsg); err != nil {
+				if ws.logger != nil {
+					ws.logger.Error("failed to unmarshal ticker message", zap.Error(errors.NewInternalError("error unmarshaling ticker message", err)))
+				}
+				return
+			}
+		}
+
+		subscriber.SubscribeTicker(tickerMsg)
+	}
+}
+
+func (ws *publicWebsocketClient) processPriceMessage(bytes []byte, symbol model.Symbol) {
+	var priceMsg *model.PriceChannelMessage
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+	for subscriber := range ws.priceHandlers {
+		if subscriber.SubscribeSymbol().Normalize() != symbol {
+			continue
+		}
+
+		if priceMsg == nil {
+			priceMsg = &model.PriceChannelMessage{}
+			if err := json.Unmarshal(bytes, priceMsg); err != nil {
+				if ws.logger != nil {
+					ws.logger.Error("failed to unmarshal price message", zap.Error(errors.NewInternalError("error unmarshaling price message", err)))
+				}
+				return
+			}
+		}
+
+		subscriber.SubscribePrice(priceMsg)
+	}
+}
+
+func (ws *publicWebsocketClient) processTickersMessage(bytes []byte) {
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+
+	if len(ws.tickersHandlers) == 0 {
+		return
+	}
+
+	var tickersMsg model.TickersChannelMessage
+	if err := json.Unmarshal(bytes, &tickersMsg); err != nil {
+		if ws.logger != nil {
+			ws.logger.Error("failed to unmarshal tickers message", zap.Error(errors.NewInternalError("error unmarshaling tickers message", err)))
+		}
+		return
+	}
+
+	for subscriber := range ws.tickersHandlers {
+		subscriber.SubscribeTickers(&tickersMsg)
+	}
+}
+
+func parseDepthChannel(channelStr string) (model.DepthBook, bool, error) {
+	bookStr, ok := strings.CutPrefix(channelStr, "depth_")
+	if !ok {
+		return "", false, nil
+	}
+
+	book, err := model.ParseDepthBook(bookStr)
+	if err != nil {
+		return "", true, errors.NewValidationError("channel", "failed to parse depth book", err)
+	}
+
+	return book, true, nil
+}
+
+func (ws *publicWebsocketClient) processDepthMessage(bytes []byte, symbol model.Symbol, book model.DepthBook) {
+	var depthMsg *model.DepthChannelMessage
+
+	ws.subscriberMtx.Lock()
+	defer ws.subscriberMtx.Unlock()
+	for subscriber := range ws.depthHandlers {
+		if subscriber.SubscribeSymbol().Normalize() != symbol || subscriber.SubscribeDepthBook().Normalize() != book {
+			continue
+		}
+
+		if depthMsg == nil {
+			depthMsg = &model.DepthChannelMessage{}
+			if err := json.Unmarshal(bytes, depthMsg); err != nil {
+				if ws.logger != nil {
+					ws.logger.Error("failed to unmarshal depth message", zap.Error(errors.NewInternalError("error unmarshaling depth message", err)))
+				}
+				return
+			}
+		}
+
+		subscriber.SubscribeDepth(depthMsg)
+	}
+}
+
+// parseChannel parses priceType_kline_interval channels and the single word channels like trade and ticker
 func parseChannel(channelStr string) (model.Interval, model.Channel, model.PriceType, error) {
 	parts := strings.Split(channelStr, "_")
 
+	if len(parts) == 1 {
+		channel, err := model.ParseChannel(channelStr)
+		if err != nil || channel == model.ChannelKline {
+			return "", "", "", errors.NewValidationError("channel", fmt.Sprintf("invalid channel %s", channelStr), err)
+		}
+
+		return "", channel, "", nil
+	}
+
 	if len(parts) != 3 {
 		return "", "", "", errors.NewValidationError(
 			"channel",
@@ -332,7 +752,24 @@ func (ws *publicWebsocketClient) processMessage(bytes []byte) {
 	}
 
 	if ch, ok := result["ch"].(string); ok {
+		if model.Channel(ch).Normalize() == model.ChannelTickers {
+			ws.processTickersMessage(bytes)
+			return
+		}
+
 		if sym, symbolOk := result["symbol"].(string); symbolOk {
+			if book, isDepth, err := parseDepthChannel(ch); isDepth {
+				if err != nil {
+					if ws.logger != nil {
+						ws.logger.Error("error parsing channel", zap.Error(err))
+					}
+					return
+				}
+
+				ws.processDepthMessage(bytes, model.ParseSymbol(sym).Normalize(), book)
+				return
+			}
+
 			interval, channel, priceType, err := parseChannel(ch)
 			if err != nil {
 				if ws.logger != nil {
@@ -341,7 +778,14 @@ func (ws *publicWebsocketClient) processMessage(bytes []byte) {
 				return
 			}
 
-			if channel == model.ChannelKline {
+			switch channel {
+			case model.ChannelTrade:
+				ws.processTradeMessage(bytes, model.ParseSymbol(sym).Normalize())
+			case model.ChannelTicker:
+				ws.processTickerMessage(bytes, model.ParseSymbol(sym).Normalize())
+			case model.ChannelPrice:
+				ws.processPriceMessage(bytes, model.ParseSymbol(sym).Normalize())
+			case model.ChannelKline:
 				symbol := model.ParseSymbol(sym).Normalize()
 				ws.subscriberMtx.Lock()
 				defer ws.subscriberMtx.Unlock()
@@ -390,11 +834,22 @@ type SubscribeKLineRequest struct {
 	Ch     string `json:"ch"`
 }
 
+type SubscribeChannelRequest struct {
+	Symbol string `json:"symbol,omitempty"`
+	Ch     string `json:"ch"`
+}
+
 type SubscribeRequest struct {
 	Op   string        `json:"op"`
 	Args []interface{} `json:"args"`
 }
 
+// reconnectInvalidator is implemented by subscribers keeping local state that
+// has to be rebuilt after updates were missed during a reconnect
+type reconnectInvalidator interface {
+	Invalidate()
+}
+
 type KLineSubscriber interface {
 	SubscribeKLine(*model.KLineChannelMessage)
 	SubscribeInterval() model.Interval
@@ -402,12 +857,48 @@ type KLineSubscriber interface {
 	SubscribePriceType() model.PriceType
 }
 
+type DepthSubscriber interface {
+	SubscribeDepth(*model.DepthChannelMessage)
+	SubscribeSymbol() model.Symbol
+	SubscribeDepthBook() model.DepthBook
+}
+
+type TradeSubscriber interface {
+	SubscribeTrade(*model.TradeChannelMessage)
+	SubscribeSymbol() model.Symbol
+}
+
+type TickerSubscriber interface {
+	SubscribeTicker(*model.TickerChannelMessage)
+	SubscribeSymbol() model.Symbol
+}
+
+// PriceSubscriber receives the mark price, index price and funding rate of a symbol
+type PriceSubscriber interface {
+	SubscribePrice(*model.PriceChannelMessage)
+	SubscribeSymbol() model.Symbol
+}
+
+type TickersSubscriber interface {
+	SubscribeTickers(*model.TickersChannelMessage)
+}
+
 type PublicWebsocketClient interface {
 	Stream() error
 	Connect() error
 	Disconnect()
 	SubscribeKLine(subscriber KLineSubscriber) error
 	UnsubscribeKLine(subscriber KLineSubscriber) error
+	SubscribeDepth(subscriber DepthSubscriber) error
+	UnsubscribeDepth(subscriber DepthSubscriber) error
+	SubscribeTrade(subscriber TradeSubscriber) error
+	UnsubscribeTrade(subscriber TradeSubscriber) error
+	SubscribeTicker(subscriber TickerSubscriber) error
+	UnsubscribeTicker(subscriber TickerSubscriber) error
+	SubscribeTickers(subscriber TickersSubscriber) error
+	UnsubscribeTickers(subscriber TickersSubscriber) error
+	SubscribePrice(subscriber PriceSubscriber) error
+	UnsubscribePrice(subscriber PriceSubscriber) error
 }
 
 type ReconnectingPublicWebsocketClient struct {
@@ -423,6 +914,11 @@ type ReconnectingPublicWebsocketClient struct {
 	mu                   sync.RWMutex
 	stopReconnecting     chan struct{}
 	subscribers          map[KLineSubscriber]struct{}
+	depthSubscribers     map[DepthSubscriber]struct{}
+	tradeSubscribers     map[TradeSubscriber]struct{}
+	tickerSubscribers    map[TickerSubscriber]struct{}
+	tickersSubscribers   map[TickersSubscriber]struct{}
+	priceSubscribers     map[PriceSubscriber]struct{}
 	subscriberMu         sync.RWMutex
 }
 
@@ -490,6 +986,11 @@ func NewReconnectingPublicWebsocket(ctx context.Context, options ...Reconnecting
 		logger:               opts.Logger,
 		stopReconnecting:     make(chan struct{}),
 		subscribers:          make(map[KLineSubscriber]struct{}),
+		depthSubscribers:     make(map[DepthSubscriber]struct{}),
+		tradeSubscribers:     make(map[TradeSubscriber]struct{}),
+		tickerSubscribers:    make(map[TickerSubscriber]struct{}),
+		tickersSubscribers:   make(map[TickersSubscriber]struct{}),
+		priceSubscribers:     make(map[PriceSubscriber]struct{}),
 	}
 
 	return r, nil
@@ -541,6 +1042,136 @@ func (r *ReconnectingPublicWebsocketClient) UnsubscribeKLine(subscriber KLineSub
 	return err
 }
 
+func (r *ReconnectingPublicWebsocketClient) SubscribeDepth(subscriber DepthSubscriber) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.client.SubscribeDepth(subscriber)
+	if err == nil {
+		r.subscriberMu.Lock()
+		r.depthSubscribers[subscriber] = struct{}{}
+		r.subscriberMu.Unlock()
+	}
+	return err
+}
+
+func (r *ReconnectingPublicWebsocketClient) UnsubscribeDepth(subscriber DepthSubscriber) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.client.UnsubscribeDepth(subscriber)
+	if err == nil {
+		r.subscriberMu.Lock()
+		delete(r.depthSubscribers, subscriber)
+		r.subscriberMu.Unlock()
+	}
+	return err
+}
+
+func (r *ReconnectingPublicWebsocketClient) SubscribeTrade(subscriber TradeSubscriber) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.client.SubscribeTrade(subscriber)
+	if err == nil {
+		r.subscriberMu.Lock()
+		r.tradeSubscribers[subscriber] = struct{}{}
+		r.subscriberMu.Unlock()
+	}
+	return err
+}
+
+func (r *ReconnectingPublicWebsocketClient) UnsubscribeTrade(subscriber TradeSubscriber) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.client.UnsubscribeTrade(subscriber)
+	if err == nil {
+		r.subscriberMu.Lock()
+		delete(r.tradeSubscribers, subscriber)
+		r.subscriberMu.Unlock()
+	}
+	return err
+}
+
+func (r *ReconnectingPublicWebsocketClient) SubscribeTicker(subscriber TickerSubscriber) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.client.SubscribeTicker(subscriber)
+	if err == nil {
+		r.subscriberMu.Lock()
+		r.tickerSubscribers[subscriber] = struct{}{}
+		r.subscriberMu.Unlock()
+	}
+	return err
+}
+
+func (r *ReconnectingPublicWebsocketClient) UnsubscribeTicker(subscriber TickerSubscriber) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.client.UnsubscribeTicker(subscriber)
+	if err == nil {
+		r.subscriberMu.Lock()
+		delete(r.tickerSubscribers, subscriber)
+		r.subscriberMu.Unlock()
+	}
+	return err
+}
+
+func (r *ReconnectingPublicWebsocketClient) SubscribeTickers(subscriber TickersSubscriber) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.client.SubscribeTickers(subscriber)
+	if err == nil {
+		r.subscriberMu.Lock()
+		r.tickersSubscribers[subscriber] = struct{}{}
+		r.subscriberMu.Unlock()
+	}
+	return err
+}
+
+func (r *ReconnectingPublicWebsocketClient) UnsubscribeTickers(subscriber TickersSubscriber) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.client.UnsubscribeTickers(subscriber)
+	if err == nil {
+		r.subscriberMu.Lock()
+		delete(r.tickersSubscribers, subscriber)
+		r.subscriberMu.Unlock()
+	}
+	return err
+}
+
+func (r *ReconnectingPublicWebsocketClient) SubscribePrice(subscriber PriceSubscriber) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.client.SubscribePrice(subscriber)
+	if err == nil {
+		r.subscriberMu.Lock()
+		r.priceSubscribers[subscriber] = struct{}{}
+		r.subscriberMu.Unlock()
+	}
+	return err
+}
+
+func (r *ReconnectingPublicWebsocketClient) UnsubscribePrice(subscriber PriceSubscriber) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	err := r.client.UnsubscribePrice(subscriber)
+	if err == nil {
+		r.subscriberMu.Lock()
+		delete(r.priceSubscribers, subscriber)
+		r.subscriberMu.Unlock()
+	}
+	return err
+}
+
 func (r *ReconnectingPublicWebsocketClient) Stream() error {
 	attempt := 0
 	for {
@@ -644,5 +1275,58 @@ func (r *ReconnectingPublicWebsocketClient) resubscribeAll() error {
 			zap.String("price_type", subscriber.SubscribePriceType().String()))
 	}
 
+	for subscriber := range r.depthSubscribers {
+		// Updates were missed while disconnected, local books have to be rebuilt
+		if invalidator, ok := subscriber.(reconnectInvalidator); ok {
+			invalidator.Invalidate()
+		}
+
+		err := r.client.SubscribeDepth(subscriber)
+		if err != nil {
+			r.logger.Error("failed to resubscribe",
+				zap.String("symbol", subscriber.SubscribeSymbol().String()),
+				zap.String("book", subscriber.SubscribeDepthBook().String()),
+				zap.Error(err))
+			return err
+		}
+		r.logger.Debug("resubscribed successfully",
+			zap.String("symbol", subscriber.SubscribeSymbol().String()),
+			zap.String("book", subscriber.SubscribeDepthBook().String()))
+	}
+
+	for subscriber := range r.tradeSubscribers {
+		if err := r.client.SubscribeTrade(subscriber); err != nil {
+			r.logger.Error("failed to resubscribe trades",
+				zap.String("symbol", subscriber.SubscribeSymbol().String()),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	for subscriber := range r.tickerSubscribers {
+		if err := r.client.SubscribeTicker(subscriber); err != nil {
+			r.logger.Error("failed to resubscribe ticker",
+				zap.String("symbol", subscriber.SubscribeSymbol().String()),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	for subscriber := range r.tickersSubscribers {
+		if err := r.client.SubscribeTickers(subscriber); err != nil {
+			r.logger.Error("failed to resubscribe tickers", zap.Error(err))
+			return err
+		}
+	}
+
+	for subscriber := range r.priceSubscribers {
+		if err := r.client.SubscribePrice(subscriber); err != nil {
+			r.logger.Error("failed to resubscribe price",
+				zap.String("symbol", subscriber.SubscribeSymbol().String()),
+				zap.Error(err))
+			return err
+		}
+	}
+
 	return nil
 }
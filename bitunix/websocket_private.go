@@ -39,7 +39,12 @@ func NewPrivateWebsocket(ctx context.Context, apiKey, secretKey string, options
 	}
 
 	var wsOptions []websocket.ClientOption
-	wsOptions = append(wsOptions, websocket.WithAuthentication(WebsocketSigner(apiKey, secretKey)))
+	timestampFunc := generateWebsocketTimestamp
+	if wsc.timeSync != nil {
+		timestampFunc = wsc.timeSync.Unix
+	}
+
+	wsOptions = append(wsOptions, websocket.WithAuthentication(WebsocketSignerWithTimestamp(apiKey, secretKey, timestampFunc)))
 	wsOptions = append(wsOptions, websocket.WithKeepAliveMonitor(30*time.Second, KeepAliveMonitor()))
 
 	if wsc.logger != nil {
@@ -48,11 +53,11 @@ func NewPrivateWebsocket(ctx context.Context, apiKey, secretKey string, options
 		wsOptions = append(wsOptions, websocket.WithLogLevel(wsc.logLevel))
 	}
 
-	wsc.client = websocket.New(
+	wsc.client = wsc.transport(websocket.New(
 		ctx,
 		wsc.uri,
 		wsOptions...,
-	)
+	))
 
 	if wsc.workerPoolSize <= 0 {
 		wsc.workerPoolSize = 10
@@ -368,14 +373,34 @@ func WithWebsocketLogger(logger *zap.Logger) WebsocketClientOption {
 	}
 }
 
+// WithWebsocketURI connects to uri instead of the Bitunix endpoint, e.g. a bitunixtest.Server
+func WithWebsocketURI(uri string) WebsocketClientOption {
+	return func(ws *websocketClient) {
+		ws.uri = uri
+	}
+}
+
+// WithWebsocketTimeSync signs the login with the exchange clock measured by timeSync instead of the local clock
+func WithWebsocketTimeSync(timeSync *TimeSync) WebsocketClientOption {
+	return func(ws *websocketClient) {
+		ws.timeSync = timeSync
+	}
+}
+
+func generateWebsocketTimestamp() int64 { return time.Now().Unix() }
+
 func WebsocketSigner(apiKey, apiSecret string) func() ([]byte, error) {
+	return WebsocketSignerWithTimestamp(apiKey, apiSecret, generateWebsocketTimestamp)
+}
+
+func WebsocketSignerWithTimestamp(apiKey, apiSecret string, timestampGenerationFunc func() int64) func() ([]byte, error) {
 	return func() ([]byte, error) {
 		nonce, err := security.GenerateNonce(32)
 		if err != nil {
 			return nil, errors.NewAuthenticationError("failed to generate nonce for websocket authentication", err)
 		}
 
-		sign, timestamp := generateWebsocketSignature(apiKey, apiSecret, time.Now().Unix(), nonce)
+		sign, timestamp := generateWebsocketSignature(apiKey, apiSecret, timestampGenerationFunc(), nonce)
 
 		loginReq := loginMessage{
 			Op: "login",
@@ -712,6 +737,30 @@ func (r *ReconnectingPrivateWebsocketClient) resubscribeAll() error {
 	r.subscriberMu.RLock()
 	defer r.subscriberMu.RUnlock()
 
+	// Updates were missed while disconnected, local account state has to be seeded again.
+	// A state subscribed to several channels is only invalidated once.
+	invalidators := make(map[reconnectInvalidator]struct{})
+	collect := func(subscriber any) {
+		if invalidator, ok := subscriber.(reconnectInvalidator); ok {
+			invalidators[invalidator] = struct{}{}
+		}
+	}
+	for subscriber := range r.balanceSubscribers {
+		collect(subscriber)
+	}
+	for subscriber := range r.positionSubscribers {
+		collect(subscriber)
+	}
+	for subscriber := range r.orderSubscribers {
+		collect(subscriber)
+	}
+	for subscriber := range r.tpSlOrderSubscribers {
+		collect(subscriber)
+	}
+	for invalidator := range invalidators {
+		invalidator.Invalidate()
+	}
+
 	for subscriber := range r.balanceSubscribers {
 		err := r.client.SubscribeBalance(subscriber)
 		if err != nil {
@@ -0,0 +1,267 @@
+package bitunix
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/websocket"
+)
+
+// RecordedFrame is a raw websocket frame with the time it was received. The
+// frame is stored base64 encoded, it is not necessarily JSON, e.g. a text pong.
+type RecordedFrame struct {
+	Time  time.Time `json:"time"`
+	Frame []byte    `json:"frame"`
+}
+
+// WebsocketRecorder writes every frame a websocket client receives to gzip
+// compressed JSON lines, one RecordedFrame per line. Frames are written as
+// they arrive; a failed write does not interrupt the stream and is returned by Close.
+type WebsocketRecorder struct {
+	mu      sync.Mutex
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+	file    io.Closer
+	err     error
+	now     func() time.Time
+}
+
+func NewWebsocketRecorder(w io.Writer) *WebsocketRecorder {
+	gz := gzip.NewWriter(w)
+	return &WebsocketRecorder{gzip: gz, encoder: json.NewEncoder(gz), now: time.Now}
+}
+
+// CreateWebsocketRecording records to a new file at path, closing the recorder closes the file
+func CreateWebsocketRecording(path string) (*WebsocketRecorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to create websocket recording", err)
+	}
+
+	r := NewWebsocketRecorder(file)
+	r.file = file
+	return r, nil
+}
+
+func (r *WebsocketRecorder) Record(frame []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return
+	}
+
+	if err := r.encoder.Encode(RecordedFrame{Time: r.now(), Frame: frame}); err != nil {
+		r.err = errors.NewInternalError("failed to record websocket frame", err)
+	}
+}
+
+// Close flushes the recording and returns the first error that occurred while recording
+func (r *WebsocketRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.gzip.Close(); err != nil && r.err == nil {
+		r.err = errors.NewInternalError("failed to flush websocket recording", err)
+	}
+
+	if r.file != nil {
+		if err := r.file.Close(); err != nil && r.err == nil {
+			r.err = errors.NewInternalError("failed to close websocket recording", err)
+		}
+		r.file = nil
+	}
+
+	return r.err
+}
+
+// WithWebsocketRecorder records the frames received by the client, e.g. to
+// reproduce a session later with WithWebsocketReplayer. The recorder is not
+// closed with the client.
+func WithWebsocketRecorder(recorder *WebsocketRecorder) WebsocketClientOption {
+	return func(ws *websocketClient) {
+		ws.recorder = recorder
+	}
+}
+
+type recordingClient struct {
+	wsClientInterface
+	recorder *WebsocketRecorder
+}
+
+func (c *recordingClient) Listen(callback websocket.HandlerFunc) error {
+	return c.wsClientInterface.Listen(func(bytes []byte) error {
+		c.recorder.Record(bytes)
+		return callback(bytes)
+	})
+}
+
+// ReadWebsocketRecording reads the frames written by a WebsocketRecorder
+func ReadWebsocketRecording(r io.Reader) ([]RecordedFrame, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to open websocket recording", err)
+	}
+	defer gz.Close()
+
+	var frames []RecordedFrame
+	decoder := json.NewDecoder(gz)
+	for {
+		var frame RecordedFrame
+		err := decoder.Decode(&frame)
+		if err == io.EOF {
+			return frames, nil
+		}
+		if err != nil {
+			return nil, errors.NewInternalError("failed to decode recorded websocket frame", err)
+		}
+		frames = append(frames, frame)
+	}
+}
+
+// WebsocketReplayer stands in for the websocket connection and feeds recorded
+// frames to the client instead. Subscriptions and other writes are accepted
+// and ignored, the client's subscribers receive whatever was recorded.
+type WebsocketReplayer struct {
+	frames     []RecordedFrame
+	next       int
+	speed      float64
+	done       chan struct{}
+	closeOnce  sync.Once
+	deliverMtx sync.Mutex
+	retryDelay time.Duration
+}
+
+type WebsocketReplayerOption func(*WebsocketReplayer)
+
+// WithReplaySpeed replays the frames speed times faster than they were
+// received, 1 keeps the original pace and 0 replays without waiting
+func WithReplaySpeed(speed float64) WebsocketReplayerOption {
+	return func(r *WebsocketReplayer) {
+		r.speed = speed
+	}
+}
+
+func NewWebsocketReplayer(frames []RecordedFrame, options ...WebsocketReplayerOption) *WebsocketReplayer {
+	r := &WebsocketReplayer{
+		frames:     frames,
+		speed:      1,
+		done:       make(chan struct{}),
+		retryDelay: time.Millisecond,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// OpenWebsocketReplayer replays the recording at path
+func OpenWebsocketReplayer(path string, options ...WebsocketReplayerOption) (*WebsocketReplayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to open websocket recording", err)
+	}
+	defer file.Close()
+
+	frames, err := ReadWebsocketRecording(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewWebsocketReplayer(frames, options...), nil
+}
+
+// WithWebsocketReplayer replaces the connection with replayer. The frames are
+// processed by a single worker so subscribers see them in the recorded order.
+func WithWebsocketReplayer(replayer *WebsocketReplayer) WebsocketClientOption {
+	return func(ws *websocketClient) {
+		ws.replayer = replayer
+	}
+}
+
+func (r *WebsocketReplayer) Connect() error {
+	return nil
+}
+
+func (r *WebsocketReplayer) Write([]byte) error {
+	return nil
+}
+
+func (r *WebsocketReplayer) Close() {
+	r.closeOnce.Do(func() {
+		close(r.done)
+	})
+
+	// Wait for a frame being handed over, the client releases its queue after Close
+	r.deliverMtx.Lock()
+	defer r.deliverMtx.Unlock()
+}
+
+// Listen hands the remaining frames to callback and returns once all were
+// delivered. Frames the client's queue has no room for are retried.
+func (r *WebsocketReplayer) Listen(callback websocket.HandlerFunc) error {
+	for ; r.next < len(r.frames); r.next++ {
+		if r.next > 0 && r.speed > 0 {
+			gap := r.frames[r.next].Time.Sub(r.frames[r.next-1].Time)
+			if err := r.pause(time.Duration(float64(gap) / r.speed)); err != nil {
+				return err
+			}
+		}
+
+		if err := r.deliver(callback, r.frames[r.next].Frame); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *WebsocketReplayer) pause(d time.Duration) error {
+	if d <= 0 {
+		return r.closed()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-r.done:
+		return r.closed()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *WebsocketReplayer) closed() error {
+	select {
+	case <-r.done:
+		return errors.NewConnectionClosedError("listen", "replay closed", nil)
+	default:
+		return nil
+	}
+}
+
+func (r *WebsocketReplayer) deliver(callback websocket.HandlerFunc, frame []byte) error {
+	for {
+		r.deliverMtx.Lock()
+		if err := r.closed(); err != nil {
+			r.deliverMtx.Unlock()
+			return err
+		}
+		err := callback(frame)
+		r.deliverMtx.Unlock()
+
+		if !stderrors.Is(err, errors.ErrWorkgroupExhausted) {
+			return err
+		}
+		if err := r.pause(r.retryDelay); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package bitunix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+	"github.com/tradingiq/bitunix-client/websocket"
+)
+
+func recordedKline(closePrice string) json.RawMessage {
+	return json.RawMessage(fmt.Sprintf(`{"ch":"market_kline_1min","symbol":"BTCUSDT","ts":1732178884994,"data":{"o":"1","c":"%s","h":"5","l":"0.5","b":"1","q":"1"}}`, closePrice))
+}
+
+func recordedOrder(orderID string) json.RawMessage {
+	return json.RawMessage(fmt.Sprintf(`{"ch":"order","ts":1651234567890,"data":{"event":"CREATE","orderId":"%s","symbol":"BTCUSDT","positionType":"CROSS","positionMode":"ONE_WAY","side":"BUY","type":"LIMIT","qty":"1","price":"50000","ctime":"2023-01-01T00:00:00.000Z","mtime":"2023-01-01T00:00:00.000Z","leverage":"10","orderStatus":"NEW","fee":"0"}}`, orderID))
+}
+
+func TestWebsocketRecorderWrapsClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "public.jsonl.gz")
+	recorder, err := CreateWebsocketRecording(path)
+	require.NoError(t, err)
+
+	// Frames that are not JSON do not stop the recording
+	frames := []json.RawMessage{recordedKline("2"), json.RawMessage("pong"), recordedKline("3")}
+	var received [][]byte
+	client := &websocketClient{recorder: recorder}
+	transport := client.transport(&mockWsClient{listenFn: func(callback websocket.HandlerFunc) error {
+		for _, frame := range frames {
+			if err := callback(frame); err != nil {
+				return err
+			}
+		}
+		return nil
+	}})
+
+	require.NoError(t, transport.Listen(func(bytes []byte) error {
+		received = append(received, bytes)
+		return nil
+	}))
+	require.NoError(t, recorder.Close())
+	assert.Len(t, received, 3)
+
+	replayer, err := OpenWebsocketReplayer(path)
+	require.NoError(t, err)
+	require.Len(t, replayer.frames, 3)
+	assert.JSONEq(t, string(frames[0]), string(replayer.frames[0].Frame))
+	assert.Equal(t, "pong", string(replayer.frames[1].Frame))
+	assert.JSONEq(t, string(frames[2]), string(replayer.frames[2].Frame))
+	assert.False(t, replayer.frames[0].Time.IsZero())
+	assert.False(t, replayer.frames[1].Time.Before(replayer.frames[0].Time))
+}
+
+func TestWebsocketReplayerFeedsPublicClientInOrder(t *testing.T) {
+	var frames []RecordedFrame
+	base := time.Now()
+	for i := range 50 {
+		frames = append(frames, RecordedFrame{Time: base.Add(time.Duration(i) * time.Hour), Frame: recordedKline(fmt.Sprint(i))})
+	}
+
+	ctx := context.Background()
+	client, err := NewPublicWebsocket(ctx, WithWebsocketReplayer(NewWebsocketReplayer(frames, WithReplaySpeed(0))), WithWorkerBufferSize(1))
+	require.NoError(t, err)
+	defer client.Disconnect()
+	require.NoError(t, client.Connect())
+
+	var closes []float64
+	done := make(chan struct{})
+	require.NoError(t, client.SubscribeKLine(&testKLineSubscriber{symbol: "BTCUSDT", interval: model.Interval1Min, fn: func(msg *model.KLineChannelMessage) {
+		closes = append(closes, msg.Data.ClosePrice)
+		if len(closes) == len(frames) {
+			close(done)
+		}
+	}}))
+
+	require.NoError(t, client.Stream())
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("replayed %d of %d frames", len(closes), len(frames))
+	}
+	for i, closePrice := range closes {
+		assert.Equal(t, float64(i), closePrice)
+	}
+}
+
+type orderIDRecorder struct {
+	ids chan string
+}
+
+func (r *orderIDRecorder) SubscribeOrder(msg *model.OrderChannelMessage) {
+	r.ids <- msg.Data.OrderID
+}
+
+func TestWebsocketReplayerFeedsPrivateClient(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := NewWebsocketRecorder(&buf)
+	recorder.Record(recordedOrder("1"))
+	recorder.Record(recordedOrder("2"))
+	require.NoError(t, recorder.Close())
+
+	frames, err := ReadWebsocketRecording(&buf)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	client, err := NewPrivateWebsocket(ctx, "key", "secret", WithWebsocketReplayer(NewWebsocketReplayer(frames, WithReplaySpeed(1000))))
+	require.NoError(t, err)
+	defer client.Disconnect()
+	require.NoError(t, client.Connect())
+
+	subscriber := &orderIDRecorder{ids: make(chan string, 2)}
+	require.NoError(t, client.SubscribeOrders(subscriber))
+	require.NoError(t, client.Stream())
+
+	for _, expected := range []string{"1", "2"} {
+		select {
+		case id := <-subscriber.ids:
+			assert.Equal(t, expected, id)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for replayed order")
+		}
+	}
+}
+
+func TestWebsocketReplayerCloseStopsListen(t *testing.T) {
+	base := time.Now()
+	replayer := NewWebsocketReplayer([]RecordedFrame{
+		{Time: base, Frame: recordedKline("1")},
+		{Time: base.Add(time.Hour), Frame: recordedKline("2")},
+	})
+
+	delivered := make(chan struct{}, 2)
+	result := make(chan error, 1)
+	go func() {
+		result <- replayer.Listen(func([]byte) error {
+			delivered <- struct{}{}
+			return nil
+		})
+	}()
+
+	<-delivered
+	replayer.Close()
+
+	select {
+	case err := <-result:
+		assert.ErrorIs(t, err, errors.ErrConnectionClosed)
+	case <-time.After(time.Second):
+		t.Fatal("listen did not return after close")
+	}
+	assert.Empty(t, delivered)
+}
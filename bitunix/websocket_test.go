@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tradingiq/bitunix-client/model"
 	"github.com/tradingiq/bitunix-client/websocket"
+	"go.uber.org/zap"
 )
 
 func TestHeartbeatMessage(t *testing.T) {
@@ -942,3 +943,244 @@ func TestWaitForDisconnection(t *testing.T) {
 	_, ok = <-client.quit
 	assert.False(t, ok, "Quit channel should be closed")
 }
+
+type tradeSubTest struct {
+	symbol model.Symbol
+	msgs   chan *model.TradeChannelMessage
+}
+
+func (s *tradeSubTest) SubscribeTrade(msg *model.TradeChannelMessage) { s.msgs <- msg }
+func (s *tradeSubTest) SubscribeSymbol() model.Symbol                 { return s.symbol }
+
+type tickerSubTest struct {
+	symbol model.Symbol
+	msgs   chan *model.TickerChannelMessage
+}
+
+func (s *tickerSubTest) SubscribeTicker(msg *model.TickerChannelMessage) { s.msgs <- msg }
+func (s *tickerSubTest) SubscribeSymbol() model.Symbol                   { return s.symbol }
+
+type tickersSubTest struct {
+	msgs chan *model.TickersChannelMessage
+}
+
+func (s *tickersSubTest) SubscribeTickers(msg *model.TickersChannelMessage) { s.msgs <- msg }
+
+func newMarketChannelTestClient(mockWs *mockWsClient) *publicWebsocketClient {
+	return &publicWebsocketClient{
+		websocketClient: &websocketClient{
+			client: mockWs,
+			uri:    "wss://test.com",
+		},
+		klineHandlers:   make(map[KLineSubscriber]struct{}),
+		depthHandlers:   make(map[DepthSubscriber]struct{}),
+		tradeHandlers:   make(map[TradeSubscriber]struct{}),
+		tickerHandlers:  make(map[TickerSubscriber]struct{}),
+		tickersHandlers: make(map[TickersSubscriber]struct{}),
+		priceHandlers:   make(map[PriceSubscriber]struct{}),
+	}
+}
+
+func TestParseChannel_SingleWordChannels(t *testing.T) {
+	for _, ch := range []model.Channel{model.ChannelTrade, model.ChannelTicker, model.ChannelTickers} {
+		interval, channel, priceType, err := parseChannel(ch.String())
+		assert.NoError(t, err)
+		assert.Equal(t, ch, channel)
+		assert.Empty(t, interval)
+		assert.Empty(t, priceType)
+	}
+
+	_, _, _, err := parseChannel("kline")
+	assert.Error(t, err, "kline requires a price type and interval")
+
+	_, _, _, err = parseChannel("unknown")
+	assert.Error(t, err)
+}
+
+func TestPublicWebsocketClient_SubscribeTradeAndTicker(t *testing.T) {
+	mockWs := &mockWsClient{}
+	var written []string
+	mockWs.writeFn = func(bytes []byte) error {
+		written = append(written, string(bytes))
+		return nil
+	}
+
+	client := newMarketChannelTestClient(mockWs)
+
+	trades := &tradeSubTest{symbol: "BTCUSDT"}
+	otherTrades := &tradeSubTest{symbol: "btcusdt"}
+	ticker := &tickerSubTest{symbol: "ETHUSDT"}
+	tickers := &tickersSubTest{}
+
+	require.NoError(t, client.SubscribeTrade(trades))
+	require.NoError(t, client.SubscribeTrade(otherTrades))
+	require.NoError(t, client.SubscribeTicker(ticker))
+	require.NoError(t, client.SubscribeTickers(tickers))
+
+	require.Len(t, written, 3)
+	assert.JSONEq(t, `{"op":"subscribe","args":[{"symbol":"BTCUSDT","ch":"trade"}]}`, written[0])
+	assert.JSONEq(t, `{"op":"subscribe","args":[{"symbol":"ETHUSDT","ch":"ticker"}]}`, written[1])
+	assert.JSONEq(t, `{"op":"subscribe","args":[{"ch":"tickers"}]}`, written[2])
+
+	require.NoError(t, client.UnsubscribeTrade(trades))
+	assert.Len(t, written, 3, "the trade channel is still used by another subscriber")
+	require.NoError(t, client.UnsubscribeTrade(otherTrades))
+	require.NoError(t, client.UnsubscribeTicker(ticker))
+	require.NoError(t, client.UnsubscribeTickers(tickers))
+
+	require.Len(t, written, 6)
+	assert.JSONEq(t, `{"op":"unsubscribe","args":[{"symbol":"BTCUSDT","ch":"trade"}]}`, written[3])
+	assert.JSONEq(t, `{"op":"unsubscribe","args":[{"symbol":"ETHUSDT","ch":"ticker"}]}`, written[4])
+	assert.JSONEq(t, `{"op":"unsubscribe","args":[{"ch":"tickers"}]}`, written[5])
+
+	assert.Error(t, client.SubscribeTrade(nil))
+	assert.Error(t, client.SubscribeTicker(nil))
+	assert.Error(t, client.SubscribeTickers(nil))
+}
+
+func TestPublicWebsocketClient_ProcessTradeMessage(t *testing.T) {
+	client := newMarketChannelTestClient(&mockWsClient{})
+
+	btc := &tradeSubTest{symbol: "BTCUSDT", msgs: make(chan *model.TradeChannelMessage, 1)}
+	eth := &tradeSubTest{symbol: "ETHUSDT", msgs: make(chan *model.TradeChannelMessage, 1)}
+	require.NoError(t, client.SubscribeTrade(btc))
+	require.NoError(t, client.SubscribeTrade(eth))
+
+	client.processMessage([]byte(`{
+		"ch": "trade",
+		"symbol": "BTCUSDT",
+		"ts": 1732178884994,
+		"data": [
+			{"t": "2024-12-04T11:36:47.959Z", "p": "27300.5", "v": "0.001", "s": "buy"},
+			{"t": "2024-12-04T11:36:48.012Z", "p": "27300.4", "v": "0.25", "s": "sell"}
+		]
+	}`))
+
+	require.Len(t, btc.msgs, 1)
+	msg := <-btc.msgs
+	assert.Equal(t, model.Symbol("BTCUSDT"), msg.Symbol)
+	require.Len(t, msg.Data, 2)
+	assert.Equal(t, 27300.5, msg.Data[0].Price)
+	assert.Equal(t, 0.001, msg.Data[0].Volume)
+	assert.Equal(t, model.TradeSideBuy, msg.Data[0].Side)
+	assert.Equal(t, "27300.5", msg.Data[0].Exact.Price.String())
+	assert.Equal(t, time.Date(2024, 12, 4, 11, 36, 47, 959000000, time.UTC), msg.Data[0].Time)
+	assert.Equal(t, model.TradeSideSell, msg.Data[1].Side)
+	assert.Empty(t, eth.msgs)
+}
+
+func TestPublicWebsocketClient_ProcessTickerMessages(t *testing.T) {
+	client := newMarketChannelTestClient(&mockWsClient{})
+
+	ticker := &tickerSubTest{symbol: "BTCUSDT", msgs: make(chan *model.TickerChannelMessage, 1)}
+	tickers := &tickersSubTest{msgs: make(chan *model.TickersChannelMessage, 1)}
+	require.NoError(t, client.SubscribeTicker(ticker))
+	require.NoError(t, client.SubscribeTickers(tickers))
+
+	client.processMessage([]byte(`{"ch":"ticker","symbol":"BTCUSDT","ts":1732178884994,
+		"data":{"la":"27300","o":"27000","h":"27500","l":"26900","b":"120.5","q":"3290000","r":"0.0111"}}`))
+
+	require.Len(t, ticker.msgs, 1)
+	tickerMsg := <-ticker.msgs
+	assert.Equal(t, 27300.0, tickerMsg.Data.LastPrice)
+	assert.Equal(t, 27000.0, tickerMsg.Data.OpenPrice)
+	assert.Equal(t, 120.5, tickerMsg.Data.BaseVolume)
+	assert.Equal(t, 0.0111, tickerMsg.Data.ChangeRate)
+	assert.Empty(t, tickers.msgs)
+
+	client.processMessage([]byte(`{"ch":"tickers","ts":1732178884994,"data":[
+		{"s":"BTCUSDT","la":"27300","o":"27000","h":"27500","l":"26900","b":"120.5","q":"3290000","r":"0.0111"},
+		{"s":"ETHUSDT","la":"1800","o":"1750","h":"1820","l":"1740","b":"1000","q":"1800000","r":"0.0285"}]}`))
+
+	require.Len(t, tickers.msgs, 1)
+	tickersMsg := <-tickers.msgs
+	require.Len(t, tickersMsg.Data, 2)
+	assert.Equal(t, model.Symbol("ETHUSDT"), tickersMsg.Data[1].Symbol)
+	assert.Equal(t, 1800.0, tickersMsg.Data[1].LastPrice)
+	assert.Equal(t, "0.0285", tickersMsg.Data[1].Exact.ChangeRate.String())
+}
+
+func TestReconnectingPublicWebsocketClient_ResubscribeAll(t *testing.T) {
+	mockWs := &mockWsClient{}
+	var written []string
+	mockWs.writeFn = func(bytes []byte) error {
+		written = append(written, string(bytes))
+		return nil
+	}
+
+	r := &ReconnectingPublicWebsocketClient{
+		client:             newMarketChannelTestClient(mockWs),
+		logger:             zap.NewNop(),
+		subscribers:        make(map[KLineSubscriber]struct{}),
+		depthSubscribers:   make(map[DepthSubscriber]struct{}),
+		tradeSubscribers:   make(map[TradeSubscriber]struct{}),
+		tickerSubscribers:  make(map[TickerSubscriber]struct{}),
+		tickersSubscribers: make(map[TickersSubscriber]struct{}),
+		priceSubscribers:   make(map[PriceSubscriber]struct{}),
+	}
+
+	require.NoError(t, r.SubscribeTrade(&tradeSubTest{symbol: "BTCUSDT"}))
+	require.NoError(t, r.SubscribePrice(&priceSubTest{symbol: "BTCUSDT"}))
+	require.NoError(t, r.SubscribeTicker(&tickerSubTest{symbol: "BTCUSDT"}))
+	require.NoError(t, r.SubscribeTickers(&tickersSubTest{}))
+
+	// Simulate the fresh client created by a reconnect
+	r.client = newMarketChannelTestClient(mockWs)
+	written = nil
+
+	require.NoError(t, r.resubscribeAll())
+	assert.ElementsMatch(t, []string{
+		`{"op":"subscribe","args":[{"symbol":"BTCUSDT","ch":"trade"}]}`,
+		`{"op":"subscribe","args":[{"symbol":"BTCUSDT","ch":"ticker"}]}`,
+		`{"op":"subscribe","args":[{"ch":"tickers"}]}`,
+		`{"op":"subscribe","args":[{"symbol":"BTCUSDT","ch":"price"}]}`,
+	}, written)
+}
+
+type priceSubTest struct {
+	symbol model.Symbol
+	msgs   chan *model.PriceChannelMessage
+}
+
+func (s *priceSubTest) SubscribePrice(msg *model.PriceChannelMessage) { s.msgs <- msg }
+func (s *priceSubTest) SubscribeSymbol() model.Symbol                 { return s.symbol }
+
+func TestPublicWebsocketClient_SubscribePrice(t *testing.T) {
+	mockWs := &mockWsClient{}
+	var written []string
+	mockWs.writeFn = func(bytes []byte) error {
+		written = append(written, string(bytes))
+		return nil
+	}
+
+	client := newMarketChannelTestClient(mockWs)
+	sub := &priceSubTest{symbol: "BTCUSDT", msgs: make(chan *model.PriceChannelMessage, 1)}
+
+	require.NoError(t, client.SubscribePrice(sub))
+	require.Len(t, written, 1)
+	assert.JSONEq(t, `{"op":"subscribe","args":[{"symbol":"BTCUSDT","ch":"price"}]}`, written[0])
+
+	client.processMessage([]byte(`{"ch":"price","symbol":"BTCUSDT","ts":1732178884994,
+		"data":{"mp":"27300.12","ip":"27299.5","fr":"0.0001","ft":"2024-12-04T08:00:00Z","nft":"1733313600000"}}`))
+
+	require.Len(t, sub.msgs, 1)
+	msg := <-sub.msgs
+	assert.Equal(t, model.Symbol("BTCUSDT"), msg.Symbol)
+	assert.Equal(t, 27300.12, msg.Data.MarkPrice)
+	assert.Equal(t, 27299.5, msg.Data.IndexPrice)
+	assert.Equal(t, 0.0001, msg.Data.FundingRate)
+	assert.Equal(t, "0.0001", msg.Data.Exact.FundingRate.String())
+	assert.Equal(t, time.Date(2024, 12, 4, 8, 0, 0, 0, time.UTC), msg.Data.FundingTime)
+	assert.Equal(t, int64(1733313600000), msg.Data.NextFundingTime.UnixMilli())
+
+	require.NoError(t, client.UnsubscribePrice(sub))
+	require.Len(t, written, 2)
+	assert.JSONEq(t, `{"op":"unsubscribe","args":[{"symbol":"BTCUSDT","ch":"price"}]}`, written[1])
+	assert.Error(t, client.SubscribePrice(nil))
+}
+
+func TestPriceEventUnmarshal_InvalidNumber(t *testing.T) {
+	var event model.PriceEvent
+	err := json.Unmarshal([]byte(`{"mp":"abc","ip":"1","fr":"0"}`), &event)
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+package bitunixtest
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/internal/paper"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+// apiError is answered with the exchange error code instead of data
+type apiError struct {
+	code    int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d: %s", e.code, e.message)
+}
+
+var (
+	errParameter        = &apiError{code: 10002, message: "Parameter error"}
+	errOrderNotFound    = &apiError{code: 20007, message: "Order not found"}
+	errPositionNotExist = &apiError{code: 30004, message: "Position not exist"}
+)
+
+func parameterError(format string, args ...any) *apiError {
+	return &apiError{code: errParameter.code, message: fmt.Sprintf(format, args...)}
+}
+
+// rejected turns an error of the paper engine into the answer of the exchange
+func rejected(err error) *apiError {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *errors.APIError
+	if stderrors.As(err, &apiErr) {
+		return &apiError{code: apiErr.Code, message: apiErr.Message}
+	}
+	return &apiError{code: errParameter.code, message: err.Error()}
+}
+
+// bookEvents pushes the changes of the book to the private websocket connections
+type bookEvents struct {
+	server *Server
+}
+
+func (e bookEvents) OrderChanged(o *paper.Order, event model.OrderEventType) {
+	e.server.pushPrivate(model.ChannelOrder, newOrderEventJSON(o, event))
+}
+
+func (e bookEvents) PositionChanged(p *paper.Position, event model.PositionEventType) {
+	e.server.pushPrivate(model.ChannelPosition, newPositionEventJSON(p, event, e.server.engine.Prices[p.Symbol]))
+}
+
+func (e bookEvents) TpSlChanged(t *paper.TpSlOrder, event model.TpSlEventType) {
+	e.server.pushPrivate(model.ChannelTpSl, newTpSlEventJSON(t, event))
+}
+
+func (e bookEvents) BalanceChanged() {
+	e.server.pushBalance()
+}
+
+// page applies skip and limit to entries sorted from newest to oldest
+func page[T any](entries []T, skip, limit int) []T {
+	if skip >= len(entries) {
+		return []T{}
+	}
+	entries = entries[skip:]
+
+	if limit > 0 && limit < len(entries) {
+		entries = entries[:limit]
+	}
+	return entries
+}
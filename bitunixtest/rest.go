@@ -0,0 +1,588 @@
+package bitunixtest
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/internal/paper"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+type listQuery struct {
+	skip  int
+	limit int
+	start *time.Time
+	end   *time.Time
+}
+
+func parseListQuery(query url.Values) (listQuery, *apiError) {
+	q := listQuery{limit: 10}
+
+	for key, target := range map[string]*int{"skip": &q.skip, "limit": &q.limit} {
+		if value := firstValue(query, key); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return q, parameterError("invalid %s %s", key, value)
+			}
+			*target = n
+		}
+	}
+	if q.limit == 0 || q.limit > 100 {
+		return q, parameterError("limit must be between 1 and 100")
+	}
+
+	for key, target := range map[string]**time.Time{"startTime": &q.start, "endTime": &q.end} {
+		if value := firstValue(query, key); value != "" {
+			ms, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return q, parameterError("invalid %s %s", key, value)
+			}
+			t := time.UnixMilli(ms)
+			*target = &t
+		}
+	}
+
+	return q, nil
+}
+
+func (q listQuery) includes(t time.Time) bool {
+	if q.start != nil && t.Before(*q.start) {
+		return false
+	}
+	return q.end == nil || !t.After(*q.end)
+}
+
+type orderFilter struct {
+	symbol   model.Symbol
+	orderID  string
+	clientID string
+	status   model.OrderStatus
+	typ      model.OrderType
+}
+
+func newOrderFilter(query url.Values) orderFilter {
+	return orderFilter{
+		symbol:   model.Symbol(firstValue(query, "symbol")).Normalize(),
+		orderID:  firstValue(query, "orderId"),
+		clientID: firstValue(query, "clientId"),
+		status:   model.OrderStatus(firstValue(query, "status")).Normalize(),
+		typ:      model.OrderType(firstValue(query, "type")).Normalize(),
+	}
+}
+
+func (f orderFilter) matches(o *paper.Order) bool {
+	return (f.symbol == "" || o.Symbol == f.symbol) &&
+		(f.orderID == "" || o.ID == f.orderID) &&
+		(f.clientID == "" || o.ClientID == f.clientID) &&
+		(f.status == "" || o.Status == f.status) &&
+		(f.typ == "" || o.OrderType == f.typ)
+}
+
+// listOrders filters, sorts from newest to oldest and pages orders
+func listOrders(orders []*paper.Order, query url.Values) ([]orderJSON, int, *apiError) {
+	q, apiErr := parseListQuery(query)
+	if apiErr != nil {
+		return nil, 0, apiErr
+	}
+
+	filter := newOrderFilter(query)
+	var matched []*paper.Order
+	for _, o := range orders {
+		if filter.matches(o) && q.includes(o.CreateTime) {
+			matched = append(matched, o)
+		}
+	}
+	matched = paper.NewestFirst(matched, func(o *paper.Order) string { return o.ID })
+
+	list := make([]orderJSON, 0, len(matched))
+	for _, o := range page(matched, q.skip, q.limit) {
+		list = append(list, newOrderJSON(o))
+	}
+	return list, len(matched), nil
+}
+
+func (s *Server) getAccountBalance(query url.Values, _ []byte) (any, *apiError) {
+	if coin := firstValue(query, "marginCoin"); !strings.EqualFold(coin, s.marginCoin) {
+		return nil, parameterError("unsupported margin coin %s", coin)
+	}
+
+	b := s.engine.Balances()
+	return balanceJSON{
+		MarginCoin:             s.marginCoin,
+		Available:              number(b.Available),
+		Frozen:                 number(b.Frozen),
+		Margin:                 number(b.Margin),
+		Transfer:               number(b.Available),
+		PositionMode:           s.engine.PositionMode,
+		CrossUnrealizedPNL:     number(b.CrossUnrealizedPNL),
+		IsolationUnrealizedPNL: number(b.IsolationUnrealizedPNL),
+	}, nil
+}
+
+func firstValue(query url.Values, key string) string {
+	if v := query[key]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (s *Server) changeLeverage(_ url.Values, body []byte) (any, *apiError) {
+	var request model.ChangeLeverageRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if err := s.engine.ChangeLeverage(request.Symbol, request.Leverage); err != nil {
+		return nil, rejected(err)
+	}
+
+	return []map[string]any{{"symbol": request.Symbol.Normalize(), "marginCoin": s.marginCoin, "leverage": request.Leverage}}, nil
+}
+
+func (s *Server) changeMarginMode(_ url.Values, body []byte) (any, *apiError) {
+	var request model.ChangeMarginModeRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if err := s.engine.ChangeMarginMode(request.Symbol, request.MarginMode); err != nil {
+		return nil, rejected(err)
+	}
+
+	return []map[string]any{{"symbol": request.Symbol.Normalize(), "marginCoin": s.marginCoin, "marginMode": request.MarginMode.Normalize()}}, nil
+}
+
+func (s *Server) getLeverageAndMarginMode(query url.Values, _ []byte) (any, *apiError) {
+	symbol := model.Symbol(firstValue(query, "symbol")).Normalize()
+	if symbol == "" {
+		return nil, parameterError("symbol is required")
+	}
+
+	return map[string]any{
+		"symbol":     symbol,
+		"marginCoin": s.marginCoin,
+		"leverage":   s.engine.LeverageOf(symbol),
+		"marginMode": s.engine.MarginModeOf(symbol),
+	}, nil
+}
+
+func (s *Server) changePositionMode(_ url.Values, body []byte) (any, *apiError) {
+	var request model.ChangePositionModeRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if err := s.engine.ChangePositionMode(request.PositionMode); err != nil {
+		return nil, rejected(err)
+	}
+
+	return []map[string]any{{"positionMode": s.engine.PositionMode}}, nil
+}
+
+func (s *Server) adjustPositionMargin(_ url.Values, body []byte) (any, *apiError) {
+	var request adjustMarginRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	p, err := s.engine.AdjustMargin(request.PositionID, request.Symbol, request.Side, float64(request.Amount))
+	if err != nil {
+		return nil, rejected(err)
+	}
+
+	return model.AdjustPositionMarginData{
+		PositionID: p.ID,
+		Symbol:     p.Symbol,
+		MarginCoin: model.MarginCoin(s.marginCoin),
+		Margin:     model.NewDecimalFromFloat(p.Margin()),
+	}, nil
+}
+
+func (s *Server) handlePlaceOrder(_ url.Values, body []byte) (any, *apiError) {
+	var request orderRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	o, err := s.engine.PlaceOrder(request.model())
+	if err != nil {
+		return nil, rejected(err)
+	}
+
+	return model.OrderResponseData{OrderId: o.ID, ClientId: o.ClientID}, nil
+}
+
+func (s *Server) batchOrder(_ url.Values, body []byte) (any, *apiError) {
+	var request batchOrderRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	data := model.BatchOrderResponseData{
+		SuccessList: []model.BatchOrderResult{},
+		FailureList: []model.BatchOrderFailure{},
+	}
+	for _, orderRequest := range request.OrderList {
+		if orderRequest.Symbol == "" {
+			orderRequest.Symbol = request.Symbol
+		}
+
+		o, err := s.engine.PlaceOrder(orderRequest.model())
+		if err != nil {
+			apiErr := rejected(err)
+			data.FailureList = append(data.FailureList, model.BatchOrderFailure{
+				ClientId:  orderRequest.ClientID,
+				ErrorMsg:  apiErr.message,
+				ErrorCode: strconv.Itoa(apiErr.code),
+			})
+			continue
+		}
+		data.SuccessList = append(data.SuccessList, model.BatchOrderResult{OrderId: o.ID, ClientId: o.ClientID})
+	}
+
+	return data, nil
+}
+
+func (s *Server) cancelOrders(_ url.Values, body []byte) (any, *apiError) {
+	var request model.CancelOrderRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	data := model.CancelOrderResponseData{
+		SuccessList: []model.CancelOrderResult{},
+		FailureList: []model.CancelOrderFailure{},
+	}
+	symbol := request.Symbol.Normalize()
+	for _, param := range request.OrderList {
+		o := s.engine.FindOrder(param.OrderID, param.ClientID)
+		if o == nil || o.Symbol != symbol {
+			data.FailureList = append(data.FailureList, model.CancelOrderFailure{
+				OrderId:   param.OrderID,
+				ClientId:  param.ClientID,
+				ErrorMsg:  errOrderNotFound.message,
+				ErrorCode: strconv.Itoa(errOrderNotFound.code),
+			})
+			continue
+		}
+
+		s.engine.CancelOrder(o)
+		data.SuccessList = append(data.SuccessList, model.CancelOrderResult{OrderId: o.ID, ClientId: o.ClientID})
+	}
+
+	return data, nil
+}
+
+func (s *Server) cancelAllOrders(_ url.Values, body []byte) (any, *apiError) {
+	var request model.CancelAllOrdersRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	data := model.CancelOrderResponseData{
+		SuccessList: []model.CancelOrderResult{},
+		FailureList: []model.CancelOrderFailure{},
+	}
+	symbol := request.Symbol.Normalize()
+	for _, o := range s.engine.OpenOrders() {
+		if symbol != "" && o.Symbol != symbol {
+			continue
+		}
+
+		s.engine.CancelOrder(o)
+		data.SuccessList = append(data.SuccessList, model.CancelOrderResult{OrderId: o.ID, ClientId: o.ClientID})
+	}
+
+	return data, nil
+}
+
+func (s *Server) modifyOrder(_ url.Values, body []byte) (any, *apiError) {
+	var request modifyOrderRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	o := s.engine.FindOrder(request.OrderID, request.ClientID)
+	if o == nil {
+		return nil, errOrderNotFound
+	}
+
+	tp, sl := request.tpsl()
+	if err := s.engine.ModifyOrder(o, float64(request.Qty), float64(request.Price), tp, sl); err != nil {
+		return nil, rejected(err)
+	}
+
+	return model.OrderResponseData{OrderId: o.ID, ClientId: o.ClientID}, nil
+}
+
+func (s *Server) getOrderDetail(query url.Values, _ []byte) (any, *apiError) {
+	o := s.engine.LookupOrder(firstValue(query, "orderId"), firstValue(query, "clientId"))
+	if o == nil {
+		return nil, errOrderNotFound
+	}
+
+	return newOrderJSON(o), nil
+}
+
+func (s *Server) getPendingOrders(query url.Values, _ []byte) (any, *apiError) {
+	list, total, apiErr := listOrders(s.engine.OpenOrders(), query)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return map[string]any{"orderList": list, "total": strconv.Itoa(total)}, nil
+}
+
+func (s *Server) getHistoryOrders(query url.Values, _ []byte) (any, *apiError) {
+	list, total, apiErr := listOrders(s.engine.OrderHistory, query)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return map[string]any{"orderList": list, "total": strconv.Itoa(total)}, nil
+}
+
+func (s *Server) getHistoryTrades(query url.Values, _ []byte) (any, *apiError) {
+	q, apiErr := parseListQuery(query)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	symbol := model.Symbol(firstValue(query, "symbol")).Normalize()
+	orderID := firstValue(query, "orderId")
+	var matched []*paper.Trade
+	for _, t := range slices.Backward(s.engine.Trades) {
+		if (symbol == "" || t.Order.Symbol == symbol) && (orderID == "" || t.Order.ID == orderID) && q.includes(t.CreateTime) {
+			matched = append(matched, t)
+		}
+	}
+
+	list := make([]tradeJSON, 0, len(matched))
+	for _, t := range page(matched, q.skip, q.limit) {
+		list = append(list, newTradeJSON(t))
+	}
+
+	return map[string]any{"tradeList": list, "total": strconv.Itoa(len(matched))}, nil
+}
+
+func (s *Server) flashClosePosition(_ url.Values, body []byte) (any, *apiError) {
+	var request model.FlashClosePositionRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	p, ok := s.engine.Positions[request.PositionID]
+	if !ok {
+		return nil, errPositionNotExist
+	}
+
+	o, err := s.engine.ClosePosition(p)
+	if err != nil {
+		return nil, rejected(err)
+	}
+
+	return model.ClosePositionResult{PositionID: p.ID, OrderID: o.ID}, nil
+}
+
+func (s *Server) closeAllPositions(_ url.Values, body []byte) (any, *apiError) {
+	var request model.CloseAllPositionsRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	data := model.CloseAllPositionsData{
+		SuccessList: []model.ClosePositionResult{},
+		FailureList: []model.ClosePositionFailure{},
+	}
+	symbol := request.Symbol.Normalize()
+	for _, p := range s.engine.SortedPositions() {
+		if symbol != "" && p.Symbol != symbol {
+			continue
+		}
+
+		o, err := s.engine.ClosePosition(p)
+		if err != nil {
+			apiErr := rejected(err)
+			data.FailureList = append(data.FailureList, model.ClosePositionFailure{
+				PositionID: p.ID,
+				ErrorMsg:   apiErr.message,
+				ErrorCode:  strconv.Itoa(apiErr.code),
+			})
+			continue
+		}
+		data.SuccessList = append(data.SuccessList, model.ClosePositionResult{PositionID: p.ID, OrderID: o.ID})
+	}
+
+	return data, nil
+}
+
+func (s *Server) getPendingPositions(query url.Values, _ []byte) (any, *apiError) {
+	symbol := model.Symbol(firstValue(query, "symbol")).Normalize()
+	positionID := firstValue(query, "positionId")
+
+	list := []positionJSON{}
+	for _, p := range s.engine.SortedPositions() {
+		if (symbol == "" || p.Symbol == symbol) && (positionID == "" || p.ID == positionID) {
+			list = append(list, newPositionJSON(p, s.engine.Prices[p.Symbol]))
+		}
+	}
+
+	return list, nil
+}
+
+func (s *Server) getHistoryPositions(query url.Values, _ []byte) (any, *apiError) {
+	q, apiErr := parseListQuery(query)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	symbol := model.Symbol(firstValue(query, "symbol")).Normalize()
+	positionID := firstValue(query, "positionId")
+	var matched []*paper.Position
+	for _, p := range slices.Backward(s.engine.PositionHistory) {
+		if (symbol == "" || p.Symbol == symbol) && (positionID == "" || p.ID == positionID) && q.includes(p.ModifyTime) {
+			matched = append(matched, p)
+		}
+	}
+
+	list := make([]historicalPositionJSON, 0, len(matched))
+	for _, p := range page(matched, q.skip, q.limit) {
+		list = append(list, newHistoricalPositionJSON(p))
+	}
+
+	return map[string]any{"positionList": list, "total": strconv.Itoa(len(matched))}, nil
+}
+
+func (s *Server) placeTpSl(_ url.Values, body []byte) (any, *apiError) {
+	var request tpslOrderRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	tp, sl := request.tpsl()
+	o, err := s.engine.PlaceTpSl(request.PositionID, tp, sl, request.TpQty.value(), request.SlQty.value())
+	if err != nil {
+		return nil, rejected(err)
+	}
+
+	return []map[string]any{{"orderId": o.ID}}, nil
+}
+
+func (s *Server) modifyTpSl(_ url.Values, body []byte) (any, *apiError) {
+	var request tpslOrderRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	tp, sl := request.tpsl()
+	if err := s.engine.ModifyTpSl(request.OrderID, tp, sl, request.TpQty.value(), request.SlQty.value()); err != nil {
+		return nil, rejected(err)
+	}
+
+	return map[string]any{"orderId": request.OrderID}, nil
+}
+
+func (s *Server) cancelTpSl(_ url.Values, body []byte) (any, *apiError) {
+	var request model.CancelTPSLOrderRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if err := s.engine.CancelTpSl(request.OrderID); err != nil {
+		return nil, rejected(err)
+	}
+
+	return map[string]any{"orderId": request.OrderID}, nil
+}
+
+// tpslOrdersMatching returns tp/sl orders from newest to oldest
+func tpslOrdersMatching(orders []*paper.TpSlOrder, query url.Values, q listQuery) []*paper.TpSlOrder {
+	symbol := model.Symbol(firstValue(query, "symbol")).Normalize()
+	positionID := firstValue(query, "positionId")
+	// Pending orders are filtered by BUY/SELL, the history by LONG/SHORT
+	side := model.TradeSide(firstValue(query, "side")).Normalize()
+	switch model.PositionSide(side) {
+	case model.PositionSideLong:
+		side = model.TradeSideBuy
+	case model.PositionSideShort:
+		side = model.TradeSideSell
+	}
+	positionMode := model.PositionMode(firstValue(query, "positionMode")).Normalize()
+
+	var matched []*paper.TpSlOrder
+	for _, o := range orders {
+		if (symbol == "" || o.Symbol == symbol) &&
+			(positionID == "" || o.PositionID == positionID) &&
+			(side == "" || o.Side == side) &&
+			(positionMode == "" || o.PositionMode == positionMode) &&
+			q.includes(o.CreateTime) {
+			matched = append(matched, o)
+		}
+	}
+
+	return paper.NewestFirst(matched, func(o *paper.TpSlOrder) string { return o.ID })
+}
+
+func (s *Server) getPendingTpSlOrders(query url.Values, _ []byte) (any, *apiError) {
+	q, apiErr := parseListQuery(query)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	list := []tpslOrderJSON{}
+	for _, o := range page(tpslOrdersMatching(slices.Collect(maps.Values(s.engine.TpSlOrders)), query, q), q.skip, q.limit) {
+		list = append(list, newTpSlOrderJSON(o, s.marginCoin))
+	}
+
+	return list, nil
+}
+
+func (s *Server) getTpSlHistory(query url.Values, _ []byte) (any, *apiError) {
+	q, apiErr := parseListQuery(query)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	matched := tpslOrdersMatching(s.engine.TpSlHistory, query, q)
+	list := make([]tpslOrderJSON, 0, len(matched))
+	for _, o := range page(matched, q.skip, q.limit) {
+		list = append(list, newTpSlOrderJSON(o, s.marginCoin))
+	}
+
+	return map[string]any{"orderList": list, "total": strconv.Itoa(len(matched))}, nil
+}
+
+// placePositionTpSl replaces the tp/sl of the whole position if there is one already
+func (s *Server) placePositionTpSl(_ url.Values, body []byte) (any, *apiError) {
+	var request tpslOrderRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	tp, sl := request.tpsl()
+	o, err := s.engine.PlacePositionTpSl(request.PositionID, tp, sl)
+	if err != nil {
+		return nil, rejected(err)
+	}
+
+	return map[string]any{"orderId": o.ID}, nil
+}
+
+func (s *Server) modifyPositionTpSl(_ url.Values, body []byte) (any, *apiError) {
+	var request tpslOrderRequest
+	if apiErr := decode(body, &request); apiErr != nil {
+		return nil, apiErr
+	}
+
+	tp, sl := request.tpsl()
+	if err := s.engine.ModifyPositionTpSl(request.PositionID, tp, sl); err != nil {
+		return nil, rejected(err)
+	}
+
+	o, _ := s.engine.PositionTpSl(request.PositionID)
+	return map[string]any{"orderId": o.ID}, nil
+}
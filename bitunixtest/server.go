@@ -0,0 +1,251 @@
+// Package bitunixtest provides an in-memory Bitunix futures exchange for tests.
+// It serves the REST API and the public and private websocket protocols, so the
+// clients of the bitunix package can run against it without network access:
+//
+//	srv := bitunixtest.NewServer("api-key", "secret-key")
+//	defer srv.Close()
+//
+//	client, _ := bitunix.NewApiClient("api-key", "secret-key", bitunix.WithBaseURI(srv.URL))
+//	ws, _ := bitunix.NewPrivateWebsocket(ctx, "api-key", "secret-key", bitunix.WithWebsocketURI(srv.PrivateWebsocketURL))
+package bitunixtest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/tradingiq/bitunix-client/internal/paper"
+	"github.com/tradingiq/bitunix-client/model"
+	"github.com/tradingiq/bitunix-client/security"
+)
+
+// Server keeps an order and position book and answers signed REST requests from
+// it. Every change of the book is pushed to the logged in private websocket
+// connections. The book is matched by the engine behind bitunix.PaperClient:
+// market orders fill at the price set with SetPrice, limit orders rest until a
+// price crosses them or FillOrder is called.
+type Server struct {
+	URL                 string
+	PublicWebsocketURL  string
+	PrivateWebsocketURL string
+
+	apiKey     string
+	secretKey  string
+	marginCoin string
+	httpServer *httptest.Server
+
+	mu     sync.Mutex
+	engine *paper.Engine
+
+	connMu       sync.Mutex
+	publicConns  map[*publicConn]struct{}
+	privateConns map[*websocket.Conn]struct{}
+}
+
+type Option func(*Server)
+
+func WithBalance(balance float64) Option {
+	return func(s *Server) {
+		s.engine.Balance = balance
+	}
+}
+
+func WithMarginCoin(coin string) Option {
+	return func(s *Server) {
+		s.marginCoin = coin
+	}
+}
+
+// WithDefaultLeverage is used for symbols without a leverage set through ChangeLeverage
+func WithDefaultLeverage(leverage int) Option {
+	return func(s *Server) {
+		s.engine.DefaultLeverage = leverage
+	}
+}
+
+// WithFees charges fill value times the maker rate for resting limit orders and the taker rate otherwise
+func WithFees(maker, taker float64) Option {
+	return func(s *Server) {
+		s.engine.Fees = paper.RateFees(maker, taker)
+	}
+}
+
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.engine.Now = now
+	}
+}
+
+func NewServer(apiKey, secretKey string, options ...Option) *Server {
+	s := &Server{
+		apiKey:       apiKey,
+		secretKey:    secretKey,
+		marginCoin:   "USDT",
+		publicConns:  make(map[*publicConn]struct{}),
+		privateConns: make(map[*websocket.Conn]struct{}),
+	}
+	s.engine = paper.NewEngine(bookEvents{server: s})
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.httpServer = httptest.NewServer(s.routes())
+	s.URL = s.httpServer.URL
+	wsURL := "ws" + strings.TrimPrefix(s.URL, "http")
+	s.PublicWebsocketURL = wsURL + "/public/"
+	s.PrivateWebsocketURL = wsURL + "/private/"
+
+	return s
+}
+
+// Close drops all websocket connections and shuts the server down
+func (s *Server) Close() {
+	s.Disconnect()
+	s.httpServer.Close()
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/public/", s.servePublicWebsocket)
+	mux.HandleFunc("/private/", s.servePrivateWebsocket)
+
+	routes := map[string]handlerFunc{
+		"GET /api/v1/futures/account":                          s.getAccountBalance,
+		"POST /api/v1/futures/account/change_leverage":         s.changeLeverage,
+		"POST /api/v1/futures/account/change_margin_mode":      s.changeMarginMode,
+		"GET /api/v1/futures/account/get_leverage_margin_mode": s.getLeverageAndMarginMode,
+		"POST /api/v1/futures/account/change_position_mode":    s.changePositionMode,
+		"POST /api/v1/futures/account/adjust_position_margin":  s.adjustPositionMargin,
+		"POST /api/v1/futures/trade/place_order":               s.handlePlaceOrder,
+		"POST /api/v1/futures/trade/batch_order":               s.batchOrder,
+		"POST /api/v1/futures/trade/cancel_orders":             s.cancelOrders,
+		"POST /api/v1/futures/trade/cancel_all_orders":         s.cancelAllOrders,
+		"POST /api/v1/futures/trade/modify_order":              s.modifyOrder,
+		"GET /api/v1/futures/trade/get_order_detail":           s.getOrderDetail,
+		"GET /api/v1/futures/trade/get_pending_orders":         s.getPendingOrders,
+		"GET /api/v1/futures/trade/get_history_orders":         s.getHistoryOrders,
+		"GET /api/v1/futures/trade/get_history_trades":         s.getHistoryTrades,
+		"POST /api/v1/futures/trade/flash_close_position":      s.flashClosePosition,
+		"POST /api/v1/futures/trade/close_all_position":        s.closeAllPositions,
+		"GET /api/v1/futures/position/get_pending_positions":   s.getPendingPositions,
+		"GET /api/v1/futures/position/get_history_positions":   s.getHistoryPositions,
+		"POST /api/v1/futures/tpsl/place_order":                s.placeTpSl,
+		"POST /api/v1/futures/tpsl/modify_order":               s.modifyTpSl,
+		"POST /api/v1/futures/tpsl/cancel_order":               s.cancelTpSl,
+		"GET /api/v1/futures/tpsl/get_pending_orders":          s.getPendingTpSlOrders,
+		"GET /api/v1/futures/tpsl/get_history_orders":          s.getTpSlHistory,
+		"POST /api/v1/futures/tpsl/position/place_order":       s.placePositionTpSl,
+		"POST /api/v1/futures/tpsl/position/modify_order":      s.modifyPositionTpSl,
+	}
+	for pattern, handler := range routes {
+		mux.Handle(pattern, s.handle(handler))
+	}
+
+	return mux
+}
+
+type handlerFunc func(query url.Values, body []byte) (any, *apiError)
+
+// handle verifies the request signature and runs handler with the book locked
+func (s *Server) handle(handler handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if apiErr := s.authenticate(r, body); apiErr != nil {
+			writeResponse(w, nil, apiErr)
+			return
+		}
+
+		s.mu.Lock()
+		data, apiErr := handler(r.URL.Query(), body)
+		s.mu.Unlock()
+
+		writeResponse(w, data, apiErr)
+	})
+}
+
+func writeResponse(w http.ResponseWriter, data any, apiErr *apiError) {
+	resp := response{Code: 0, Message: "Success", Data: data}
+	if apiErr != nil {
+		resp = response{Code: apiErr.code, Message: apiErr.message}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// authenticate checks the headers set by bitunix.RequestSigner
+func (s *Server) authenticate(r *http.Request, body []byte) *apiError {
+	if r.Header.Get("Api-Key") != s.apiKey {
+		return &apiError{code: 10003, message: "Invalid api key"}
+	}
+
+	query := strings.NewReplacer("&", "", "=", "").Replace(r.URL.RawQuery)
+	digest := security.Sha256Hex(r.Header.Get("Nonce") + r.Header.Get("Timestamp") + s.apiKey + query + string(body))
+	if security.Sha256Hex(digest+s.secretKey) != r.Header.Get("Sign") {
+		return &apiError{code: 10007, message: "Signature Error"}
+	}
+
+	return nil
+}
+
+func decode(body []byte, v any) *apiError {
+	if err := json.Unmarshal(body, v); err != nil {
+		return parameterError("invalid request body: %v", err)
+	}
+	return nil
+}
+
+// SetPrice records a trade at price. Market orders and position closes fill at
+// it, resting limit and tp/sl orders crossed by it are executed.
+func (s *Server) SetPrice(symbol model.Symbol, price float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.engine.Move(symbol, price, price, price)
+}
+
+func (s *Server) SetBalance(balance float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.engine.Balance = balance
+	s.pushBalance()
+}
+
+// Balance is the wallet balance, including the margin of open positions
+func (s *Server) Balance() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.engine.Balance
+}
+
+// FillOrder fills qty of an open order at price as a maker
+func (s *Server) FillOrder(orderID string, qty, price float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.engine.Orders[orderID]
+	if !ok {
+		return errOrderNotFound
+	}
+
+	if qty <= 0 || qty > o.Remaining()+paper.Epsilon {
+		return parameterError("fill qty %v exceeds the remaining qty %v", qty, o.Remaining())
+	}
+
+	s.engine.Fill(o, qty, price, model.TradeRoleTypeMaker)
+	return nil
+}
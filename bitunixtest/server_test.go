@@ -0,0 +1,264 @@
+package bitunixtest_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tradingiq/bitunix-client/bitunix"
+	"github.com/tradingiq/bitunix-client/bitunixtest"
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+const (
+	apiKey    = "test-api-key"
+	secretKey = "test-secret-key"
+)
+
+func newClient(t *testing.T, srv *bitunixtest.Server, secret string) bitunix.ApiClient {
+	t.Helper()
+
+	client, err := bitunix.NewApiClient(apiKey, secret, bitunix.WithBaseURI(srv.URL))
+	require.NoError(t, err)
+	return client
+}
+
+func TestServerMarketOrderOpensPosition(t *testing.T) {
+	srv := bitunixtest.NewServer(apiKey, secretKey, bitunixtest.WithFees(0, 0.001))
+	defer srv.Close()
+	client := newClient(t, srv, secretKey)
+	ctx := context.Background()
+
+	srv.SetPrice("BTCUSDT", 50000)
+	resp, err := client.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol:    "BTCUSDT",
+		TradeSide: model.TradeSideBuy,
+		Side:      model.SideOpen,
+		OrderType: model.OrderTypeMarket,
+		Qty:       0.1,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Data.OrderId)
+
+	positions, err := client.GetPendingPositions(ctx, model.PendingPositionParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	require.Len(t, positions.Data, 1)
+	assert.Equal(t, model.TradeSideBuy, positions.Data[0].Side)
+	assert.InDelta(t, 0.1, positions.Data[0].Qty, 1e-9)
+	assert.InDelta(t, 5000, positions.Data[0].EntryValue, 1e-9)
+
+	detail, err := client.GetOrderDetail(ctx, &bitunix.OrderDetailRequest{OrderID: resp.Data.OrderId})
+	require.NoError(t, err)
+	assert.Equal(t, model.OrderStatusFilled, detail.Data.Status)
+	assert.InDelta(t, 0.1, detail.Data.TradeQuantity, 1e-9)
+
+	assert.InDelta(t, 10000-5, srv.Balance(), 1e-9)
+
+	closed, err := client.FlashClosePosition(ctx, positions.Data[0].PositionID)
+	require.NoError(t, err)
+	assert.Equal(t, positions.Data[0].PositionID, closed.Data.PositionID)
+	assert.NotEmpty(t, closed.Data.OrderID)
+
+	history, err := client.GetPositionHistory(ctx, model.PositionHistoryParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	require.Len(t, history.Data.Positions, 1)
+
+	positions, err = client.GetPendingPositions(ctx, model.PendingPositionParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	assert.Empty(t, positions.Data)
+}
+
+func TestServerLimitOrderRestsUntilFilled(t *testing.T) {
+	srv := bitunixtest.NewServer(apiKey, secretKey)
+	defer srv.Close()
+	client := newClient(t, srv, secretKey)
+	ctx := context.Background()
+
+	price := 48000.0
+	resp, err := client.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol:    "BTCUSDT",
+		TradeSide: model.TradeSideBuy,
+		Side:      model.SideOpen,
+		OrderType: model.OrderTypeLimit,
+		Price:     &price,
+		Qty:       0.2,
+		ClientID:  "client-1",
+	})
+	require.NoError(t, err)
+
+	pending, err := client.GetPendingOrder(ctx, model.PendingOrderParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	require.Len(t, pending.Data.OrderList, 1)
+	assert.Equal(t, "client-1", pending.Data.OrderList[0].ClientID)
+
+	require.NoError(t, srv.FillOrder(resp.Data.OrderId, 0.2, price))
+
+	pending, err = client.GetPendingOrder(ctx, model.PendingOrderParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	assert.Empty(t, pending.Data.OrderList)
+
+	history, err := client.GetOrderHistory(ctx, model.OrderHistoryParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	require.Len(t, history.Data.Orders, 1)
+	assert.Equal(t, model.OrderStatusFilled, history.Data.Orders[0].Status)
+
+	trades, err := client.GetTradeHistory(ctx, model.TradeHistoryParams{OrderID: resp.Data.OrderId})
+	require.NoError(t, err)
+	require.Len(t, trades.Data.Trades, 1)
+	assert.Equal(t, model.TradeRoleTypeMaker, trades.Data.Trades[0].RoleType)
+}
+
+func TestServerErrors(t *testing.T) {
+	srv := bitunixtest.NewServer(apiKey, secretKey, bitunixtest.WithBalance(100))
+	defer srv.Close()
+	ctx := context.Background()
+
+	_, err := newClient(t, srv, "wrong-secret").GetPendingPositions(ctx, model.PendingPositionParams{})
+	assert.ErrorIs(t, err, errors.ErrSignatureError)
+
+	client := newClient(t, srv, secretKey)
+	price := 50000.0
+	_, err = client.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol:    "BTCUSDT",
+		TradeSide: model.TradeSideBuy,
+		Side:      model.SideOpen,
+		OrderType: model.OrderTypeLimit,
+		Price:     &price,
+		Qty:       1,
+	})
+	assert.ErrorIs(t, err, errors.ErrInsufficientBalance)
+
+	_, err = client.GetOrderDetail(ctx, &bitunix.OrderDetailRequest{OrderID: "missing"})
+	assert.ErrorIs(t, err, errors.ErrOrderNotFound)
+}
+
+type orderRecorder struct {
+	mu     sync.Mutex
+	events []model.OrderEvent
+}
+
+func (r *orderRecorder) SubscribeOrder(msg *model.OrderChannelMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, msg.Data)
+}
+
+func (r *orderRecorder) statuses() []model.OrderStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var statuses []model.OrderStatus
+	for _, event := range r.events {
+		statuses = append(statuses, event.OrderStatus)
+	}
+	return statuses
+}
+
+func TestServerPushesPrivateEvents(t *testing.T) {
+	srv := bitunixtest.NewServer(apiKey, secretKey)
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ws, err := bitunix.NewPrivateWebsocket(ctx, apiKey, secretKey, bitunix.WithWebsocketURI(srv.PrivateWebsocketURL))
+	require.NoError(t, err)
+	defer ws.Disconnect()
+
+	recorder := &orderRecorder{}
+	require.NoError(t, ws.SubscribeOrders(recorder))
+	require.NoError(t, ws.Connect())
+	go func() { _ = ws.Stream() }()
+
+	srv.SetPrice("ETHUSDT", 3000)
+	_, err = newClient(t, srv, secretKey).PlaceOrder(ctx, &model.OrderRequest{
+		Symbol:    "ETHUSDT",
+		TradeSide: model.TradeSideSell,
+		Side:      model.SideOpen,
+		OrderType: model.OrderTypeMarket,
+		Qty:       1,
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(recorder.statuses()) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []model.OrderStatus{model.OrderStatusNew, model.OrderStatusFilled}, recorder.statuses())
+}
+
+func TestServerPublishesToSubscribers(t *testing.T) {
+	srv := bitunixtest.NewServer(apiKey, secretKey)
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ws, err := bitunix.NewPublicWebsocket(ctx, bitunix.WithWebsocketURI(srv.PublicWebsocketURL))
+	require.NoError(t, err)
+	defer ws.Disconnect()
+	require.NoError(t, ws.Connect())
+	go func() { _ = ws.Stream() }()
+
+	tickers := make(chan *model.TickerChannelMessage, 1)
+	require.NoError(t, ws.SubscribeTicker(tickerSubscriber(tickers)))
+	require.Eventually(t, func() bool {
+		return srv.Subscribed("BTCUSDT", model.ChannelTicker.String())
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, srv.Publish("BTCUSDT", model.ChannelTicker.String(), map[string]string{"la": "50123.5"}))
+
+	select {
+	case msg := <-tickers:
+		assert.Equal(t, model.Symbol("BTCUSDT"), msg.Symbol)
+		assert.InDelta(t, 50123.5, msg.Data.LastPrice, 1e-9)
+	case <-time.After(2 * time.Second):
+		t.Fatal("ticker was not delivered")
+	}
+}
+
+type tickerSubscriber chan *model.TickerChannelMessage
+
+func (s tickerSubscriber) SubscribeTicker(msg *model.TickerChannelMessage) { s <- msg }
+
+func (s tickerSubscriber) SubscribeSymbol() model.Symbol { return "BTCUSDT" }
+
+func TestServerMatchesOrdersOnPrice(t *testing.T) {
+	srv := bitunixtest.NewServer(apiKey, secretKey)
+	defer srv.Close()
+	client := newClient(t, srv, secretKey)
+	ctx := context.Background()
+
+	srv.SetPrice("BTCUSDT", 50000)
+	price, stop := 49000.0, 48000.0
+	resp, err := client.PlaceOrder(ctx, &model.OrderRequest{
+		Symbol:    "BTCUSDT",
+		TradeSide: model.TradeSideBuy,
+		Side:      model.SideOpen,
+		OrderType: model.OrderTypeLimit,
+		Price:     &price,
+		Qty:       0.1,
+		SlPrice:   &stop,
+	})
+	require.NoError(t, err)
+
+	// The limit order rests until the price crosses it, like with PaperClient
+	srv.SetPrice("BTCUSDT", 49500)
+	detail, err := client.GetOrderDetail(ctx, &bitunix.OrderDetailRequest{OrderID: resp.Data.OrderId})
+	require.NoError(t, err)
+	assert.Equal(t, model.OrderStatusNew, detail.Data.Status)
+
+	srv.SetPrice("BTCUSDT", 49000)
+	positions, err := client.GetPendingPositions(ctx, model.PendingPositionParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	require.Len(t, positions.Data, 1)
+	assert.InDelta(t, 49000, positions.Data[0].AvgOpenPrice, 1e-9)
+
+	// The attached stop loss closes the position
+	srv.SetPrice("BTCUSDT", 47900)
+	positions, err = client.GetPendingPositions(ctx, model.PendingPositionParams{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	assert.Empty(t, positions.Data)
+	assert.InDelta(t, 10000-110, srv.Balance(), 1e-9)
+}
@@ -0,0 +1,244 @@
+package bitunixtest
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/tradingiq/bitunix-client/model"
+	"github.com/tradingiq/bitunix-client/security"
+)
+
+const websocketWriteTimeout = 5 * time.Second
+
+type subscription struct {
+	symbol  model.Symbol
+	channel string
+}
+
+type publicConn struct {
+	conn          *websocket.Conn
+	subscriptions map[subscription]struct{}
+}
+
+func (s *Server) servePublicWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow()
+
+	ctx := r.Context()
+	if err := s.writeConnected(ctx, conn); err != nil {
+		return
+	}
+
+	pc := &publicConn{conn: conn, subscriptions: make(map[subscription]struct{})}
+	s.connMu.Lock()
+	s.publicConns[pc] = struct{}{}
+	s.connMu.Unlock()
+
+	defer func() {
+		s.connMu.Lock()
+		delete(s.publicConns, pc)
+		s.connMu.Unlock()
+	}()
+
+	for {
+		var request websocketRequest
+		if err := wsjson.Read(ctx, conn, &request); err != nil {
+			return
+		}
+
+		switch request.Op {
+		case "ping":
+			if err := s.writePong(ctx, conn, request.Ping); err != nil {
+				return
+			}
+		case "subscribe", "unsubscribe":
+			s.connMu.Lock()
+			for _, raw := range request.Args {
+				var args subscriptionArgs
+				if err := json.Unmarshal(raw, &args); err != nil {
+					continue
+				}
+
+				sub := subscription{symbol: args.Symbol.Normalize(), channel: args.Ch}
+				if request.Op == "subscribe" {
+					pc.subscriptions[sub] = struct{}{}
+				} else {
+					delete(pc.subscriptions, sub)
+				}
+			}
+			s.connMu.Unlock()
+		}
+	}
+}
+
+func (s *Server) servePrivateWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow()
+
+	ctx := r.Context()
+	if err := s.writeConnected(ctx, conn); err != nil {
+		return
+	}
+
+	var login websocketRequest
+	if err := wsjson.Read(ctx, conn, &login); err != nil {
+		return
+	}
+
+	if !s.verifyLogin(login) {
+		_ = wsjson.Write(ctx, conn, map[string]any{"op": "login", "data": map[string]any{"result": false}})
+		conn.Close(websocket.StatusPolicyViolation, "authentication failed")
+		return
+	}
+
+	// Registered before answering, pushes are delivered once the client is connected
+	s.connMu.Lock()
+	s.privateConns[conn] = struct{}{}
+	s.connMu.Unlock()
+
+	defer func() {
+		s.connMu.Lock()
+		delete(s.privateConns, conn)
+		s.connMu.Unlock()
+	}()
+
+	if err := wsjson.Write(ctx, conn, map[string]any{"op": "login", "data": map[string]any{"result": true}}); err != nil {
+		return
+	}
+
+	for {
+		var request websocketRequest
+		if err := wsjson.Read(ctx, conn, &request); err != nil {
+			return
+		}
+
+		if request.Op == "ping" {
+			if err := s.writePong(ctx, conn, request.Ping); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// verifyLogin checks a login built by bitunix.WebsocketSigner
+func (s *Server) verifyLogin(request websocketRequest) bool {
+	if request.Op != "login" || len(request.Args) != 1 {
+		return false
+	}
+
+	var args loginArgs
+	if err := json.Unmarshal(request.Args[0], &args); err != nil {
+		return false
+	}
+
+	if _, err := hex.DecodeString(args.Nonce); err != nil || args.ApiKey != s.apiKey {
+		return false
+	}
+
+	preSign := security.Sha256Hex(fmt.Sprintf("%s%d%s", args.Nonce, args.Timestamp, args.ApiKey))
+	return security.Sha256Hex(preSign+s.secretKey) == args.Sign
+}
+
+func (s *Server) writeConnected(ctx context.Context, conn *websocket.Conn) error {
+	return wsjson.Write(ctx, conn, map[string]any{"op": "connect", "data": map[string]any{"result": true}})
+}
+
+func (s *Server) writePong(ctx context.Context, conn *websocket.Conn, ping int64) error {
+	return wsjson.Write(ctx, conn, map[string]any{"op": "ping", "ping": ping, "pong": s.engine.Now().Unix()})
+}
+
+// Disconnect drops all websocket connections, clients see it as a connection loss
+func (s *Server) Disconnect() {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	for pc := range s.publicConns {
+		pc.conn.CloseNow()
+	}
+	for conn := range s.privateConns {
+		conn.CloseNow()
+	}
+}
+
+// Subscribed reports whether a public connection subscribed to channel of symbol.
+// Subscriptions are sent asynchronously, tests wait for them before publishing.
+func (s *Server) Subscribed(symbol model.Symbol, channel string) bool {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	sub := subscription{symbol: symbol.Normalize(), channel: channel}
+	for pc := range s.publicConns {
+		if _, ok := pc.subscriptions[sub]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Publish pushes data on channel to the public connections subscribed to it.
+// Channels without a symbol, like tickers, are published with an empty symbol.
+func (s *Server) Publish(symbol model.Symbol, channel string, data any) error {
+	bytes, err := json.Marshal(channelMessage{Channel: channel, Symbol: symbol.Normalize(), Ts: s.engine.Now().UnixMilli(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	sub := subscription{symbol: symbol.Normalize(), channel: channel}
+	for pc := range s.publicConns {
+		if _, ok := pc.subscriptions[sub]; ok {
+			s.write(pc.conn, bytes)
+		}
+	}
+	return nil
+}
+
+// pushPrivate sends a private channel update, it is called with the book locked
+func (s *Server) pushPrivate(channel string, data any) {
+	bytes, err := json.Marshal(channelMessage{Channel: channel, Ts: s.engine.Now().UnixMilli(), Data: data})
+	if err != nil {
+		return
+	}
+
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+
+	for conn := range s.privateConns {
+		s.write(conn, bytes)
+	}
+}
+
+func (s *Server) write(conn *websocket.Conn, bytes []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), websocketWriteTimeout)
+	defer cancel()
+
+	_ = conn.Write(ctx, websocket.MessageText, bytes)
+}
+
+func (s *Server) pushBalance() {
+	b := s.engine.Balances()
+	s.pushPrivate(model.ChannelBalance, balanceEventJSON{
+		Coin:            s.marginCoin,
+		Available:       number(b.Available),
+		Frozen:          number(b.Frozen),
+		IsolationFrozen: number(b.IsolationFrozen),
+		CrossFrozen:     number(b.CrossFrozen),
+		Margin:          number(b.Margin),
+		IsolationMargin: number(b.IsolationMargin),
+		CrossMargin:     number(b.CrossMargin),
+	})
+}
@@ -0,0 +1,590 @@
+package bitunixtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/internal/paper"
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+// number is a float carried as a JSON string, as done by the exchange. Bare
+// JSON numbers are accepted as well.
+type number float64
+
+func (n number) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, strconv.FormatFloat(float64(n), 'f', -1, 64)), nil
+}
+
+func (n *number) UnmarshalJSON(data []byte) error {
+	value := string(bytes.Trim(data, `"`))
+	if value == "" || value == "null" {
+		*n = 0
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	*n = number(f)
+	return nil
+}
+
+func optionalNumber(value *float64) *number {
+	if value == nil {
+		return nil
+	}
+	n := number(*value)
+	return &n
+}
+
+func (n *number) value() *float64 {
+	if n == nil {
+		return nil
+	}
+	f := float64(*n)
+	return &f
+}
+
+// millis is a unix millisecond timestamp carried as a JSON string
+type millis int64
+
+func (m millis) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, strconv.FormatInt(int64(m), 10)), nil
+}
+
+func toMillis(t time.Time) millis {
+	return millis(t.UnixMilli())
+}
+
+type response struct {
+	Code    int    `json:"code"`
+	Message string `json:"msg"`
+	Data    any    `json:"data"`
+}
+
+type orderRequest struct {
+	Symbol       model.Symbol      `json:"symbol"`
+	Side         model.TradeSide   `json:"side"`
+	TradeSide    model.Side        `json:"tradeSide"`
+	Price        *number           `json:"price"`
+	Qty          number            `json:"qty"`
+	PositionID   string            `json:"positionId"`
+	OrderType    model.OrderType   `json:"orderType"`
+	ReduceOnly   bool              `json:"reduceOnly"`
+	Effect       model.TimeInForce `json:"effect"`
+	ClientID     string            `json:"clientId"`
+	TpPrice      *number           `json:"tpPrice"`
+	TpStopType   model.StopType    `json:"tpStopType"`
+	TpOrderType  model.OrderType   `json:"tpOrderType"`
+	TpOrderPrice *number           `json:"tpOrderPrice"`
+	SlPrice      *number           `json:"slPrice"`
+	SlStopType   model.StopType    `json:"slStopType"`
+	SlOrderType  model.OrderType   `json:"slOrderType"`
+	SlOrderPrice *number           `json:"slOrderPrice"`
+}
+
+func (r orderRequest) model() *model.OrderRequest {
+	tp, sl := r.tpsl()
+	return &model.OrderRequest{
+		Symbol:       r.Symbol,
+		TradeSide:    r.Side,
+		Side:         r.TradeSide,
+		Price:        r.Price.value(),
+		Qty:          float64(r.Qty),
+		PositionID:   r.PositionID,
+		OrderType:    r.OrderType,
+		ReduceOnly:   r.ReduceOnly,
+		Effect:       r.Effect,
+		ClientID:     r.ClientID,
+		TpPrice:      tp.Price,
+		TpStopType:   tp.StopType,
+		TpOrderType:  tp.OrderType,
+		TpOrderPrice: tp.OrderPrice,
+		SlPrice:      sl.Price,
+		SlStopType:   sl.StopType,
+		SlOrderType:  sl.OrderType,
+		SlOrderPrice: sl.OrderPrice,
+	}
+}
+
+func (r orderRequest) tpsl() (tp, sl paper.Trigger) {
+	return paper.Trigger{Price: r.TpPrice.value(), StopType: r.TpStopType, OrderType: r.TpOrderType, OrderPrice: r.TpOrderPrice.value()},
+		paper.Trigger{Price: r.SlPrice.value(), StopType: r.SlStopType, OrderType: r.SlOrderType, OrderPrice: r.SlOrderPrice.value()}
+}
+
+type batchOrderRequest struct {
+	Symbol    model.Symbol   `json:"symbol"`
+	OrderList []orderRequest `json:"orderList"`
+}
+
+type modifyOrderRequest struct {
+	OrderID      string          `json:"orderId"`
+	ClientID     string          `json:"clientId"`
+	Qty          number          `json:"qty"`
+	Price        number          `json:"price"`
+	TpPrice      *number         `json:"tpPrice"`
+	TpStopType   model.StopType  `json:"tpStopType"`
+	TpOrderType  model.OrderType `json:"tpOrderType"`
+	TpOrderPrice *number         `json:"tpOrderPrice"`
+	SlPrice      *number         `json:"slPrice"`
+	SlStopType   model.StopType  `json:"slStopType"`
+	SlOrderType  model.OrderType `json:"slOrderType"`
+	SlOrderPrice *number         `json:"slOrderPrice"`
+}
+
+func (r modifyOrderRequest) tpsl() (tp, sl paper.Trigger) {
+	return paper.Trigger{Price: r.TpPrice.value(), StopType: r.TpStopType, OrderType: r.TpOrderType, OrderPrice: r.TpOrderPrice.value()},
+		paper.Trigger{Price: r.SlPrice.value(), StopType: r.SlStopType, OrderType: r.SlOrderType, OrderPrice: r.SlOrderPrice.value()}
+}
+
+type tpslOrderRequest struct {
+	OrderID      string          `json:"orderId"`
+	Symbol       model.Symbol    `json:"symbol"`
+	PositionID   string          `json:"positionId"`
+	TpPrice      *number         `json:"tpPrice"`
+	TpStopType   model.StopType  `json:"tpStopType"`
+	TpOrderType  model.OrderType `json:"tpOrderType"`
+	TpOrderPrice *number         `json:"tpOrderPrice"`
+	SlPrice      *number         `json:"slPrice"`
+	SlStopType   model.StopType  `json:"slStopType"`
+	SlOrderType  model.OrderType `json:"slOrderType"`
+	SlOrderPrice *number         `json:"slOrderPrice"`
+	TpQty        *number         `json:"tpQty"`
+	SlQty        *number         `json:"slQty"`
+}
+
+func (r tpslOrderRequest) tpsl() (tp, sl paper.Trigger) {
+	return paper.Trigger{Price: r.TpPrice.value(), StopType: r.TpStopType, OrderType: r.TpOrderType, OrderPrice: r.TpOrderPrice.value()},
+		paper.Trigger{Price: r.SlPrice.value(), StopType: r.SlStopType, OrderType: r.SlOrderType, OrderPrice: r.SlOrderPrice.value()}
+}
+
+type adjustMarginRequest struct {
+	Symbol     model.Symbol       `json:"symbol"`
+	Amount     number             `json:"amount"`
+	Side       model.PositionSide `json:"side"`
+	PositionID string             `json:"positionId"`
+}
+
+type orderJSON struct {
+	OrderID       string             `json:"orderId"`
+	ClientID      string             `json:"clientId"`
+	Symbol        model.Symbol       `json:"symbol"`
+	Quantity      number             `json:"qty"`
+	TradeQuantity number             `json:"tradeQty"`
+	Price         number             `json:"price"`
+	PositionMode  model.PositionMode `json:"positionMode"`
+	MarginMode    model.MarginMode   `json:"marginMode"`
+	Leverage      int                `json:"leverage"`
+	Side          model.TradeSide    `json:"side"`
+	OrderType     model.OrderType    `json:"orderType"`
+	Effect        model.TimeInForce  `json:"effect"`
+	ReduceOnly    bool               `json:"reduceOnly"`
+	Status        model.OrderStatus  `json:"status"`
+	Fee           number             `json:"fee"`
+	RealizedPNL   number             `json:"realizedPNL"`
+	TpPrice       *number            `json:"tpPrice,omitempty"`
+	TpStopType    model.StopType     `json:"tpStopType,omitempty"`
+	TpOrderType   model.OrderType    `json:"tpOrderType,omitempty"`
+	TpOrderPrice  *number            `json:"tpOrderPrice,omitempty"`
+	SlPrice       *number            `json:"slPrice,omitempty"`
+	SlStopType    model.StopType     `json:"slStopType,omitempty"`
+	SlOrderType   model.OrderType    `json:"slOrderType,omitempty"`
+	SlOrderPrice  *number            `json:"slOrderPrice,omitempty"`
+	CreateTime    millis             `json:"ctime"`
+	ModifyTime    millis             `json:"mtime"`
+}
+
+func newOrderJSON(o *paper.Order) orderJSON {
+	return orderJSON{
+		OrderID:       o.ID,
+		ClientID:      o.ClientID,
+		Symbol:        o.Symbol,
+		Quantity:      number(o.Qty),
+		TradeQuantity: number(o.TradeQty),
+		Price:         number(o.Price),
+		PositionMode:  o.PositionMode,
+		MarginMode:    o.MarginMode,
+		Leverage:      o.Leverage,
+		Side:          o.Side,
+		OrderType:     o.OrderType,
+		Effect:        o.Effect,
+		ReduceOnly:    o.ReduceOnly,
+		Status:        o.Status,
+		Fee:           number(o.Fee),
+		RealizedPNL:   number(o.RealizedPNL),
+		TpPrice:       optionalNumber(o.TP.Price),
+		TpStopType:    o.TP.StopType,
+		TpOrderType:   o.TP.OrderType,
+		TpOrderPrice:  optionalNumber(o.TP.OrderPrice),
+		SlPrice:       optionalNumber(o.SL.Price),
+		SlStopType:    o.SL.StopType,
+		SlOrderType:   o.SL.OrderType,
+		SlOrderPrice:  optionalNumber(o.SL.OrderPrice),
+		CreateTime:    toMillis(o.CreateTime),
+		ModifyTime:    toMillis(o.ModifyTime),
+	}
+}
+
+type positionJSON struct {
+	PositionID    string             `json:"positionId"`
+	Symbol        model.Symbol       `json:"symbol"`
+	Quantity      number             `json:"qty"`
+	EntryValue    number             `json:"entryValue"`
+	Side          model.TradeSide    `json:"side"`
+	PositionMode  model.PositionMode `json:"positionMode"`
+	MarginMode    model.MarginMode   `json:"marginMode"`
+	Leverage      int                `json:"leverage"`
+	Fees          number             `json:"fees"`
+	Funding       number             `json:"funding"`
+	RealizedPNL   number             `json:"realizedPNL"`
+	Margin        number             `json:"margin"`
+	UnrealizedPNL number             `json:"unrealizedPNL"`
+	LiqPrice      number             `json:"liqPrice"`
+	MarginRate    number             `json:"marginRate"`
+	AvgOpenPrice  number             `json:"avgOpenPrice"`
+	CreateTime    millis             `json:"ctime"`
+	ModifyTime    millis             `json:"mtime"`
+}
+
+func newPositionJSON(p *paper.Position, markPrice float64) positionJSON {
+	return positionJSON{
+		PositionID:    p.ID,
+		Symbol:        p.Symbol,
+		Quantity:      number(p.Qty),
+		EntryValue:    number(p.EntryValue()),
+		Side:          p.Side,
+		PositionMode:  p.PositionMode,
+		MarginMode:    p.MarginMode,
+		Leverage:      p.Leverage,
+		Fees:          number(p.Fee),
+		RealizedPNL:   number(p.RealizedPNL),
+		Margin:        number(p.Margin()),
+		UnrealizedPNL: number(p.UnrealizedPNL(markPrice)),
+		AvgOpenPrice:  number(p.EntryPrice),
+		CreateTime:    toMillis(p.CreateTime),
+		ModifyTime:    toMillis(p.ModifyTime),
+	}
+}
+
+type historicalPositionJSON struct {
+	PositionID   string             `json:"positionId"`
+	Symbol       model.Symbol       `json:"symbol"`
+	MaxQty       number             `json:"maxQty"`
+	EntryPrice   number             `json:"entryPrice"`
+	ClosePrice   number             `json:"closePrice"`
+	LiqQty       number             `json:"liqQty"`
+	Side         model.TradeSide    `json:"side"`
+	PositionMode model.PositionMode `json:"positionMode"`
+	MarginMode   model.MarginMode   `json:"marginMode"`
+	Leverage     string             `json:"leverage"`
+	Fee          number             `json:"fee"`
+	Funding      number             `json:"funding"`
+	RealizedPNL  number             `json:"realizedPNL"`
+	LiqPrice     number             `json:"liqPrice"`
+	CreateTime   millis             `json:"ctime"`
+	ModifyTime   millis             `json:"mtime"`
+}
+
+func newHistoricalPositionJSON(p *paper.Position) historicalPositionJSON {
+	return historicalPositionJSON{
+		PositionID:   p.ID,
+		Symbol:       p.Symbol,
+		MaxQty:       number(p.MaxQty),
+		EntryPrice:   number(p.EntryPrice),
+		ClosePrice:   number(p.ClosePrice()),
+		Side:         p.Side,
+		PositionMode: p.PositionMode,
+		MarginMode:   p.MarginMode,
+		Leverage:     strconv.Itoa(p.Leverage),
+		Fee:          number(p.Fee),
+		RealizedPNL:  number(p.RealizedPNL),
+		CreateTime:   toMillis(p.CreateTime),
+		ModifyTime:   toMillis(p.ModifyTime),
+	}
+}
+
+type tradeJSON struct {
+	TradeID      string              `json:"tradeId"`
+	OrderID      string              `json:"orderId"`
+	ClientID     string              `json:"clientId"`
+	Symbol       model.Symbol        `json:"symbol"`
+	Quantity     number              `json:"qty"`
+	Price        number              `json:"price"`
+	Fee          number              `json:"fee"`
+	RealizedPNL  number              `json:"realizedPNL"`
+	PositionMode model.PositionMode  `json:"positionMode"`
+	MarginMode   model.MarginMode    `json:"marginMode"`
+	Leverage     int                 `json:"leverage"`
+	Side         model.TradeSide     `json:"side"`
+	OrderType    model.OrderType     `json:"orderType"`
+	Effect       model.TimeInForce   `json:"effect"`
+	ReduceOnly   bool                `json:"reduceOnly"`
+	RoleType     model.TradeRoleType `json:"roleType"`
+	CreateTime   millis              `json:"ctime"`
+}
+
+func newTradeJSON(t *paper.Trade) tradeJSON {
+	return tradeJSON{
+		TradeID:      t.ID,
+		OrderID:      t.Order.ID,
+		ClientID:     t.Order.ClientID,
+		Symbol:       t.Order.Symbol,
+		Quantity:     number(t.Qty),
+		Price:        number(t.Price),
+		Fee:          number(t.Fee),
+		RealizedPNL:  number(t.RealizedPNL),
+		PositionMode: t.Order.PositionMode,
+		MarginMode:   t.Order.MarginMode,
+		Leverage:     t.Order.Leverage,
+		Side:         t.Order.Side,
+		OrderType:    t.Order.OrderType,
+		Effect:       t.Order.Effect,
+		ReduceOnly:   t.Order.ReduceOnly,
+		RoleType:     t.RoleType,
+		CreateTime:   toMillis(t.CreateTime),
+	}
+}
+
+type tpslOrderJSON struct {
+	ID           string            `json:"id"`
+	PositionID   string            `json:"positionId"`
+	Symbol       model.Symbol      `json:"symbol"`
+	Base         string            `json:"base"`
+	Quote        string            `json:"quote"`
+	TpPrice      *number           `json:"tpPrice,omitempty"`
+	TpStopType   model.StopType    `json:"tpStopType,omitempty"`
+	TpOrderType  model.OrderType   `json:"tpOrderType,omitempty"`
+	TpOrderPrice *number           `json:"tpOrderPrice,omitempty"`
+	SlPrice      *number           `json:"slPrice,omitempty"`
+	SlStopType   model.StopType    `json:"slStopType,omitempty"`
+	SlOrderType  model.OrderType   `json:"slOrderType,omitempty"`
+	SlOrderPrice *number           `json:"slOrderPrice,omitempty"`
+	TpQty        *number           `json:"tpQty,omitempty"`
+	SlQty        *number           `json:"slQty,omitempty"`
+	Status       model.OrderStatus `json:"status"`
+	CreateTime   millis            `json:"ctime"`
+	TriggerTime  *millis           `json:"triggerTime,omitempty"`
+}
+
+func newTpSlOrderJSON(o *paper.TpSlOrder, marginCoin string) tpslOrderJSON {
+	base, _ := strings.CutSuffix(o.Symbol.String(), marginCoin)
+	return tpslOrderJSON{
+		ID:           o.ID,
+		PositionID:   o.PositionID,
+		Symbol:       o.Symbol,
+		Base:         base,
+		Quote:        marginCoin,
+		TpPrice:      optionalNumber(o.TP.Price),
+		TpStopType:   o.TP.StopType,
+		TpOrderType:  o.TP.OrderType,
+		TpOrderPrice: optionalNumber(o.TP.OrderPrice),
+		SlPrice:      optionalNumber(o.SL.Price),
+		SlStopType:   o.SL.StopType,
+		SlOrderType:  o.SL.OrderType,
+		SlOrderPrice: optionalNumber(o.SL.OrderPrice),
+		TpQty:        optionalNumber(o.TpQty),
+		SlQty:        optionalNumber(o.SlQty),
+		Status:       o.Status,
+		CreateTime:   toMillis(o.CreateTime),
+	}
+}
+
+type balanceJSON struct {
+	MarginCoin             string             `json:"marginCoin"`
+	Available              number             `json:"available"`
+	Frozen                 number             `json:"frozen"`
+	Margin                 number             `json:"margin"`
+	Transfer               number             `json:"transfer"`
+	PositionMode           model.PositionMode `json:"positionMode"`
+	CrossUnrealizedPNL     number             `json:"crossUnrealizedPNL"`
+	IsolationUnrealizedPNL number             `json:"isolationUnrealizedPNL"`
+	Bonus                  number             `json:"bonus"`
+}
+
+type channelMessage struct {
+	Channel string       `json:"ch"`
+	Symbol  model.Symbol `json:"symbol,omitempty"`
+	Ts      int64        `json:"ts"`
+	Data    any          `json:"data"`
+}
+
+type orderEventJSON struct {
+	Event         model.OrderEventType `json:"event"`
+	OrderID       string               `json:"orderId"`
+	Symbol        model.Symbol         `json:"symbol"`
+	PositionType  model.MarginMode     `json:"positionType"`
+	PositionMode  model.PositionMode   `json:"positionMode"`
+	Side          model.TradeSide      `json:"side"`
+	Effect        model.TimeInForce    `json:"effect"`
+	Type          model.OrderType      `json:"type"`
+	Quantity      number               `json:"qty"`
+	ReductionOnly bool                 `json:"reductionOnly"`
+	Price         number               `json:"price"`
+	CreateTime    time.Time            `json:"ctime"`
+	ModifyTime    time.Time            `json:"mtime"`
+	Leverage      string               `json:"leverage"`
+	OrderStatus   model.OrderStatus    `json:"orderStatus"`
+	Fee           number               `json:"fee"`
+	TpStopType    model.StopType       `json:"tpStopType,omitempty"`
+	TpPrice       *number              `json:"tpPrice,omitempty"`
+	TpOrderType   model.OrderType      `json:"tpOrderType,omitempty"`
+	TpOrderPrice  *number              `json:"tpOrderPrice,omitempty"`
+	SlStopType    model.StopType       `json:"slStopType,omitempty"`
+	SlPrice       *number              `json:"slPrice,omitempty"`
+	SlOrderType   model.OrderType      `json:"slOrderType,omitempty"`
+	SlOrderPrice  *number              `json:"slOrderPrice,omitempty"`
+}
+
+func newOrderEventJSON(o *paper.Order, event model.OrderEventType) orderEventJSON {
+	return orderEventJSON{
+		Event:         event,
+		OrderID:       o.ID,
+		Symbol:        o.Symbol,
+		PositionType:  o.MarginMode,
+		PositionMode:  o.PositionMode,
+		Side:          o.Side,
+		Effect:        o.Effect,
+		Type:          o.OrderType,
+		Quantity:      number(o.Qty),
+		ReductionOnly: o.ReduceOnly,
+		Price:         number(o.Price),
+		CreateTime:    o.CreateTime,
+		ModifyTime:    o.ModifyTime,
+		Leverage:      strconv.Itoa(o.Leverage),
+		OrderStatus:   o.Status,
+		Fee:           number(o.Fee),
+		TpStopType:    o.TP.StopType,
+		TpPrice:       optionalNumber(o.TP.Price),
+		TpOrderType:   o.TP.OrderType,
+		TpOrderPrice:  optionalNumber(o.TP.OrderPrice),
+		SlStopType:    o.SL.StopType,
+		SlPrice:       optionalNumber(o.SL.Price),
+		SlOrderType:   o.SL.OrderType,
+		SlOrderPrice:  optionalNumber(o.SL.OrderPrice),
+	}
+}
+
+type positionEventJSON struct {
+	Event         model.PositionEventType `json:"event"`
+	PositionID    string                  `json:"positionId"`
+	Symbol        model.Symbol            `json:"symbol"`
+	MarginMode    model.MarginMode        `json:"marginMode"`
+	PositionMode  model.PositionMode      `json:"positionMode"`
+	Side          model.PositionSide      `json:"side"`
+	Leverage      string                  `json:"leverage"`
+	Margin        number                  `json:"margin"`
+	CreateTime    time.Time               `json:"ctime"`
+	Quantity      number                  `json:"qty"`
+	EntryValue    number                  `json:"entryValue"`
+	RealizedPNL   number                  `json:"realizedPNL"`
+	UnrealizedPNL number                  `json:"unrealizedPNL"`
+	Funding       number                  `json:"funding"`
+	Fee           number                  `json:"fee"`
+}
+
+func newPositionEventJSON(p *paper.Position, event model.PositionEventType, markPrice float64) positionEventJSON {
+	side := model.PositionSideLong
+	if p.Side == model.TradeSideSell {
+		side = model.PositionSideShort
+	}
+
+	return positionEventJSON{
+		Event:         event,
+		PositionID:    p.ID,
+		Symbol:        p.Symbol,
+		MarginMode:    p.MarginMode,
+		PositionMode:  p.PositionMode,
+		Side:          side,
+		Leverage:      strconv.Itoa(p.Leverage),
+		Margin:        number(p.Margin()),
+		CreateTime:    p.CreateTime,
+		Quantity:      number(p.Qty),
+		EntryValue:    number(p.EntryValue()),
+		RealizedPNL:   number(p.RealizedPNL),
+		UnrealizedPNL: number(p.UnrealizedPNL(markPrice)),
+		Fee:           number(p.Fee),
+	}
+}
+
+type balanceEventJSON struct {
+	Coin            string `json:"coin"`
+	Available       number `json:"available"`
+	Frozen          number `json:"frozen"`
+	IsolationFrozen number `json:"isolationFrozen"`
+	CrossFrozen     number `json:"crossFrozen"`
+	Margin          number `json:"margin"`
+	IsolationMargin number `json:"isolationMargin"`
+	CrossMargin     number `json:"crossMargin"`
+	ExpMoney        number `json:"expMoney"`
+}
+
+type tpslEventJSON struct {
+	Event        model.TpSlEventType `json:"event"`
+	PositionID   string              `json:"positionId"`
+	OrderID      string              `json:"orderId"`
+	Symbol       model.Symbol        `json:"symbol"`
+	Leverage     string              `json:"leverage"`
+	Side         model.TradeSide     `json:"side"`
+	PositionMode model.PositionMode  `json:"positionMode"`
+	Status       model.OrderStatus   `json:"status"`
+	CreateTime   time.Time           `json:"ctime"`
+	Type         model.TpSlType      `json:"type"`
+	TpQty        *number             `json:"tpQty,omitempty"`
+	SlQty        *number             `json:"slQty,omitempty"`
+	TpStopType   model.StopType      `json:"tpStopType,omitempty"`
+	TpPrice      *number             `json:"tpPrice,omitempty"`
+	TpOrderType  model.OrderType     `json:"tpOrderType,omitempty"`
+	TpOrderPrice *number             `json:"tpOrderPrice,omitempty"`
+	SlStopType   model.StopType      `json:"slStopType,omitempty"`
+	SlPrice      *number             `json:"slPrice,omitempty"`
+	SlOrderType  model.OrderType     `json:"slOrderType,omitempty"`
+	SlOrderPrice *number             `json:"slOrderPrice,omitempty"`
+}
+
+func newTpSlEventJSON(o *paper.TpSlOrder, event model.TpSlEventType) tpslEventJSON {
+	return tpslEventJSON{
+		Event:        event,
+		PositionID:   o.PositionID,
+		OrderID:      o.ID,
+		Symbol:       o.Symbol,
+		Leverage:     strconv.Itoa(o.Leverage),
+		Side:         o.Side,
+		PositionMode: o.PositionMode,
+		Status:       o.Status,
+		CreateTime:   o.CreateTime,
+		Type:         o.Type,
+		TpQty:        optionalNumber(o.TpQty),
+		SlQty:        optionalNumber(o.SlQty),
+		TpStopType:   o.TP.StopType,
+		TpPrice:      optionalNumber(o.TP.Price),
+		TpOrderType:  o.TP.OrderType,
+		TpOrderPrice: optionalNumber(o.TP.OrderPrice),
+		SlStopType:   o.SL.StopType,
+		SlPrice:      optionalNumber(o.SL.Price),
+		SlOrderType:  o.SL.OrderType,
+		SlOrderPrice: optionalNumber(o.SL.OrderPrice),
+	}
+}
+
+type websocketRequest struct {
+	Op   string            `json:"op"`
+	Ping int64             `json:"ping"`
+	Args []json.RawMessage `json:"args"`
+}
+
+type loginArgs struct {
+	ApiKey    string `json:"apiKey"`
+	Timestamp int64  `json:"timestamp"`
+	Nonce     string `json:"nonce"`
+	Sign      string `json:"sign"`
+}
+
+type subscriptionArgs struct {
+	Symbol model.Symbol `json:"symbol"`
+	Ch     string       `json:"ch"`
+}
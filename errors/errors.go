@@ -39,6 +39,8 @@ var (
 	ErrTriggerPriceInvalid   = errors.New("trigger price invalid")
 	ErrLeadTrading           = errors.New("lead trading error")
 	ErrSubAccountIssue       = errors.New("sub-account issue")
+	ErrOrderNotFilled        = errors.New("order ended without being filled")
+	ErrUnconfirmedOrders     = errors.New("orders not confirmed")
 )
 
 type ValidationError struct {
@@ -113,6 +115,30 @@ func (e *WorkgroupExhaustedError) Is(target error) bool {
 	return target == ErrWorkgroupExhausted
 }
 
+// UnconfirmedOrdersError lists orders that may have been placed although no response confirmed them
+type UnconfirmedOrdersError struct {
+	ClientIDs []string
+	Err       error
+}
+
+func (e *UnconfirmedOrdersError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%d orders not confirmed: %v", len(e.ClientIDs), e.Err)
+	}
+	return fmt.Sprintf("%d orders not confirmed", len(e.ClientIDs))
+}
+
+func (e *UnconfirmedOrdersError) Unwrap() error {
+	if e.Err != nil {
+		return e.Err
+	}
+	return ErrUnconfirmedOrders
+}
+
+func (e *UnconfirmedOrdersError) Is(target error) bool {
+	return target == ErrUnconfirmedOrders
+}
+
 type NetworkError struct {
 	Operation string
 	Message   string
@@ -232,6 +258,30 @@ func (e *InternalError) Is(target error) bool {
 	return target == ErrInternal
 }
 
+type RateLimitError struct {
+	Endpoint string
+	Wait     string
+	Err      error
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Wait != "" {
+		return fmt.Sprintf("rate limit exceeded on %s: next slot in %s", e.Endpoint, e.Wait)
+	}
+	return fmt.Sprintf("rate limit exceeded on %s", e.Endpoint)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	if e.Err != nil {
+		return e.Err
+	}
+	return ErrRateLimitExceeded
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimitExceeded
+}
+
 type TimeoutError struct {
 	Operation string
 	Timeout   string
@@ -313,6 +363,14 @@ func NewTimeoutError(operation, timeout string, err error) error {
 	}
 }
 
+func NewRateLimitError(endpoint, wait string, err error) error {
+	return &RateLimitError{
+		Endpoint: endpoint,
+		Wait:     wait,
+		Err:      err,
+	}
+}
+
 func NewConnectionClosedError(operation, message string, err error) error {
 	return &ConnectionClosedError{
 		Operation: operation,
@@ -328,3 +386,10 @@ func NewWorkgroupExhaustedError(operation, message string, err error) error {
 		Err:       err,
 	}
 }
+
+func NewUnconfirmedOrdersError(clientIDs []string, err error) error {
+	return &UnconfirmedOrdersError{
+		ClientIDs: clientIDs,
+		Err:       err,
+	}
+}
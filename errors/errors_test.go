@@ -215,6 +215,35 @@ func TestTimeoutError(t *testing.T) {
 	}
 }
 
+func TestRateLimitError(t *testing.T) {
+
+	err := NewRateLimitError("/api/endpoint", "250ms", nil)
+
+	expected := "rate limit exceeded on /api/endpoint: next slot in 250ms"
+	if err.Error() != expected {
+		t.Errorf("Wrong error message. Expected '%s', got '%s'", expected, err.Error())
+	}
+
+	if !errors.Is(err, ErrRateLimitExceeded) {
+		t.Error("errors.Is(err, ErrRateLimitExceeded) should be true")
+	}
+
+	originalErr := errors.New("context canceled")
+	wrappedErr := NewRateLimitError("/api/endpoint", "", originalErr)
+
+	if !errors.Is(wrappedErr, originalErr) {
+		t.Error("errors.Is(wrappedErr, originalErr) should be true")
+	}
+
+	if wrappedErr.Error() != "rate limit exceeded on /api/endpoint" {
+		t.Errorf("Wrong error message, got '%s'", wrappedErr.Error())
+	}
+
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Error("Type assertion to *RateLimitError should succeed")
+	}
+}
+
 func TestErrorHierarchy(t *testing.T) {
 
 	originalErr := errors.New("original error")
@@ -241,3 +270,28 @@ func TestErrorHierarchy(t *testing.T) {
 		t.Error("Second unwrap should result in the original error")
 	}
 }
+
+func TestUnconfirmedOrdersError(t *testing.T) {
+	err := NewUnconfirmedOrdersError([]string{"a", "b"}, nil)
+
+	expected := "2 orders not confirmed"
+	if err.Error() != expected {
+		t.Errorf("Wrong error message. Expected '%s', got '%s'", expected, err.Error())
+	}
+
+	if !errors.Is(err, ErrUnconfirmedOrders) {
+		t.Error("errors.Is(err, ErrUnconfirmedOrders) should be true")
+	}
+
+	timeoutErr := NewTimeoutError("POST", "5s", nil)
+	wrappedErr := NewUnconfirmedOrdersError([]string{"a"}, timeoutErr)
+
+	if !errors.Is(wrappedErr, ErrTimeout) || !errors.Is(wrappedErr, ErrUnconfirmedOrders) {
+		t.Error("errors.Is should match both ErrTimeout and ErrUnconfirmedOrders")
+	}
+
+	var unconfirmed *UnconfirmedOrdersError
+	if !errors.As(wrappedErr, &unconfirmed) || len(unconfirmed.ClientIDs) != 1 {
+		t.Error("errors.As to *UnconfirmedOrdersError should expose the client IDs")
+	}
+}
@@ -0,0 +1,165 @@
+package paper
+
+import (
+	"cmp"
+	"strings"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+// Epsilon absorbs float rounding when comparing filled and ordered quantities
+const Epsilon = 1e-12
+
+type Trigger struct {
+	Price      *float64
+	StopType   model.StopType
+	OrderType  model.OrderType
+	OrderPrice *float64
+}
+
+func (t Trigger) IsSet() bool {
+	return t.Price != nil
+}
+
+type Order struct {
+	ID           string
+	ClientID     string
+	Symbol       model.Symbol
+	Side         model.TradeSide
+	TradeSide    model.Side
+	OrderType    model.OrderType
+	Effect       model.TimeInForce
+	PositionID   string
+	ReduceOnly   bool
+	Price        float64
+	Qty          float64
+	TradeQty     float64
+	Fee          float64
+	RealizedPNL  float64
+	Leverage     int
+	MarginMode   model.MarginMode
+	PositionMode model.PositionMode
+	Status       model.OrderStatus
+	TP           Trigger
+	SL           Trigger
+	CreateTime   time.Time
+	ModifyTime   time.Time
+
+	tpslPlaced bool
+}
+
+func (o *Order) Remaining() float64 {
+	return o.Qty - o.TradeQty
+}
+
+// Reduces reports whether the order can only shrink a position
+func (o *Order) Reduces() bool {
+	return o.ReduceOnly || (o.PositionMode == model.PositionModeHedge && o.TradeSide == model.SideClose)
+}
+
+func (o *Order) marketable(last float64) bool {
+	if o.Side == model.TradeSideBuy {
+		return o.Price >= last
+	}
+	return o.Price <= last
+}
+
+// Frozen is the margin reserved for the unfilled part of a resting order
+func (o *Order) Frozen() float64 {
+	if o.Reduces() || o.OrderType != model.OrderTypeLimit {
+		return 0
+	}
+	return o.Remaining() * o.Price / float64(o.Leverage)
+}
+
+type Position struct {
+	ID           string
+	Symbol       model.Symbol
+	Side         model.TradeSide
+	Qty          float64
+	MaxQty       float64
+	EntryPrice   float64
+	RealizedPNL  float64
+	Fee          float64
+	ExtraMargin  float64
+	Leverage     int
+	MarginMode   model.MarginMode
+	PositionMode model.PositionMode
+	CreateTime   time.Time
+	ModifyTime   time.Time
+
+	closedQty   float64
+	closedValue float64
+}
+
+func (p *Position) EntryValue() float64 {
+	return p.Qty * p.EntryPrice
+}
+
+func (p *Position) Margin() float64 {
+	return p.EntryValue()/float64(p.Leverage) + p.ExtraMargin
+}
+
+func (p *Position) pnl(qty, price float64) float64 {
+	if p.Side == model.TradeSideSell {
+		return (p.EntryPrice - price) * qty
+	}
+	return (price - p.EntryPrice) * qty
+}
+
+func (p *Position) UnrealizedPNL(mark float64) float64 {
+	if mark == 0 {
+		return 0
+	}
+	return p.pnl(p.Qty, mark)
+}
+
+// ClosePrice is the average price the position was reduced at
+func (p *Position) ClosePrice() float64 {
+	if p.closedQty == 0 {
+		return 0
+	}
+	return p.closedValue / p.closedQty
+}
+
+type Trade struct {
+	ID          string
+	Order       *Order
+	PositionID  string
+	Qty         float64
+	Price       float64
+	Fee         float64
+	RealizedPNL float64
+	RoleType    model.TradeRoleType
+	CreateTime  time.Time
+}
+
+type TpSlOrder struct {
+	ID           string
+	PositionID   string
+	Symbol       model.Symbol
+	Side         model.TradeSide
+	Leverage     int
+	PositionMode model.PositionMode
+	Type         model.TpSlType
+	TP           Trigger
+	SL           Trigger
+	TpQty        *float64
+	SlQty        *float64
+	Status       model.OrderStatus
+	CreateTime   time.Time
+	TriggerTime  *time.Time
+}
+
+// CompareIDs orders the sequential numeric ids handed out by the engine
+func CompareIDs(a, b string) int {
+	return cmp.Or(cmp.Compare(len(a), len(b)), strings.Compare(a, b))
+}
+
+func opposite(side model.TradeSide) model.TradeSide {
+	if side == model.TradeSideBuy {
+		return model.TradeSideSell
+	}
+	return model.TradeSideBuy
+}
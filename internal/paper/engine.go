@@ -0,0 +1,899 @@
+// Package paper is the matching engine behind bitunix.PaperClient and
+// bitunixtest.Server. It keeps a simulated futures account and reports every
+// change to a Listener; callers serialize access to an Engine themselves.
+package paper
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+	"github.com/tradingiq/bitunix-client/model"
+	"go.uber.org/zap"
+)
+
+// ErrorCodes are the exchange codes of the errors the engine rejects requests with
+var ErrorCodes = map[error]int{
+	errors.ErrParameterError:      10002,
+	errors.ErrInsufficientBalance: 20003,
+	errors.ErrInvalidLeverage:     20005,
+	errors.ErrOpenOrdersExist:     20006,
+	errors.ErrOrderNotFound:       20007,
+	errors.ErrPositionsModeChange: 20009,
+	errors.ErrPositionNotExist:    30004,
+	errors.ErrTPSLOrderError:      30005,
+	errors.ErrDuplicateClientID:   30042,
+}
+
+// Reject builds the error the exchange would answer with
+func Reject(err error, format string, args ...any) error {
+	return errors.NewAPIError(ErrorCodes[err], fmt.Sprintf(format, args...), "", err)
+}
+
+// Listener is told about every change of the account while the engine is in use
+type Listener interface {
+	OrderChanged(o *Order, event model.OrderEventType)
+	PositionChanged(p *Position, event model.PositionEventType)
+	TpSlChanged(t *TpSlOrder, event model.TpSlEventType)
+	BalanceChanged()
+}
+
+type Engine struct {
+	Fees            FeeModel
+	Slippage        SlippageModel
+	DefaultLeverage int
+	Now             func() time.Time
+	Logger          *zap.Logger
+
+	Balance         float64
+	PositionMode    model.PositionMode
+	Leverage        map[model.Symbol]int
+	MarginModes     map[model.Symbol]model.MarginMode
+	Prices          map[model.Symbol]float64
+	Orders          map[string]*Order
+	OrderHistory    []*Order
+	Positions       map[string]*Position
+	PositionHistory []*Position
+	TpSlOrders      map[string]*TpSlOrder
+	TpSlHistory     []*TpSlOrder
+	Trades          []*Trade
+
+	listener Listener
+	lastID   int64
+}
+
+func NewEngine(listener Listener) *Engine {
+	return &Engine{
+		Fees:            RateFees(0, 0),
+		DefaultLeverage: 10,
+		Now:             time.Now,
+		Logger:          zap.NewNop(),
+		Balance:         10000,
+		PositionMode:    model.PositionModeOneWay,
+		Leverage:        make(map[model.Symbol]int),
+		MarginModes:     make(map[model.Symbol]model.MarginMode),
+		Prices:          make(map[model.Symbol]float64),
+		Orders:          make(map[string]*Order),
+		Positions:       make(map[string]*Position),
+		TpSlOrders:      make(map[string]*TpSlOrder),
+		listener:        listener,
+	}
+}
+
+func (e *Engine) nextID() string {
+	e.lastID++
+	return strconv.FormatInt(e.lastID, 10)
+}
+
+func (e *Engine) LeverageOf(symbol model.Symbol) int {
+	if leverage, ok := e.Leverage[symbol]; ok {
+		return leverage
+	}
+	return e.DefaultLeverage
+}
+
+func (e *Engine) MarginModeOf(symbol model.Symbol) model.MarginMode {
+	if mode, ok := e.MarginModes[symbol]; ok {
+		return mode
+	}
+	return model.MarginModeCross
+}
+
+func (e *Engine) Frozen() float64 {
+	var frozen float64
+	for _, o := range e.Orders {
+		frozen += o.Frozen()
+	}
+	return frozen
+}
+
+func (e *Engine) Margin() float64 {
+	var margin float64
+	for _, p := range e.Positions {
+		margin += p.Margin()
+	}
+	return margin
+}
+
+func (e *Engine) Available() float64 {
+	return e.Balance - e.Margin() - e.Frozen()
+}
+
+// Equity is the balance plus the unrealized PNL of the open positions
+func (e *Engine) Equity() float64 {
+	equity := e.Balance
+	for _, p := range e.Positions {
+		equity += p.UnrealizedPNL(e.Prices[p.Symbol])
+	}
+	return equity
+}
+
+// Balances splits the account by margin mode
+type Balances struct {
+	Available              float64
+	Frozen                 float64
+	CrossFrozen            float64
+	IsolationFrozen        float64
+	Margin                 float64
+	CrossMargin            float64
+	IsolationMargin        float64
+	CrossUnrealizedPNL     float64
+	IsolationUnrealizedPNL float64
+}
+
+func (e *Engine) Balances() Balances {
+	var b Balances
+	for _, p := range e.Positions {
+		if p.MarginMode == model.MarginModeIsolation {
+			b.IsolationMargin += p.Margin()
+			b.IsolationUnrealizedPNL += p.UnrealizedPNL(e.Prices[p.Symbol])
+		} else {
+			b.CrossMargin += p.Margin()
+			b.CrossUnrealizedPNL += p.UnrealizedPNL(e.Prices[p.Symbol])
+		}
+	}
+	for _, o := range e.Orders {
+		if o.MarginMode == model.MarginModeIsolation {
+			b.IsolationFrozen += o.Frozen()
+		} else {
+			b.CrossFrozen += o.Frozen()
+		}
+	}
+
+	b.Available = e.Available()
+	b.Frozen = b.CrossFrozen + b.IsolationFrozen
+	b.Margin = b.CrossMargin + b.IsolationMargin
+	return b
+}
+
+// FindOrder finds an open order by id, or by client id without one
+func (e *Engine) FindOrder(orderID, clientID string) *Order {
+	if orderID != "" {
+		return e.Orders[orderID]
+	}
+
+	for _, o := range e.Orders {
+		if clientID != "" && o.ClientID == clientID {
+			return o
+		}
+	}
+	return nil
+}
+
+// LookupOrder finds open and finished orders
+func (e *Engine) LookupOrder(orderID, clientID string) *Order {
+	if o := e.FindOrder(orderID, clientID); o != nil {
+		return o
+	}
+
+	for _, o := range e.OrderHistory {
+		if (orderID != "" && o.ID == orderID) || (orderID == "" && clientID != "" && o.ClientID == clientID) {
+			return o
+		}
+	}
+	return nil
+}
+
+// OpenOrders returns the open orders from newest to oldest
+func (e *Engine) OpenOrders() []*Order {
+	return NewestFirst(slices.Collect(maps.Values(e.Orders)), func(o *Order) string { return o.ID })
+}
+
+func (e *Engine) sortedOrders(symbol model.Symbol) []*Order {
+	var orders []*Order
+	for _, o := range e.Orders {
+		if o.Symbol == symbol {
+			orders = append(orders, o)
+		}
+	}
+	slices.SortFunc(orders, func(a, b *Order) int { return CompareIDs(a.ID, b.ID) })
+	return orders
+}
+
+func (e *Engine) PositionOf(symbol model.Symbol, side model.TradeSide) *Position {
+	for _, p := range e.Positions {
+		if p.Symbol == symbol && p.Side == side {
+			return p
+		}
+	}
+	return nil
+}
+
+// SortedPositions returns the open positions from oldest to newest
+func (e *Engine) SortedPositions() []*Position {
+	positions := slices.Collect(maps.Values(e.Positions))
+	slices.SortFunc(positions, func(a, b *Position) int { return CompareIDs(a.ID, b.ID) })
+	return positions
+}
+
+// closingPosition is the position an order reduces. In hedge mode positions are
+// closed with the side they were opened with, in one-way mode with the opposite side.
+func (e *Engine) closingPosition(o *Order) *Position {
+	if o.PositionMode == model.PositionModeHedge {
+		if o.TradeSide != model.SideClose {
+			return nil
+		}
+		if o.PositionID != "" {
+			if p, ok := e.Positions[o.PositionID]; ok && p.Symbol == o.Symbol && p.Side == o.Side {
+				return p
+			}
+			return nil
+		}
+		return e.PositionOf(o.Symbol, o.Side)
+	}
+
+	return e.PositionOf(o.Symbol, opposite(o.Side))
+}
+
+// newOrder validates request and builds the order without placing it
+func (e *Engine) newOrder(request *model.OrderRequest) (*Order, error) {
+	symbol := request.Symbol.Normalize()
+	side := request.TradeSide.Normalize()
+	tradeSide := request.Side.Normalize()
+	orderType := request.OrderType.Normalize()
+	effect := request.Effect.Normalize()
+	if tradeSide == "" {
+		tradeSide = model.SideOpen
+	}
+	if effect == "" {
+		effect = model.TimeInForceGTC
+	}
+
+	qty := request.Qty
+	if request.QtyDecimal != nil {
+		qty = request.QtyDecimal.Float64()
+	}
+	var price float64
+	if request.Price != nil {
+		price = *request.Price
+	}
+	if request.PriceDecimal != nil {
+		price = request.PriceDecimal.Float64()
+	}
+
+	switch {
+	case symbol == "":
+		return nil, Reject(errors.ErrParameterError, "symbol is required")
+	case !side.IsValid():
+		return nil, Reject(errors.ErrParameterError, "invalid side %s", request.TradeSide)
+	case !tradeSide.IsValid():
+		return nil, Reject(errors.ErrParameterError, "invalid trade side %s", request.Side)
+	case !orderType.IsValid():
+		return nil, Reject(errors.ErrParameterError, "invalid order type %s", request.OrderType)
+	case !effect.IsValid():
+		return nil, Reject(errors.ErrParameterError, "invalid effect %s", request.Effect)
+	case qty <= 0:
+		return nil, Reject(errors.ErrParameterError, "qty must be positive")
+	case orderType == model.OrderTypeLimit && price <= 0:
+		return nil, Reject(errors.ErrParameterError, "price is required for limit orders")
+	}
+
+	if request.ClientID != "" && e.LookupOrder("", request.ClientID) != nil {
+		return nil, Reject(errors.ErrDuplicateClientID, "client id %s already used", request.ClientID)
+	}
+
+	last, hasPrice := e.Prices[symbol]
+	if orderType == model.OrderTypeMarket {
+		if !hasPrice {
+			return nil, Reject(errors.ErrParameterError, "no price for %s, feed one before placing market orders", symbol)
+		}
+		price = 0
+	}
+
+	now := e.Now()
+	o := &Order{
+		ID:           e.nextID(),
+		ClientID:     request.ClientID,
+		Symbol:       symbol,
+		Side:         side,
+		TradeSide:    tradeSide,
+		OrderType:    orderType,
+		Effect:       effect,
+		PositionID:   request.PositionID,
+		ReduceOnly:   request.ReduceOnly,
+		Price:        price,
+		Qty:          qty,
+		Leverage:     e.LeverageOf(symbol),
+		MarginMode:   e.MarginModeOf(symbol),
+		PositionMode: e.PositionMode,
+		Status:       model.OrderStatusNew,
+		TP:           Trigger{Price: request.TpPrice, StopType: request.TpStopType, OrderType: request.TpOrderType, OrderPrice: request.TpOrderPrice},
+		SL:           Trigger{Price: request.SlPrice, StopType: request.SlStopType, OrderType: request.SlOrderType, OrderPrice: request.SlOrderPrice},
+		CreateTime:   now,
+		ModifyTime:   now,
+	}
+
+	if o.PositionMode == model.PositionModeHedge && o.TradeSide == model.SideClose && e.closingPosition(o) == nil {
+		return nil, Reject(errors.ErrPositionNotExist, "no %s position of %s to close", o.Side, symbol)
+	}
+
+	if !o.Reduces() {
+		// In one-way mode an opposite order closes the position first, only the rest opens
+		opening := qty
+		if p := e.closingPosition(o); p != nil {
+			opening = max(0, qty-p.Qty)
+		}
+		cost := opening * cmp.Or(price, last) / float64(o.Leverage)
+		if cost > e.Available()+Epsilon {
+			return nil, Reject(errors.ErrInsufficientBalance, "order needs %v margin, %v available", cost, e.Available())
+		}
+	}
+
+	return o, nil
+}
+
+// PlaceOrder fills an order that crosses the last price and lets other limit orders rest
+func (e *Engine) PlaceOrder(request *model.OrderRequest) (*Order, error) {
+	o, err := e.newOrder(request)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Orders[o.ID] = o
+	e.listener.OrderChanged(o, model.OrderEventCreate)
+	e.execute(o)
+
+	return o, nil
+}
+
+func (e *Engine) execute(o *Order) {
+	last, hasPrice := e.Prices[o.Symbol]
+
+	switch {
+	case o.OrderType == model.OrderTypeMarket:
+		e.Fill(o, o.Remaining(), last, model.TradeRoleTypeTaker)
+	case hasPrice && o.marketable(last):
+		if o.Effect == model.TimeInForcePostOnly {
+			e.CancelOrder(o)
+			return
+		}
+		e.Fill(o, o.Remaining(), last, model.TradeRoleTypeTaker)
+	case o.Effect == model.TimeInForceIOC || o.Effect == model.TimeInForceFOK:
+		e.CancelOrder(o)
+	default:
+		e.listener.BalanceChanged()
+	}
+}
+
+// ModifyOrder changes an open order, a limit price is kept when price is zero
+func (e *Engine) ModifyOrder(o *Order, qty, price float64, tp, sl Trigger) error {
+	if qty <= 0 || qty < o.TradeQty {
+		return Reject(errors.ErrParameterError, "qty must exceed the traded qty %v", o.TradeQty)
+	}
+
+	previous := *o
+	o.Qty = qty
+	if o.OrderType == model.OrderTypeLimit && price > 0 {
+		o.Price = price
+	}
+	o.TP, o.SL = tp, sl
+	if o.Frozen()-previous.Frozen() > e.Available()+Epsilon {
+		*o = previous
+		return Reject(errors.ErrInsufficientBalance, "Insufficient balance")
+	}
+	o.ModifyTime = e.Now()
+
+	e.listener.OrderChanged(o, model.OrderEventUpdate)
+	e.execute(o)
+	return nil
+}
+
+func (e *Engine) finishOrder(o *Order) {
+	delete(e.Orders, o.ID)
+	e.OrderHistory = append(e.OrderHistory, o)
+}
+
+func (e *Engine) CancelOrder(o *Order) {
+	o.Status = model.OrderStatusCanceled
+	o.ModifyTime = e.Now()
+	e.finishOrder(o)
+
+	e.listener.OrderChanged(o, model.OrderEventClose)
+	e.listener.BalanceChanged()
+}
+
+// Fill executes qty of an open order at price and applies it to the positions
+func (e *Engine) Fill(o *Order, qty, price float64, role model.TradeRoleType) {
+	if o.Reduces() {
+		p := e.closingPosition(o)
+		if p == nil {
+			e.CancelOrder(o)
+			return
+		}
+		qty = min(qty, p.Qty)
+	}
+
+	if role == model.TradeRoleTypeTaker && e.Slippage != nil {
+		price = e.slip(o, qty, price)
+	}
+	fee := e.Fees.Fee(o.Symbol, role, qty, price)
+	pnl, positionID := e.applyFill(o, qty, price, fee)
+
+	if o.OrderType == model.OrderTypeMarket {
+		o.Price = (o.Price*o.TradeQty + price*qty) / (o.TradeQty + qty)
+	}
+	o.TradeQty += qty
+	o.Fee += fee
+	o.RealizedPNL += pnl
+	o.ModifyTime = e.Now()
+	e.Balance += pnl - fee
+
+	e.Trades = append(e.Trades, &Trade{
+		ID:          e.nextID(),
+		Order:       o,
+		PositionID:  positionID,
+		Qty:         qty,
+		Price:       price,
+		Fee:         fee,
+		RealizedPNL: pnl,
+		RoleType:    role,
+		CreateTime:  o.ModifyTime,
+	})
+
+	switch {
+	case o.Remaining() <= Epsilon:
+		o.Status = model.OrderStatusFilled
+		e.finishOrder(o)
+		e.listener.OrderChanged(o, model.OrderEventClose)
+	case o.Reduces() && e.closingPosition(o) == nil:
+		// The position is gone, the rest of a reducing order can never fill
+		e.CancelOrder(o)
+	default:
+		o.Status = model.OrderStatusPartFilled
+		e.listener.OrderChanged(o, model.OrderEventUpdate)
+	}
+
+	e.placeAttachedTpSl(o, positionID)
+	e.listener.BalanceChanged()
+}
+
+// slip moves a taker fill against the order, limit orders never fill beyond their price
+func (e *Engine) slip(o *Order, qty, price float64) float64 {
+	slipped := e.Slippage.Slip(o.Symbol, o.Side, qty, price)
+	if o.OrderType != model.OrderTypeLimit {
+		return slipped
+	}
+
+	if o.Side == model.TradeSideBuy {
+		return min(slipped, o.Price)
+	}
+	return max(slipped, o.Price)
+}
+
+// applyFill reduces the position the order closes and opens or increases one
+// with the rest. It returns the realized PNL and the position that was touched last.
+func (e *Engine) applyFill(o *Order, qty, price, fee float64) (float64, string) {
+	var pnl float64
+	var positionID string
+	total := qty
+
+	if p := e.closingPosition(o); p != nil {
+		closed := min(qty, p.Qty)
+		pnl = p.pnl(closed, price)
+		positionID = p.ID
+		qty -= closed
+		e.reducePosition(p, closed, price, pnl, fee*closed/total)
+	}
+
+	if qty > Epsilon && !o.Reduces() {
+		positionID = e.increasePosition(o, qty, price, fee*qty/total).ID
+	}
+
+	return pnl, positionID
+}
+
+func (e *Engine) increasePosition(o *Order, qty, price, fee float64) *Position {
+	now := e.Now()
+	p := e.PositionOf(o.Symbol, o.Side)
+	if p == nil {
+		p = &Position{
+			ID:           e.nextID(),
+			Symbol:       o.Symbol,
+			Side:         o.Side,
+			EntryPrice:   price,
+			Leverage:     o.Leverage,
+			MarginMode:   o.MarginMode,
+			PositionMode: o.PositionMode,
+			CreateTime:   now,
+		}
+		e.Positions[p.ID] = p
+	}
+
+	event := model.PositionEventUpdate
+	if p.Qty == 0 {
+		event = model.PositionEventOpen
+	}
+
+	p.EntryPrice = (p.EntryValue() + qty*price) / (p.Qty + qty)
+	p.Qty += qty
+	p.MaxQty = max(p.MaxQty, p.Qty)
+	p.Fee += fee
+	p.ModifyTime = now
+
+	e.listener.PositionChanged(p, event)
+	return p
+}
+
+func (e *Engine) reducePosition(p *Position, qty, price, pnl, fee float64) {
+	p.Qty -= qty
+	p.closedQty += qty
+	p.closedValue += qty * price
+	p.RealizedPNL += pnl
+	p.Fee += fee
+	p.ModifyTime = e.Now()
+
+	if p.Qty > Epsilon {
+		e.listener.PositionChanged(p, model.PositionEventUpdate)
+		return
+	}
+
+	p.Qty = 0
+	delete(e.Positions, p.ID)
+	e.PositionHistory = append(e.PositionHistory, p)
+	e.listener.PositionChanged(p, model.PositionEventClose)
+
+	for _, t := range e.sortedTpSlOrders(p.Symbol) {
+		if t.PositionID == p.ID {
+			e.finishTpSl(t, model.OrderStatusCanceled)
+		}
+	}
+}
+
+// closeRequest builds the reduce-only market order that closes qty of a position
+func closeRequest(p *Position, qty float64) *model.OrderRequest {
+	request := &model.OrderRequest{
+		Symbol:     p.Symbol,
+		TradeSide:  opposite(p.Side),
+		Side:       model.SideClose,
+		OrderType:  model.OrderTypeMarket,
+		Qty:        qty,
+		PositionID: p.ID,
+		ReduceOnly: true,
+	}
+	if p.PositionMode == model.PositionModeHedge {
+		request.TradeSide = p.Side
+	}
+	return request
+}
+
+// ClosePosition closes a position at the last price with a market order
+func (e *Engine) ClosePosition(p *Position) (*Order, error) {
+	return e.PlaceOrder(closeRequest(p, p.Qty))
+}
+
+// AdjustMargin adds to or, with a negative amount, removes margin from a position.
+// Without a position id the position is looked up by symbol and side.
+func (e *Engine) AdjustMargin(positionID string, symbol model.Symbol, side model.PositionSide, amount float64) (*Position, error) {
+	p, ok := e.Positions[positionID]
+	if !ok && positionID == "" {
+		tradeSide := model.TradeSideBuy
+		if side.Normalize() == model.PositionSideShort {
+			tradeSide = model.TradeSideSell
+		}
+		p = e.PositionOf(symbol.Normalize(), tradeSide)
+		ok = p != nil
+	}
+	if !ok {
+		return nil, Reject(errors.ErrPositionNotExist, "Position not exist")
+	}
+
+	if amount > e.Available() || p.ExtraMargin+amount < 0 {
+		return nil, Reject(errors.ErrInsufficientBalance, "Insufficient balance")
+	}
+	p.ExtraMargin += amount
+
+	e.listener.PositionChanged(p, model.PositionEventUpdate)
+	e.listener.BalanceChanged()
+	return p, nil
+}
+
+func (e *Engine) ChangeLeverage(symbol model.Symbol, leverage int) error {
+	if leverage < 1 || leverage > 125 {
+		return Reject(errors.ErrInvalidLeverage, "Invalid leverage %d", leverage)
+	}
+
+	e.Leverage[symbol.Normalize()] = leverage
+	return nil
+}
+
+func (e *Engine) ChangeMarginMode(symbol model.Symbol, mode model.MarginMode) error {
+	if !mode.Normalize().IsValid() {
+		return Reject(errors.ErrParameterError, "invalid margin mode %s", mode)
+	}
+
+	symbol = symbol.Normalize()
+	for _, o := range e.Orders {
+		if o.Symbol == symbol {
+			return Reject(errors.ErrOpenOrdersExist, "There are open orders")
+		}
+	}
+	e.MarginModes[symbol] = mode.Normalize()
+	return nil
+}
+
+func (e *Engine) ChangePositionMode(mode model.PositionMode) error {
+	if !mode.Normalize().IsValid() {
+		return Reject(errors.ErrParameterError, "invalid position mode %s", mode)
+	}
+
+	if len(e.Positions) > 0 || len(e.Orders) > 0 {
+		return Reject(errors.ErrPositionsModeChange, "Position mode cannot be changed with open positions or orders")
+	}
+	e.PositionMode = mode.Normalize()
+	return nil
+}
+
+func (e *Engine) placeAttachedTpSl(o *Order, positionID string) {
+	if o.tpslPlaced || o.Reduces() || (!o.TP.IsSet() && !o.SL.IsSet()) {
+		return
+	}
+
+	p, ok := e.Positions[positionID]
+	if !ok {
+		return
+	}
+
+	o.tpslPlaced = true
+	t := e.newTpSlOrder(p, model.TPSLTypePartial, o.TP, o.SL)
+	qty := o.Qty
+	if o.TP.IsSet() {
+		t.TpQty = &qty
+	}
+	if o.SL.IsSet() {
+		t.SlQty = &qty
+	}
+	e.listener.TpSlChanged(t, model.TPSLEventCreate)
+}
+
+func (e *Engine) newTpSlOrder(p *Position, tpslType model.TpSlType, tp, sl Trigger) *TpSlOrder {
+	t := &TpSlOrder{
+		ID:           e.nextID(),
+		PositionID:   p.ID,
+		Symbol:       p.Symbol,
+		Side:         p.Side,
+		Leverage:     p.Leverage,
+		PositionMode: p.PositionMode,
+		Type:         tpslType,
+		TP:           tp,
+		SL:           sl,
+		Status:       model.OrderStatusNew,
+		CreateTime:   e.Now(),
+	}
+	e.TpSlOrders[t.ID] = t
+	return t
+}
+
+// PlaceTpSl places a tp/sl order that closes tpQty or slQty of a position, all of it when they are nil
+func (e *Engine) PlaceTpSl(positionID string, tp, sl Trigger, tpQty, slQty *float64) (*TpSlOrder, error) {
+	p, ok := e.Positions[positionID]
+	if !ok {
+		return nil, Reject(errors.ErrPositionNotExist, "Position not exist")
+	}
+	if !tp.IsSet() && !sl.IsSet() {
+		return nil, Reject(errors.ErrTPSLOrderError, "tpPrice or slPrice is required")
+	}
+
+	t := e.newTpSlOrder(p, model.TPSLTypePartial, tp, sl)
+	t.TpQty, t.SlQty = tpQty, slQty
+	e.listener.TpSlChanged(t, model.TPSLEventCreate)
+	return t, nil
+}
+
+func (e *Engine) ModifyTpSl(orderID string, tp, sl Trigger, tpQty, slQty *float64) error {
+	t, ok := e.TpSlOrders[orderID]
+	if !ok {
+		return Reject(errors.ErrOrderNotFound, "Order not found")
+	}
+
+	t.TP, t.SL = tp, sl
+	t.TpQty, t.SlQty = tpQty, slQty
+	e.listener.TpSlChanged(t, model.TPSLEventUpdate)
+	return nil
+}
+
+func (e *Engine) CancelTpSl(orderID string) error {
+	t, ok := e.TpSlOrders[orderID]
+	if !ok {
+		return Reject(errors.ErrOrderNotFound, "Order not found")
+	}
+
+	e.finishTpSl(t, model.OrderStatusCanceled)
+	return nil
+}
+
+func (e *Engine) positionTpSl(positionID string) *TpSlOrder {
+	for _, t := range e.TpSlOrders {
+		if t.PositionID == positionID && t.Type == model.TPSLTypeFull {
+			return t
+		}
+	}
+	return nil
+}
+
+// PlacePositionTpSl replaces the tp/sl of the whole position if there is one already.
+// Position tp/sl orders close the position with a market order.
+func (e *Engine) PlacePositionTpSl(positionID string, tp, sl Trigger) (*TpSlOrder, error) {
+	p, ok := e.Positions[positionID]
+	if !ok {
+		return nil, Reject(errors.ErrPositionNotExist, "Position not exist")
+	}
+	if !tp.IsSet() && !sl.IsSet() {
+		return nil, Reject(errors.ErrTPSLOrderError, "tpPrice or slPrice is required")
+	}
+
+	if t := e.positionTpSl(p.ID); t != nil {
+		return t, e.ModifyPositionTpSl(p.ID, tp, sl)
+	}
+
+	tp.OrderType, tp.OrderPrice = model.OrderTypeMarket, nil
+	sl.OrderType, sl.OrderPrice = model.OrderTypeMarket, nil
+	t := e.newTpSlOrder(p, model.TPSLTypeFull, tp, sl)
+	e.listener.TpSlChanged(t, model.TPSLEventCreate)
+	return t, nil
+}
+
+func (e *Engine) ModifyPositionTpSl(positionID string, tp, sl Trigger) error {
+	t := e.positionTpSl(positionID)
+	if t == nil {
+		return Reject(errors.ErrOrderNotFound, "Order not found")
+	}
+
+	tp.OrderType, tp.OrderPrice = model.OrderTypeMarket, nil
+	sl.OrderType, sl.OrderPrice = model.OrderTypeMarket, nil
+	t.TP, t.SL = tp, sl
+	e.listener.TpSlChanged(t, model.TPSLEventUpdate)
+	return nil
+}
+
+// PositionTpSl is the tp/sl order of the whole position
+func (e *Engine) PositionTpSl(positionID string) (*TpSlOrder, bool) {
+	t := e.positionTpSl(positionID)
+	return t, t != nil
+}
+
+func (e *Engine) finishTpSl(t *TpSlOrder, status model.OrderStatus) {
+	t.Status = status
+	delete(e.TpSlOrders, t.ID)
+	e.TpSlHistory = append(e.TpSlHistory, t)
+	e.listener.TpSlChanged(t, model.TPSLEventClose)
+}
+
+func (e *Engine) sortedTpSlOrders(symbol model.Symbol) []*TpSlOrder {
+	var orders []*TpSlOrder
+	for _, t := range e.TpSlOrders {
+		if t.Symbol == symbol {
+			orders = append(orders, t)
+		}
+	}
+	slices.SortFunc(orders, func(a, b *TpSlOrder) int { return CompareIDs(a.ID, b.ID) })
+	return orders
+}
+
+// Move records a price update of symbol. The price went through low and high,
+// resting limit orders within that range fill at their limit price and tp/sl
+// orders whose trigger price was crossed close their position.
+func (e *Engine) Move(symbol model.Symbol, low, high, last float64) {
+	symbol = symbol.Normalize()
+	if previous, ok := e.Prices[symbol]; ok {
+		low = min(low, previous)
+		high = max(high, previous)
+	}
+	e.Prices[symbol] = last
+
+	for _, o := range e.sortedOrders(symbol) {
+		if e.Orders[o.ID] != o || o.OrderType != model.OrderTypeLimit {
+			continue
+		}
+
+		if (o.Side == model.TradeSideBuy && low <= o.Price) || (o.Side == model.TradeSideSell && high >= o.Price) {
+			e.Fill(o, o.Remaining(), o.Price, model.TradeRoleTypeMaker)
+		}
+	}
+
+	for _, t := range e.sortedTpSlOrders(symbol) {
+		if e.TpSlOrders[t.ID] != t {
+			continue
+		}
+		e.checkTrigger(t, low, high)
+	}
+}
+
+// checkTrigger fires the stop loss before the take profit when a move crossed both
+func (e *Engine) checkTrigger(t *TpSlOrder, low, high float64) {
+	p, ok := e.Positions[t.PositionID]
+	if !ok {
+		e.finishTpSl(t, model.OrderStatusCanceled)
+		return
+	}
+
+	long := p.Side == model.TradeSideBuy
+	slHit := t.SL.IsSet() && ((long && low <= *t.SL.Price) || (!long && high >= *t.SL.Price))
+	tpHit := t.TP.IsSet() && ((long && high >= *t.TP.Price) || (!long && low <= *t.TP.Price))
+
+	switch {
+	case slHit:
+		e.trigger(t, p, t.SL, t.SlQty)
+	case tpHit:
+		e.trigger(t, p, t.TP, t.TpQty)
+	}
+}
+
+func (e *Engine) trigger(t *TpSlOrder, p *Position, trigger Trigger, qty *float64) {
+	now := e.Now()
+	t.TriggerTime = &now
+	e.finishTpSl(t, model.OrderStatusFilled)
+
+	amount := p.Qty
+	if qty != nil {
+		amount = min(amount, *qty)
+	}
+
+	request := closeRequest(p, amount)
+	if trigger.OrderType.Normalize() == model.OrderTypeLimit && trigger.OrderPrice != nil {
+		request.OrderType = model.OrderTypeLimit
+		request.Price = trigger.OrderPrice
+	}
+
+	o, err := e.newOrder(request)
+	if err != nil {
+		e.Logger.Warn("failed to place tp/sl order", zap.String("tpslOrderId", t.ID), zap.Error(err))
+		return
+	}
+
+	e.Orders[o.ID] = o
+	e.listener.OrderChanged(o, model.OrderEventCreate)
+	if o.OrderType == model.OrderTypeMarket {
+		e.Fill(o, o.Qty, triggeredPrice(o.Side, *trigger.Price, e.Prices[o.Symbol]), model.TradeRoleTypeTaker)
+	} else {
+		e.execute(o)
+	}
+}
+
+// triggeredPrice is the market price a triggered market order fills at. The fed
+// price after the move is taken when it gapped through the trigger price, a
+// move that came back after crossing it fills no better than the trigger price.
+func triggeredPrice(side model.TradeSide, trigger, last float64) float64 {
+	if side == model.TradeSideBuy {
+		return max(trigger, last)
+	}
+	return min(trigger, last)
+}
+
+// NewestFirst sorts entries by their sequential id, the newest first
+func NewestFirst[T any](entries []T, id func(T) string) []T {
+	sorted := slices.Clone(entries)
+	slices.SortFunc(sorted, func(a, b T) int { return CompareIDs(id(b), id(a)) })
+	return sorted
+}
+
+// InRange reports whether t lies within the optional start and end times
+func InRange(t time.Time, start, end *time.Time) bool {
+	if start != nil && t.Before(*start) {
+		return false
+	}
+	return end == nil || !t.After(*end)
+}
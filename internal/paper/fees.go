@@ -0,0 +1,57 @@
+package paper
+
+import (
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+// FeeModel returns the fee charged for filling qty at price
+type FeeModel interface {
+	Fee(symbol model.Symbol, role model.TradeRoleType, qty, price float64) float64
+}
+
+type FeeModelFunc func(symbol model.Symbol, role model.TradeRoleType, qty, price float64) float64
+
+func (f FeeModelFunc) Fee(symbol model.Symbol, role model.TradeRoleType, qty, price float64) float64 {
+	return f(symbol, role, qty, price)
+}
+
+// RateFees charges a share of the filled value, maker for resting limit orders and taker otherwise
+func RateFees(maker, taker float64) FeeModel {
+	return FeeModelFunc(func(_ model.Symbol, role model.TradeRoleType, qty, price float64) float64 {
+		if role == model.TradeRoleTypeMaker {
+			return qty * price * maker
+		}
+		return qty * price * taker
+	})
+}
+
+// SlippageModel returns the price a taker fill of qty on side executes at when the market is at price
+type SlippageModel interface {
+	Slip(symbol model.Symbol, side model.TradeSide, qty, price float64) float64
+}
+
+type SlippageModelFunc func(symbol model.Symbol, side model.TradeSide, qty, price float64) float64
+
+func (f SlippageModelFunc) Slip(symbol model.Symbol, side model.TradeSide, qty, price float64) float64 {
+	return f(symbol, side, qty, price)
+}
+
+// FixedSlippage fills buys amount above and sells amount below the market
+func FixedSlippage(amount float64) SlippageModel {
+	return SlippageModelFunc(func(_ model.Symbol, side model.TradeSide, _, price float64) float64 {
+		if side == model.TradeSideBuy {
+			return price + amount
+		}
+		return price - amount
+	})
+}
+
+// RateSlippage moves fills against the taker by a share of the price, 0.0005 is 5 basis points
+func RateSlippage(rate float64) SlippageModel {
+	return SlippageModelFunc(func(_ model.Symbol, side model.TradeSide, _, price float64) float64 {
+		if side == model.TradeSideBuy {
+			return price * (1 + rate)
+		}
+		return price * (1 - rate)
+	})
+}
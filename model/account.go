@@ -15,16 +15,27 @@ type AccountBalanceResponse struct {
 	Data *AccountBalanceEntry `json:"data"`
 }
 
+type AccountBalanceEntryDecimals struct {
+	Available              Decimal
+	Frozen                 Decimal
+	Margin                 Decimal
+	Transfer               Decimal
+	CrossUnrealizedPNL     Decimal
+	IsolationUnrealizedPNL Decimal
+	Bonus                  Decimal
+}
+
 type AccountBalanceEntry struct {
-	MarginCoin             MarginCoin   `json:"-"`
-	Available              float64      `json:"-"`
-	Frozen                 float64      `json:"-"`
-	Margin                 float64      `json:"-"`
-	Transfer               float64      `json:"-"`
-	PositionMode           PositionMode `json:"-"`
-	CrossUnrealizedPNL     float64      `json:"-"`
-	IsolationUnrealizedPNL float64      `json:"-"`
-	Bonus                  float64      `json:"-"`
+	MarginCoin             MarginCoin                  `json:"-"`
+	Available              float64                     `json:"-"`
+	Frozen                 float64                     `json:"-"`
+	Margin                 float64                     `json:"-"`
+	Transfer               float64                     `json:"-"`
+	PositionMode           PositionMode                `json:"-"`
+	CrossUnrealizedPNL     float64                     `json:"-"`
+	IsolationUnrealizedPNL float64                     `json:"-"`
+	Bonus                  float64                     `json:"-"`
+	Exact                  AccountBalanceEntryDecimals `json:"-"`
 }
 
 func (a *AccountBalanceEntry) UnmarshalJSON(data []byte) error {
@@ -119,5 +130,173 @@ func (a *AccountBalanceEntry) UnmarshalJSON(data []byte) error {
 
 	a.MarginCoin = ParseMarginCoin(aux.MarginCoin)
 
+	return parseDecimalFields(
+		decimalField{"available", aux.Available, &a.Exact.Available},
+		decimalField{"frozen", aux.Frozen, &a.Exact.Frozen},
+		decimalField{"margin", aux.Margin, &a.Exact.Margin},
+		decimalField{"transfer", aux.Transfer, &a.Exact.Transfer},
+		decimalField{"crossUnrealizedPNL", aux.CrossUnrealizedPNL, &a.Exact.CrossUnrealizedPNL},
+		decimalField{"isolationUnrealizedPNL", aux.IsolationUnrealizedPNL, &a.Exact.IsolationUnrealizedPNL},
+		decimalField{"bonus", aux.Bonus, &a.Exact.Bonus},
+	)
+}
+
+type ChangeLeverageRequest struct {
+	Symbol     Symbol     `json:"symbol"`
+	MarginCoin MarginCoin `json:"marginCoin"`
+	Leverage   int        `json:"leverage"`
+}
+
+type ChangeLeverageResponse struct {
+	BaseResponse
+	Data []LeverageEntry `json:"data"`
+}
+
+type LeverageEntry struct {
+	Symbol     Symbol     `json:"-"`
+	MarginCoin MarginCoin `json:"-"`
+	Leverage   int        `json:"leverage"`
+}
+
+func (l *LeverageEntry) UnmarshalJSON(data []byte) error {
+	type Alias LeverageEntry
+	aux := &struct {
+		Symbol     string `json:"symbol"`
+		MarginCoin string `json:"marginCoin"`
+		*Alias
+	}{
+		Alias: (*Alias)(l),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	l.Symbol = ParseSymbol(aux.Symbol)
+	l.MarginCoin = ParseMarginCoin(aux.MarginCoin)
+
+	return nil
+}
+
+type ChangeMarginModeRequest struct {
+	Symbol     Symbol     `json:"symbol"`
+	MarginCoin MarginCoin `json:"marginCoin"`
+	MarginMode MarginMode `json:"marginMode"`
+}
+
+type ChangeMarginModeResponse struct {
+	BaseResponse
+	Data []MarginModeEntry `json:"data"`
+}
+
+type MarginModeEntry struct {
+	Symbol     Symbol     `json:"-"`
+	MarginCoin MarginCoin `json:"-"`
+	MarginMode MarginMode `json:"-"`
+}
+
+func (m *MarginModeEntry) UnmarshalJSON(data []byte) error {
+	type Alias MarginModeEntry
+	aux := &struct {
+		Symbol     string `json:"symbol"`
+		MarginCoin string `json:"marginCoin"`
+		MarginMode string `json:"marginMode"`
+		*Alias
+	}{
+		Alias: (*Alias)(m),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	marginMode, err := ParseMarginMode(aux.MarginMode)
+	if err != nil {
+		return fmt.Errorf("invalid margin mode: %w", err)
+	}
+	m.MarginMode = marginMode
+
+	m.Symbol = ParseSymbol(aux.Symbol)
+	m.MarginCoin = ParseMarginCoin(aux.MarginCoin)
+
+	return nil
+}
+
+type LeverageAndMarginModeParams struct {
+	Symbol     Symbol
+	MarginCoin MarginCoin
+}
+
+type LeverageAndMarginModeResponse struct {
+	BaseResponse
+	Data *LeverageAndMarginMode `json:"data"`
+}
+
+type LeverageAndMarginMode struct {
+	Symbol     Symbol     `json:"-"`
+	MarginCoin MarginCoin `json:"-"`
+	Leverage   int        `json:"leverage"`
+	MarginMode MarginMode `json:"-"`
+}
+
+func (l *LeverageAndMarginMode) UnmarshalJSON(data []byte) error {
+	type Alias LeverageAndMarginMode
+	aux := &struct {
+		Symbol     string `json:"symbol"`
+		MarginCoin string `json:"marginCoin"`
+		MarginMode string `json:"marginMode"`
+		*Alias
+	}{
+		Alias: (*Alias)(l),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	marginMode, err := ParseMarginMode(aux.MarginMode)
+	if err != nil {
+		return fmt.Errorf("invalid margin mode: %w", err)
+	}
+	l.MarginMode = marginMode
+
+	l.Symbol = ParseSymbol(aux.Symbol)
+	l.MarginCoin = ParseMarginCoin(aux.MarginCoin)
+
+	return nil
+}
+
+type ChangePositionModeRequest struct {
+	PositionMode PositionMode `json:"positionMode"`
+}
+
+type ChangePositionModeResponse struct {
+	BaseResponse
+	Data []PositionModeEntry `json:"data"`
+}
+
+type PositionModeEntry struct {
+	PositionMode PositionMode `json:"-"`
+}
+
+func (p *PositionModeEntry) UnmarshalJSON(data []byte) error {
+	type Alias PositionModeEntry
+	aux := &struct {
+		PositionMode string `json:"positionMode"`
+		*Alias
+	}{
+		Alias: (*Alias)(p),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	positionMode, err := ParsePositionMode(aux.PositionMode)
+	if err != nil {
+		return fmt.Errorf("invalid position mode: %w", err)
+	}
+	p.PositionMode = positionMode
+
 	return nil
 }
@@ -78,3 +78,53 @@ func TestAccountBalanceEntry_UnmarshalJSON(t *testing.T) {
 		t.Fatal("expected error for invalid number format, got none")
 	}
 }
+
+func TestLeverageAndMarginMode_UnmarshalJSON(t *testing.T) {
+	jsonData := `{
+		"symbol": "btcusdt",
+		"marginCoin": "usdt",
+		"leverage": 10,
+		"marginMode": "cross"
+	}`
+
+	var entry LeverageAndMarginMode
+	if err := json.Unmarshal([]byte(jsonData), &entry); err != nil {
+		t.Fatalf("unexpected error unmarshaling leverage and margin mode: %v", err)
+	}
+
+	if entry.Symbol != "BTCUSDT" {
+		t.Errorf("unexpected symbol: %s", entry.Symbol)
+	}
+
+	if entry.MarginCoin != "USDT" {
+		t.Errorf("unexpected marginCoin: %s", entry.MarginCoin)
+	}
+
+	if entry.Leverage != 10 {
+		t.Errorf("unexpected leverage: %d", entry.Leverage)
+	}
+
+	if entry.MarginMode != MarginModeCross {
+		t.Errorf("unexpected margin mode: %s", entry.MarginMode)
+	}
+
+	err := json.Unmarshal([]byte(`{"symbol":"BTCUSDT","marginCoin":"USDT","leverage":10,"marginMode":"UNKNOWN"}`), &entry)
+	if err == nil {
+		t.Fatal("expected error for invalid margin mode, got none")
+	}
+}
+
+func TestMarginModeEntry_UnmarshalJSON(t *testing.T) {
+	var entry MarginModeEntry
+	if err := json.Unmarshal([]byte(`{"symbol":"ETHUSDT","marginCoin":"USDT","marginMode":"ISOLATION"}`), &entry); err != nil {
+		t.Fatalf("unexpected error unmarshaling margin mode entry: %v", err)
+	}
+
+	if entry.Symbol != "ETHUSDT" {
+		t.Errorf("unexpected symbol: %s", entry.Symbol)
+	}
+
+	if entry.MarginMode != MarginModeIsolation {
+		t.Errorf("unexpected margin mode: %s", entry.MarginMode)
+	}
+}
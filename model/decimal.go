@@ -0,0 +1,263 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+var bigTen = big.NewInt(10)
+
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return normalizeScale(big.NewInt(unscaled), scale)
+}
+
+func NewDecimalFromInt(value int64) Decimal {
+	return Decimal{unscaled: big.NewInt(value)}
+}
+
+func NewDecimalFromFloat(value float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+func ParseDecimal(s string) (Decimal, error) {
+	input := strings.TrimSpace(s)
+	if input == "" {
+		return Decimal{}, fmt.Errorf("%q is not a valid decimal", s)
+	}
+
+	var exponent int64
+	if idx := strings.IndexAny(input, "eE"); idx >= 0 {
+		exp, err := strconv.ParseInt(input[idx+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("%q is not a valid decimal: %w", s, err)
+		}
+		exponent = exp
+		input = input[:idx]
+	}
+
+	intPart, fracPart, hasPoint := strings.Cut(input, ".")
+	digits := intPart + fracPart
+	if digits == "" || digits == "-" || digits == "+" || (hasPoint && strings.ContainsAny(fracPart, "+-")) {
+		return Decimal{}, fmt.Errorf("%q is not a valid decimal", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%q is not a valid decimal", s)
+	}
+
+	return normalizeScale(unscaled, int32(int64(len(fracPart))-exponent)), nil
+}
+
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Negative scales only come from exponents and are folded into the unscaled value
+func normalizeScale(unscaled *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		factor := new(big.Int).Exp(bigTen, big.NewInt(int64(-scale)), nil)
+		return Decimal{unscaled: new(big.Int).Mul(unscaled, factor)}
+	}
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+func (d Decimal) value() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale <= d.scale {
+		return new(big.Int).Set(d.value())
+	}
+	factor := new(big.Int).Exp(bigTen, big.NewInt(int64(scale-d.scale)), nil)
+	return new(big.Int).Mul(d.value(), factor)
+}
+
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	scale := max(d.scale, other.scale)
+	return d.rescale(scale).Cmp(other.rescale(scale))
+}
+
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return Decimal{unscaled: new(big.Int).Add(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return Decimal{unscaled: new(big.Int).Sub(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.value(), other.value()), scale: d.scale + other.scale}
+}
+
+// Div rounds half away from zero to the given number of decimal places
+func (d Decimal) Div(other Decimal, places int32) (Decimal, error) {
+	if other.IsZero() {
+		return Decimal{}, fmt.Errorf("division by zero")
+	}
+
+	// d/other = (d.unscaled * 10^(places+1+other.scale)) / (other.unscaled * 10^d.scale), one guard digit for rounding
+	numerator := new(big.Int).Mul(d.value(), new(big.Int).Exp(bigTen, big.NewInt(int64(places+1+other.scale)), nil))
+	denominator := new(big.Int).Mul(other.value(), new(big.Int).Exp(bigTen, big.NewInt(int64(d.scale)), nil))
+	quotient := new(big.Int).Quo(numerator, denominator)
+
+	return Decimal{unscaled: quotient, scale: places + 1}.Round(places), nil
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.value()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{unscaled: new(big.Int).Abs(d.value()), scale: d.scale}
+}
+
+// Round rounds half away from zero to the given number of decimal places
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+
+	factor := new(big.Int).Exp(bigTen, big.NewInt(int64(d.scale-places)), nil)
+	quotient, remainder := new(big.Int).QuoRem(d.value(), factor, new(big.Int))
+
+	doubled := new(big.Int).Abs(remainder)
+	doubled.Mul(doubled, big.NewInt(2))
+	if doubled.Cmp(factor) >= 0 {
+		if d.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return normalizeScale(quotient, places)
+}
+
+func (d Decimal) Truncate(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+
+	factor := new(big.Int).Exp(bigTen, big.NewInt(int64(d.scale-places)), nil)
+	return normalizeScale(new(big.Int).Quo(d.value(), factor), places)
+}
+
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.value()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.scale == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= int(d.scale) {
+		digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+	}
+
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+
+	value := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return fmt.Errorf("invalid decimal %s: %w", value, err)
+		}
+		value = unquoted
+	}
+
+	if strings.TrimSpace(value) == "" {
+		*d = Decimal{}
+		return nil
+	}
+
+	parsed, err := ParseDecimal(value)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+type decimalField struct {
+	name   string
+	value  string
+	target *Decimal
+}
+
+func parseDecimalFields(fields ...decimalField) error {
+	for _, field := range fields {
+		if field.value == "" {
+			*field.target = Decimal{}
+			continue
+		}
+
+		d, err := ParseDecimal(field.value)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", field.name, err)
+		}
+		*field.target = d
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"0", "0"},
+		{"123.4500", "123.4500"},
+		{"-0.000000123456789012345", "-0.000000123456789012345"},
+		{".5", "0.5"},
+		{"+7", "7"},
+		{"1e3", "1000"},
+		{"1.5E-3", "0.0015"},
+		{" 42.1 ", "42.1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			d, err := ParseDecimal(tc.input)
+			if err != nil {
+				t.Fatalf("ParseDecimal(%q) returned error: %v", tc.input, err)
+			}
+
+			if d.String() != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, d.String())
+			}
+		})
+	}
+
+	for _, input := range []string{"", "abc", "1.2.3", "-", "1e", "1.-5", "MARKET"} {
+		if _, err := ParseDecimal(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a := MustParseDecimal("0.1")
+	b := MustParseDecimal("0.2")
+
+	if sum := a.Add(b); sum.String() != "0.3" {
+		t.Errorf("Expected 0.1 + 0.2 = 0.3, got %s", sum)
+	}
+
+	if diff := a.Sub(b); diff.String() != "-0.1" {
+		t.Errorf("Expected 0.1 - 0.2 = -0.1, got %s", diff)
+	}
+
+	if product := MustParseDecimal("1.5").Mul(MustParseDecimal("-0.25")); product.String() != "-0.375" {
+		t.Errorf("Expected 1.5 * -0.25 = -0.375, got %s", product)
+	}
+
+	quotient, err := MustParseDecimal("10").Div(MustParseDecimal("3"), 4)
+	if err != nil || quotient.String() != "3.3333" {
+		t.Errorf("Expected 10 / 3 = 3.3333, got %s (%v)", quotient, err)
+	}
+
+	quotient, err = MustParseDecimal("-2").Div(MustParseDecimal("3"), 2)
+	if err != nil || quotient.String() != "-0.67" {
+		t.Errorf("Expected -2 / 3 = -0.67, got %s (%v)", quotient, err)
+	}
+
+	if _, err := a.Div(Decimal{}, 2); err == nil {
+		t.Error("Expected division by zero error")
+	}
+
+	if !MustParseDecimal("1.50").Equal(MustParseDecimal("1.5")) {
+		t.Error("Expected 1.50 to equal 1.5")
+	}
+
+	if MustParseDecimal("2").Cmp(MustParseDecimal("10")) != -1 {
+		t.Error("Expected 2 < 10")
+	}
+
+	var zero Decimal
+	if !zero.IsZero() || zero.String() != "0" || zero.Add(a).String() != "0.1" {
+		t.Errorf("Unexpected zero value behaviour: %s", zero)
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	testCases := []struct {
+		name     string
+		actual   Decimal
+		expected string
+	}{
+		{"round half up", MustParseDecimal("1.2345").Round(3), "1.235"},
+		{"round half away from zero", MustParseDecimal("-1.2345").Round(3), "-1.235"},
+		{"round down", MustParseDecimal("1.2344").Round(3), "1.234"},
+		{"round to integer", MustParseDecimal("99.5").Round(0), "100"},
+		{"round noop", MustParseDecimal("1.2").Round(3), "1.2"},
+		{"truncate", MustParseDecimal("1.2399").Truncate(2), "1.23"},
+		{"truncate negative", MustParseDecimal("-1.2399").Truncate(2), "-1.23"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.actual.String() != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, tc.actual)
+			}
+		})
+	}
+}
+
+func TestDecimalFloatConversion(t *testing.T) {
+	if d := NewDecimalFromFloat(0.1); d.String() != "0.1" {
+		t.Errorf("Expected 0.1, got %s", d)
+	}
+
+	if f := MustParseDecimal("123.456").Float64(); f != 123.456 {
+		t.Errorf("Expected 123.456, got %v", f)
+	}
+
+	if d := NewDecimal(12345, 2); d.String() != "123.45" {
+		t.Errorf("Expected 123.45, got %s", d)
+	}
+
+	if d := NewDecimalFromInt(-7); d.String() != "-7" {
+		t.Errorf("Expected -7, got %s", d)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	payload := struct {
+		Amount Decimal  `json:"amount"`
+		Number Decimal  `json:"number"`
+		Empty  Decimal  `json:"empty"`
+		Null   *Decimal `json:"null"`
+	}{}
+
+	if err := json.Unmarshal([]byte(`{"amount":"0.000000000000000001","number":12.5,"empty":"","null":null}`), &payload); err != nil {
+		t.Fatalf("Failed to unmarshal decimals: %v", err)
+	}
+
+	if payload.Amount.String() != "0.000000000000000001" {
+		t.Errorf("Expected amount 0.000000000000000001, got %s", payload.Amount)
+	}
+
+	if payload.Number.String() != "12.5" {
+		t.Errorf("Expected number 12.5, got %s", payload.Number)
+	}
+
+	if !payload.Empty.IsZero() || payload.Null != nil {
+		t.Errorf("Expected empty and null to be zero values, got %s %v", payload.Empty, payload.Null)
+	}
+
+	data, err := json.Marshal(payload.Amount)
+	if err != nil {
+		t.Fatalf("Failed to marshal decimal: %v", err)
+	}
+
+	if string(data) != `"0.000000000000000001"` {
+		t.Errorf("Expected decimal to marshal as string, got %s", string(data))
+	}
+
+	var invalid Decimal
+	if err := json.Unmarshal([]byte(`"abc"`), &invalid); err == nil {
+		t.Error("Expected error for invalid decimal")
+	}
+}
+
+func TestExactDecimalsArePopulated(t *testing.T) {
+	var position PendingPosition
+	data := `{"positionId":"1","symbol":"PEPEUSDT","qty":"123456789.123456789","entryValue":"1.000000000000000001","side":"BUY",` +
+		`"marginMode":"CROSS","positionMode":"ONE_WAY","leverage":10,"fees":"0.00000001","funding":"0","realizedPNL":"-0.000000000000123",` +
+		`"margin":"1","unrealizedPNL":"0.1","liqPrice":"0","marginRate":"0","avgOpenPrice":"0.00000812345678","ctime":"1","mtime":"1"}`
+
+	if err := json.Unmarshal([]byte(data), &position); err != nil {
+		t.Fatalf("Failed to unmarshal pending position: %v", err)
+	}
+
+	if position.Exact.EntryValue.String() != "1.000000000000000001" {
+		t.Errorf("Expected exact entry value, got %s", position.Exact.EntryValue)
+	}
+
+	if position.EntryValue != 1 {
+		t.Errorf("Expected float entry value 1, got %v", position.EntryValue)
+	}
+
+	if position.Exact.RealizedPNL.String() != "-0.000000000000123" {
+		t.Errorf("Expected exact realized PnL, got %s", position.Exact.RealizedPNL)
+	}
+
+	if position.Exact.Qty.String() != "123456789.123456789" {
+		t.Errorf("Expected exact qty, got %s", position.Exact.Qty)
+	}
+
+	var trade HistoricalTrade
+	tradeData := `{"tradeId":"1","orderId":"1","symbol":"BTCUSDT","qty":"0.1","price":"0.2","fee":"0.3","realizedPNL":"0.4",` +
+		`"positionMode":"ONE_WAY","marginMode":"CROSS","side":"BUY","orderType":"LIMIT","roleType":"MAKER","ctime":"1"}`
+	if err := json.Unmarshal([]byte(tradeData), &trade); err != nil {
+		t.Fatalf("Failed to unmarshal trade: %v", err)
+	}
+
+	total := trade.Exact.Quantity.Add(trade.Exact.Price)
+	if total.String() != "0.3" {
+		t.Errorf("Expected exact sum 0.3, got %s", total)
+	}
+
+	var kline KLineEvent
+	if err := json.Unmarshal([]byte(`{"o":"1.1","h":"2.2","l":"0.9","c":"1.3","b":"10","q":"12.345678901234567890"}`), &kline); err != nil {
+		t.Fatalf("Failed to unmarshal kline event: %v", err)
+	}
+
+	if kline.Exact.QuoteVolume.String() != "12.345678901234567890" {
+		t.Errorf("Expected exact quote volume, got %s", kline.Exact.QuoteVolume)
+	}
+}
+
+func TestOrderRequestMarshalJSON_Decimal(t *testing.T) {
+	qty := MustParseDecimal("0.123456789012345678")
+	price := MustParseDecimal("0.00000812345678")
+	floatPrice := price.Float64()
+
+	req := &OrderRequest{
+		Symbol:       "PEPEUSDT",
+		Qty:          qty.Float64(),
+		Price:        &floatPrice,
+		QtyDecimal:   &qty,
+		PriceDecimal: &price,
+		OrderType:    OrderTypeLimit,
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal order request: %v", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if m["qty"] != "0.123456789012345678" {
+		t.Errorf("Expected exact qty, got %v", m["qty"])
+	}
+
+	if m["price"] != "0.00000812345678" {
+		t.Errorf("Expected exact price, got %v", m["price"])
+	}
+}
@@ -0,0 +1,521 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type TickersParams struct {
+	Symbols []Symbol
+}
+
+type TickersResponse struct {
+	BaseResponse
+	Data []Ticker `json:"data"`
+}
+
+type Ticker struct {
+	Symbol      Symbol  `json:"-"`
+	MarkPrice   float64 `json:"-"`
+	LastPrice   float64 `json:"-"`
+	OpenPrice   float64 `json:"-"`
+	HighPrice   float64 `json:"-"`
+	LowPrice    float64 `json:"-"`
+	BaseVolume  float64 `json:"-"`
+	QuoteVolume float64 `json:"-"`
+}
+
+func (t *Ticker) UnmarshalJSON(data []byte) error {
+	type Alias Ticker
+	aux := &struct {
+		Symbol      string `json:"symbol"`
+		MarkPrice   string `json:"markPrice"`
+		LastPrice   string `json:"lastPrice"`
+		OpenPrice   string `json:"open"`
+		HighPrice   string `json:"high"`
+		LowPrice    string `json:"low"`
+		BaseVolume  string `json:"baseVol"`
+		QuoteVolume string `json:"quoteVol"`
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	t.Symbol = ParseSymbol(aux.Symbol).Normalize()
+
+	if aux.MarkPrice != "" {
+		val, err := strconv.ParseFloat(aux.MarkPrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse mark price: %w", err)
+		}
+		t.MarkPrice = val
+	}
+
+	if aux.LastPrice != "" {
+		val, err := strconv.ParseFloat(aux.LastPrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse last price: %w", err)
+		}
+		t.LastPrice = val
+	}
+
+	if aux.OpenPrice != "" {
+		val, err := strconv.ParseFloat(aux.OpenPrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse open price: %w", err)
+		}
+		t.OpenPrice = val
+	}
+
+	if aux.HighPrice != "" {
+		val, err := strconv.ParseFloat(aux.HighPrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse high price: %w", err)
+		}
+		t.HighPrice = val
+	}
+
+	if aux.LowPrice != "" {
+		val, err := strconv.ParseFloat(aux.LowPrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse low price: %w", err)
+		}
+		t.LowPrice = val
+	}
+
+	if aux.BaseVolume != "" {
+		val, err := strconv.ParseFloat(aux.BaseVolume, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse base volume: %w", err)
+		}
+		t.BaseVolume = val
+	}
+
+	if aux.QuoteVolume != "" {
+		val, err := strconv.ParseFloat(aux.QuoteVolume, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse quote volume: %w", err)
+		}
+		t.QuoteVolume = val
+	}
+
+	return nil
+}
+
+type DepthParams struct {
+	Symbol Symbol
+	Limit  int
+}
+
+type DepthResponse struct {
+	BaseResponse
+	Data *Depth `json:"data"`
+}
+
+type Depth struct {
+	Asks []PriceLevel `json:"asks"`
+	Bids []PriceLevel `json:"bids"`
+}
+
+type PriceLevel struct {
+	Price float64
+	Qty   float64
+}
+
+func (p *PriceLevel) UnmarshalJSON(data []byte) error {
+	var level []string
+	if err := json.Unmarshal(data, &level); err != nil {
+		return err
+	}
+
+	if len(level) < 2 {
+		return fmt.Errorf("invalid price level: expected [price, qty], got %d entries", len(level))
+	}
+
+	price, err := strconv.ParseFloat(level[0], 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse price: %w", err)
+	}
+	p.Price = price
+
+	qty, err := strconv.ParseFloat(level[1], 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse qty: %w", err)
+	}
+	p.Qty = qty
+
+	return nil
+}
+
+type KlineParams struct {
+	Symbol    Symbol
+	Interval  Interval
+	PriceType PriceType
+	StartTime *time.Time
+	EndTime   *time.Time
+	Limit     int64
+}
+
+type KlineResponse struct {
+	BaseResponse
+	Data []Kline `json:"data"`
+}
+
+type Kline struct {
+	Time        time.Time `json:"-"`
+	OpenPrice   float64   `json:"-"`
+	HighPrice   float64   `json:"-"`
+	LowPrice    float64   `json:"-"`
+	ClosePrice  float64   `json:"-"`
+	BaseVolume  float64   `json:"-"`
+	QuoteVolume float64   `json:"-"`
+}
+
+func (k *Kline) UnmarshalJSON(data []byte) error {
+	type Alias Kline
+	aux := &struct {
+		Time        json.Number `json:"time"`
+		OpenPrice   string      `json:"open"`
+		HighPrice   string      `json:"high"`
+		LowPrice    string      `json:"low"`
+		ClosePrice  string      `json:"close"`
+		BaseVolume  string      `json:"baseVol"`
+		QuoteVolume string      `json:"quoteVol"`
+		*Alias
+	}{
+		Alias: (*Alias)(k),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.Time != "" {
+		ms, err := aux.Time.Int64()
+		if err != nil {
+			return fmt.Errorf("failed to parse time: %w", err)
+		}
+		k.Time = time.UnixMilli(ms)
+	}
+
+	if aux.OpenPrice != "" {
+		val, err := strconv.ParseFloat(aux.OpenPrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse open price: %w", err)
+		}
+		k.OpenPrice = val
+	}
+
+	if aux.HighPrice != "" {
+		val, err := strconv.ParseFloat(aux.HighPrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse high price: %w", err)
+		}
+		k.HighPrice = val
+	}
+
+	if aux.LowPrice != "" {
+		val, err := strconv.ParseFloat(aux.LowPrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse low price: %w", err)
+		}
+		k.LowPrice = val
+	}
+
+	if aux.ClosePrice != "" {
+		val, err := strconv.ParseFloat(aux.ClosePrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse close price: %w", err)
+		}
+		k.ClosePrice = val
+	}
+
+	if aux.BaseVolume != "" {
+		val, err := strconv.ParseFloat(aux.BaseVolume, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse base volume: %w", err)
+		}
+		k.BaseVolume = val
+	}
+
+	if aux.QuoteVolume != "" {
+		val, err := strconv.ParseFloat(aux.QuoteVolume, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse quote volume: %w", err)
+		}
+		k.QuoteVolume = val
+	}
+
+	return nil
+}
+
+// MarshalJSON writes the kline in the shape returned by the kline endpoint
+func (k Kline) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Time        string `json:"time"`
+		OpenPrice   string `json:"open"`
+		HighPrice   string `json:"high"`
+		LowPrice    string `json:"low"`
+		ClosePrice  string `json:"close"`
+		BaseVolume  string `json:"baseVol"`
+		QuoteVolume string `json:"quoteVol"`
+	}{
+		Time:        strconv.FormatInt(k.Time.UnixMilli(), 10),
+		OpenPrice:   strconv.FormatFloat(k.OpenPrice, 'f', -1, 64),
+		HighPrice:   strconv.FormatFloat(k.HighPrice, 'f', -1, 64),
+		LowPrice:    strconv.FormatFloat(k.LowPrice, 'f', -1, 64),
+		ClosePrice:  strconv.FormatFloat(k.ClosePrice, 'f', -1, 64),
+		BaseVolume:  strconv.FormatFloat(k.BaseVolume, 'f', -1, 64),
+		QuoteVolume: strconv.FormatFloat(k.QuoteVolume, 'f', -1, 64),
+	})
+}
+
+type FundingRateParams struct {
+	Symbol Symbol
+}
+
+type FundingRateResponse struct {
+	BaseResponse
+	Data *FundingRate `json:"data"`
+}
+
+type FundingRate struct {
+	Symbol          Symbol    `json:"-"`
+	MarkPrice       float64   `json:"-"`
+	LastPrice       float64   `json:"-"`
+	FundingRate     float64   `json:"-"`
+	FundingInterval int       `json:"-"`
+	NextFundingTime time.Time `json:"-"`
+}
+
+func (f *FundingRate) UnmarshalJSON(data []byte) error {
+	type Alias FundingRate
+	aux := &struct {
+		Symbol          string      `json:"symbol"`
+		MarkPrice       string      `json:"markPrice"`
+		LastPrice       string      `json:"lastPrice"`
+		FundingRate     string      `json:"fundingRate"`
+		FundingInterval json.Number `json:"fundingInterval"`
+		NextFundingTime json.Number `json:"nextFundingTime"`
+		*Alias
+	}{
+		Alias: (*Alias)(f),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	f.Symbol = ParseSymbol(aux.Symbol).Normalize()
+
+	if aux.MarkPrice != "" {
+		val, err := strconv.ParseFloat(aux.MarkPrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse mark price: %w", err)
+		}
+		f.MarkPrice = val
+	}
+
+	if aux.LastPrice != "" {
+		val, err := strconv.ParseFloat(aux.LastPrice, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse last price: %w", err)
+		}
+		f.LastPrice = val
+	}
+
+	if aux.FundingRate != "" {
+		val, err := strconv.ParseFloat(aux.FundingRate, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse funding rate: %w", err)
+		}
+		f.FundingRate = val
+	}
+
+	if aux.FundingInterval != "" {
+		val, err := aux.FundingInterval.Int64()
+		if err != nil {
+			return fmt.Errorf("failed to parse funding interval: %w", err)
+		}
+		f.FundingInterval = int(val)
+	}
+
+	if aux.NextFundingTime != "" {
+		ms, err := aux.NextFundingTime.Int64()
+		if err != nil {
+			return fmt.Errorf("failed to parse next funding time: %w", err)
+		}
+		f.NextFundingTime = time.UnixMilli(ms)
+	}
+
+	return nil
+}
+
+type FundingRateHistoryParams struct {
+	Symbol    Symbol
+	StartTime *time.Time
+	EndTime   *time.Time
+	Limit     int64
+}
+
+type FundingRateHistoryResponse struct {
+	BaseResponse
+	Data []FundingRateHistoryEntry `json:"data"`
+}
+
+type FundingRateHistoryEntry struct {
+	Symbol      Symbol    `json:"-"`
+	FundingRate float64   `json:"-"`
+	FundingTime time.Time `json:"-"`
+}
+
+func (f *FundingRateHistoryEntry) UnmarshalJSON(data []byte) error {
+	type Alias FundingRateHistoryEntry
+	aux := &struct {
+		Symbol      string      `json:"symbol"`
+		FundingRate string      `json:"fundingRate"`
+		FundingTime json.Number `json:"fundingTime"`
+		*Alias
+	}{
+		Alias: (*Alias)(f),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	f.Symbol = ParseSymbol(aux.Symbol).Normalize()
+
+	if aux.FundingRate != "" {
+		val, err := strconv.ParseFloat(aux.FundingRate, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse funding rate: %w", err)
+		}
+		f.FundingRate = val
+	}
+
+	if aux.FundingTime != "" {
+		ms, err := aux.FundingTime.Int64()
+		if err != nil {
+			return fmt.Errorf("failed to parse funding time: %w", err)
+		}
+		f.FundingTime = time.UnixMilli(ms)
+	}
+
+	return nil
+}
+
+type TradingPairsParams struct {
+	Symbols []Symbol
+}
+
+type TradingPairsResponse struct {
+	BaseResponse
+	Data []TradingPair `json:"data"`
+}
+
+type TradingPair struct {
+	Symbol               Symbol     `json:"-"`
+	Base                 string     `json:"base"`
+	Quote                string     `json:"quote"`
+	MinTradeVolume       float64    `json:"-"`
+	MinBuyPriceOffset    float64    `json:"-"`
+	MaxSellPriceOffset   float64    `json:"-"`
+	MaxLimitOrderVolume  float64    `json:"-"`
+	MaxMarketOrderVolume float64    `json:"-"`
+	BasePrecision        int        `json:"basePrecision"`
+	QuotePrecision       int        `json:"quotePrecision"`
+	MaxLeverage          int        `json:"maxLeverage"`
+	MinLeverage          int        `json:"minLeverage"`
+	DefaultLeverage      int        `json:"defaultLeverage"`
+	DefaultMarginMode    MarginMode `json:"-"`
+	PriceProtectScope    float64    `json:"-"`
+	SymbolStatus         string     `json:"symbolStatus"`
+}
+
+func (p *TradingPair) UnmarshalJSON(data []byte) error {
+	type Alias TradingPair
+	aux := &struct {
+		Symbol               string `json:"symbol"`
+		MinTradeVolume       string `json:"minTradeVolume"`
+		MinBuyPriceOffset    string `json:"minBuyPriceOffset"`
+		MaxSellPriceOffset   string `json:"maxSellPriceOffset"`
+		MaxLimitOrderVolume  string `json:"maxLimitOrderVolume"`
+		MaxMarketOrderVolume string `json:"maxMarketOrderVolume"`
+		DefaultMarginMode    string `json:"defaultMarginMode"`
+		PriceProtectScope    string `json:"priceProtectScope"`
+		*Alias
+	}{
+		Alias: (*Alias)(p),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	p.Symbol = ParseSymbol(aux.Symbol).Normalize()
+
+	if aux.DefaultMarginMode != "" {
+		marginMode, err := ParseMarginMode(aux.DefaultMarginMode)
+		if err != nil {
+			return fmt.Errorf("invalid default margin mode: %w", err)
+		}
+		p.DefaultMarginMode = marginMode
+	}
+
+	if aux.MinTradeVolume != "" {
+		val, err := strconv.ParseFloat(aux.MinTradeVolume, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse min trade volume: %w", err)
+		}
+		p.MinTradeVolume = val
+	}
+
+	if aux.MinBuyPriceOffset != "" {
+		val, err := strconv.ParseFloat(aux.MinBuyPriceOffset, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse min buy price offset: %w", err)
+		}
+		p.MinBuyPriceOffset = val
+	}
+
+	if aux.MaxSellPriceOffset != "" {
+		val, err := strconv.ParseFloat(aux.MaxSellPriceOffset, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse max sell price offset: %w", err)
+		}
+		p.MaxSellPriceOffset = val
+	}
+
+	if aux.MaxLimitOrderVolume != "" {
+		val, err := strconv.ParseFloat(aux.MaxLimitOrderVolume, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse max limit order volume: %w", err)
+		}
+		p.MaxLimitOrderVolume = val
+	}
+
+	if aux.MaxMarketOrderVolume != "" {
+		val, err := strconv.ParseFloat(aux.MaxMarketOrderVolume, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse max market order volume: %w", err)
+		}
+		p.MaxMarketOrderVolume = val
+	}
+
+	if aux.PriceProtectScope != "" {
+		val, err := strconv.ParseFloat(aux.PriceProtectScope, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse price protect scope: %w", err)
+		}
+		p.PriceProtectScope = val
+	}
+
+	return nil
+}
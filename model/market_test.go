@@ -0,0 +1,70 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPriceLevelUnmarshalJSON(t *testing.T) {
+	var level PriceLevel
+	if err := json.Unmarshal([]byte(`["95457.7","1.25"]`), &level); err != nil {
+		t.Fatalf("Failed to unmarshal price level: %v", err)
+	}
+
+	if level.Price != 95457.7 || level.Qty != 1.25 {
+		t.Errorf("Unexpected price level %+v", level)
+	}
+
+	if err := json.Unmarshal([]byte(`["95457.7"]`), &level); err == nil {
+		t.Error("Expected error for incomplete price level")
+	}
+
+	if err := json.Unmarshal([]byte(`["abc","1"]`), &level); err == nil {
+		t.Error("Expected error for invalid price")
+	}
+}
+
+func TestKlineUnmarshalJSON(t *testing.T) {
+	var kline Kline
+	data := `{"open":"100.5","high":"110","close":"105","low":"95","time":"1732982400000","quoteVol":"1000","baseVol":"10"}`
+	if err := json.Unmarshal([]byte(data), &kline); err != nil {
+		t.Fatalf("Failed to unmarshal kline: %v", err)
+	}
+
+	if kline.Time.UnixMilli() != 1732982400000 {
+		t.Errorf("Expected time 1732982400000, got %d", kline.Time.UnixMilli())
+	}
+
+	if kline.OpenPrice != 100.5 || kline.HighPrice != 110 || kline.LowPrice != 95 || kline.ClosePrice != 105 {
+		t.Errorf("Unexpected kline prices %+v", kline)
+	}
+
+	if err := json.Unmarshal([]byte(`{"open":"x"}`), &kline); err == nil {
+		t.Error("Expected error for invalid open price")
+	}
+}
+
+func TestKlineMarshalJSONRoundTrip(t *testing.T) {
+	kline := Kline{Time: time.UnixMilli(1732982400000), OpenPrice: 100.5, HighPrice: 110, LowPrice: 95, ClosePrice: 105, BaseVolume: 10, QuoteVolume: 1000}
+	data, err := json.Marshal(kline)
+	if err != nil {
+		t.Fatalf("Failed to marshal kline: %v", err)
+	}
+
+	var decoded Kline
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal kline: %v", err)
+	}
+
+	if !decoded.Time.Equal(kline.Time) || decoded.OpenPrice != 100.5 || decoded.ClosePrice != 105 || decoded.QuoteVolume != 1000 {
+		t.Errorf("Unexpected kline after round trip %+v", decoded)
+	}
+}
+
+func TestTradingPairUnmarshalJSON_InvalidMarginMode(t *testing.T) {
+	var pair TradingPair
+	if err := json.Unmarshal([]byte(`{"symbol":"btcusdt","defaultMarginMode":"UNKNOWN"}`), &pair); err == nil {
+		t.Error("Expected error for invalid default margin mode")
+	}
+}
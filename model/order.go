@@ -74,6 +74,113 @@ type TPSLOrderResponseData struct {
 	ClientId string `json:"clientId"`
 }
 
+type ModifyTPSLOrderRequest struct {
+	OrderID      string    `json:"orderId"`
+	TpPrice      *float64  `json:"-"`
+	SlPrice      *float64  `json:"-"`
+	TpStopType   StopType  `json:"tpStopType,omitempty"`
+	SlStopType   StopType  `json:"slStopType,omitempty"`
+	TpOrderType  OrderType `json:"tpOrderType,omitempty"`
+	SlOrderType  OrderType `json:"slOrderType,omitempty"`
+	TpOrderPrice *float64  `json:"-"`
+	SlOrderPrice *float64  `json:"-"`
+	TpQty        *float64  `json:"-"`
+	SlQty        *float64  `json:"-"`
+}
+
+func (r *ModifyTPSLOrderRequest) MarshalJSON() ([]byte, error) {
+	type Alias ModifyTPSLOrderRequest
+
+	aux := &struct {
+		TpPrice      string `json:"tpPrice,omitempty"`
+		SlPrice      string `json:"slPrice,omitempty"`
+		TpOrderPrice string `json:"tpOrderPrice,omitempty"`
+		SlOrderPrice string `json:"slOrderPrice,omitempty"`
+		TpQty        string `json:"tpQty,omitempty"`
+		SlQty        string `json:"slQty,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+
+	if r.TpPrice != nil {
+		aux.TpPrice = strconv.FormatFloat(*r.TpPrice, 'f', -1, 64)
+	}
+
+	if r.SlPrice != nil {
+		aux.SlPrice = strconv.FormatFloat(*r.SlPrice, 'f', -1, 64)
+	}
+
+	if r.TpOrderPrice != nil {
+		aux.TpOrderPrice = strconv.FormatFloat(*r.TpOrderPrice, 'f', -1, 64)
+	}
+
+	if r.SlOrderPrice != nil {
+		aux.SlOrderPrice = strconv.FormatFloat(*r.SlOrderPrice, 'f', -1, 64)
+	}
+
+	if r.TpQty != nil {
+		aux.TpQty = strconv.FormatFloat(*r.TpQty, 'f', -1, 64)
+	}
+
+	if r.SlQty != nil {
+		aux.SlQty = strconv.FormatFloat(*r.SlQty, 'f', -1, 64)
+	}
+
+	return json.Marshal(aux)
+}
+
+type CancelTPSLOrderRequest struct {
+	Symbol  Symbol `json:"symbol"`
+	OrderID string `json:"orderId"`
+}
+
+type PositionTPSLOrderRequest struct {
+	Symbol     Symbol   `json:"symbol"`
+	PositionID string   `json:"positionId"`
+	TpPrice    *float64 `json:"-"`
+	TpStopType StopType `json:"tpStopType,omitempty"`
+	SlPrice    *float64 `json:"-"`
+	SlStopType StopType `json:"slStopType,omitempty"`
+}
+
+func (r *PositionTPSLOrderRequest) MarshalJSON() ([]byte, error) {
+	type Alias PositionTPSLOrderRequest
+
+	aux := &struct {
+		TpPrice string `json:"tpPrice,omitempty"`
+		SlPrice string `json:"slPrice,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+
+	if r.TpPrice != nil {
+		aux.TpPrice = strconv.FormatFloat(*r.TpPrice, 'f', -1, 64)
+	}
+
+	if r.SlPrice != nil {
+		aux.SlPrice = strconv.FormatFloat(*r.SlPrice, 'f', -1, 64)
+	}
+
+	return json.Marshal(aux)
+}
+
+type ModifyTpSlOrderResponse struct {
+	BaseResponse
+	Data TPSLOrderResponseData `json:"data"`
+}
+
+type CancelTpSlOrderResponse struct {
+	BaseResponse
+	Data TPSLOrderResponseData `json:"data"`
+}
+
+type PositionTpSlOrderResponse struct {
+	BaseResponse
+	Data TPSLOrderResponseData `json:"data"`
+}
+
 type OrderRequest struct {
 	Symbol       Symbol      `json:"symbol"`
 	TradeSide    TradeSide   `json:"side"`
@@ -93,6 +200,8 @@ type OrderRequest struct {
 	SlStopType   StopType    `json:"slStopType,omitempty"`
 	SlOrderType  OrderType   `json:"slOrderType,omitempty"`
 	SlOrderPrice *float64    `json:"-"`
+	QtyDecimal   *Decimal    `json:"-"`
+	PriceDecimal *Decimal    `json:"-"`
 }
 
 func (r *OrderRequest) MarshalJSON() ([]byte, error) {
@@ -116,6 +225,14 @@ func (r *OrderRequest) MarshalJSON() ([]byte, error) {
 
 	aux.Qty = strconv.FormatFloat(r.Qty, 'f', -1, 64)
 
+	if r.PriceDecimal != nil {
+		aux.Price = r.PriceDecimal.String()
+	}
+
+	if r.QtyDecimal != nil {
+		aux.Qty = r.QtyDecimal.String()
+	}
+
 	if r.TpPrice != nil {
 		aux.TpPrice = strconv.FormatFloat(*r.TpPrice, 'f', -1, 64)
 	}
@@ -178,6 +295,93 @@ type CancelOrderRequest struct {
 	OrderList []CancelOrderParam `json:"orderList"`
 }
 
+type CancelAllOrdersRequest struct {
+	Symbol Symbol `json:"symbol,omitempty"`
+}
+
+type ModifyOrderRequest struct {
+	OrderID      string    `json:"orderId,omitempty"`
+	ClientID     string    `json:"clientId,omitempty"`
+	Qty          float64   `json:"-"`
+	Price        float64   `json:"-"`
+	TpPrice      *float64  `json:"-"`
+	TpStopType   StopType  `json:"tpStopType,omitempty"`
+	TpOrderType  OrderType `json:"tpOrderType,omitempty"`
+	TpOrderPrice *float64  `json:"-"`
+	SlPrice      *float64  `json:"-"`
+	SlStopType   StopType  `json:"slStopType,omitempty"`
+	SlOrderType  OrderType `json:"slOrderType,omitempty"`
+	SlOrderPrice *float64  `json:"-"`
+}
+
+func (r *ModifyOrderRequest) MarshalJSON() ([]byte, error) {
+	type Alias ModifyOrderRequest
+
+	aux := &struct {
+		Qty          string `json:"qty"`
+		Price        string `json:"price"`
+		TpPrice      string `json:"tpPrice,omitempty"`
+		TpOrderPrice string `json:"tpOrderPrice,omitempty"`
+		SlPrice      string `json:"slPrice,omitempty"`
+		SlOrderPrice string `json:"slOrderPrice,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+
+	aux.Qty = strconv.FormatFloat(r.Qty, 'f', -1, 64)
+	aux.Price = strconv.FormatFloat(r.Price, 'f', -1, 64)
+
+	if r.TpPrice != nil {
+		aux.TpPrice = strconv.FormatFloat(*r.TpPrice, 'f', -1, 64)
+	}
+
+	if r.TpOrderPrice != nil {
+		aux.TpOrderPrice = strconv.FormatFloat(*r.TpOrderPrice, 'f', -1, 64)
+	}
+
+	if r.SlPrice != nil {
+		aux.SlPrice = strconv.FormatFloat(*r.SlPrice, 'f', -1, 64)
+	}
+
+	if r.SlOrderPrice != nil {
+		aux.SlOrderPrice = strconv.FormatFloat(*r.SlOrderPrice, 'f', -1, 64)
+	}
+
+	return json.Marshal(aux)
+}
+
+type ModifyOrderResponse struct {
+	BaseResponse
+	Data OrderResponseData `json:"data"`
+}
+
+type BatchOrderRequest struct {
+	Symbol    Symbol         `json:"symbol"`
+	OrderList []OrderRequest `json:"orderList"`
+}
+
+type BatchOrderResponse struct {
+	BaseResponse
+	Data BatchOrderResponseData `json:"data"`
+}
+
+type BatchOrderResponseData struct {
+	SuccessList []BatchOrderResult  `json:"successList"`
+	FailureList []BatchOrderFailure `json:"failureList"`
+}
+
+type BatchOrderResult struct {
+	OrderId  string `json:"orderId"`
+	ClientId string `json:"clientId"`
+}
+
+type BatchOrderFailure struct {
+	ClientId  string `json:"clientId"`
+	ErrorMsg  string `json:"errorMsg"`
+	ErrorCode string `json:"errorCode"`
+}
+
 type OrderHistoryParams struct {
 	Symbol    Symbol
 	OrderID   string
@@ -436,33 +640,41 @@ func (o *OrderDetail) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type HistoricalOrderDecimals struct {
+	Quantity      Decimal
+	TradeQuantity Decimal
+	Fee           Decimal
+	RealizedPNL   Decimal
+}
+
 type HistoricalOrder struct {
-	OrderID       string       `json:"orderId"`
-	Symbol        Symbol       `json:"symbol"`
-	Quantity      float64      `json:"-"`
-	TradeQuantity float64      `json:"-"`
-	PositionMode  PositionMode `json:"-"`
-	MarginMode    MarginMode   `json:"-"`
-	Leverage      int          `json:"leverage"`
-	Price         string       `json:"price"`
-	Side          TradeSide    `json:"-"`
-	OrderType     OrderType    `json:"-"`
-	Effect        TimeInForce  `json:"-"`
-	ClientID      string       `json:"clientId"`
-	ReduceOnly    bool         `json:"reduceOnly"`
-	Status        OrderStatus  `json:"-"`
-	Fee           float64      `json:"-"`
-	RealizedPNL   float64      `json:"-"`
-	TpPrice       *float64     `json:"-"`
-	TpOrderPrice  *float64     `json:"-"`
-	SlPrice       *float64     `json:"-"`
-	TpStopType    *StopType    `json:"-"`
-	TpOrderType   *OrderType   `json:"-"`
-	SlStopType    *StopType    `json:"-"`
-	SlOrderType   *OrderType   `json:"-"`
-	SlOrderPrice  *float64     `json:"-"`
-	CreateTime    time.Time    `json:"-"`
-	ModifyTime    time.Time    `json:"-"`
+	OrderID       string                  `json:"orderId"`
+	Symbol        Symbol                  `json:"symbol"`
+	Quantity      float64                 `json:"-"`
+	TradeQuantity float64                 `json:"-"`
+	PositionMode  PositionMode            `json:"-"`
+	MarginMode    MarginMode              `json:"-"`
+	Leverage      int                     `json:"leverage"`
+	Price         string                  `json:"price"`
+	Side          TradeSide               `json:"-"`
+	OrderType     OrderType               `json:"-"`
+	Effect        TimeInForce             `json:"-"`
+	ClientID      string                  `json:"clientId"`
+	ReduceOnly    bool                    `json:"reduceOnly"`
+	Status        OrderStatus             `json:"-"`
+	Fee           float64                 `json:"-"`
+	RealizedPNL   float64                 `json:"-"`
+	TpPrice       *float64                `json:"-"`
+	TpOrderPrice  *float64                `json:"-"`
+	SlPrice       *float64                `json:"-"`
+	TpStopType    *StopType               `json:"-"`
+	TpOrderType   *OrderType              `json:"-"`
+	SlStopType    *StopType               `json:"-"`
+	SlOrderType   *OrderType              `json:"-"`
+	SlOrderPrice  *float64                `json:"-"`
+	CreateTime    time.Time               `json:"-"`
+	ModifyTime    time.Time               `json:"-"`
+	Exact         HistoricalOrderDecimals `json:"-"`
 }
 
 func (o *HistoricalOrder) UnmarshalJSON(data []byte) error {
@@ -658,7 +870,12 @@ func (o *HistoricalOrder) UnmarshalJSON(data []byte) error {
 		o.SlOrderType = &slOrderType
 	}
 
-	return nil
+	return parseDecimalFields(
+		decimalField{"qty", aux.Quantity, &o.Exact.Quantity},
+		decimalField{"tradeQty", aux.TradeQuantity, &o.Exact.TradeQuantity},
+		decimalField{"fee", aux.Fee, &o.Exact.Fee},
+		decimalField{"realizedPNL", aux.RealizedPNL, &o.Exact.RealizedPNL},
+	)
 }
 
 type PendingTPSLOrderParams struct {
@@ -849,33 +1066,42 @@ func (r *PendingOrderResponse) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type PendingOrderDecimals struct {
+	Quantity      Decimal
+	TradeQuantity Decimal
+	Price         Decimal
+	Fee           Decimal
+	RealizedPNL   Decimal
+}
+
 type PendingOrder struct {
-	OrderID       string       `json:"orderId"`
-	Symbol        Symbol       `json:"-"`
-	Quantity      float64      `json:"-"`
-	TradeQuantity float64      `json:"-"`
-	PositionMode  PositionMode `json:"-"`
-	MarginMode    MarginMode   `json:"-"`
-	Leverage      int          `json:"leverage"`
-	Price         float64      `json:"-"`
-	Side          TradeSide    `json:"-"`
-	OrderType     OrderType    `json:"-"`
-	Effect        TimeInForce  `json:"-"`
-	ClientID      string       `json:"clientId"`
-	ReduceOnly    bool         `json:"reduceOnly"`
-	Status        OrderStatus  `json:"-"`
-	Fee           float64      `json:"-"`
-	RealizedPNL   float64      `json:"-"`
-	TpPrice       *float64     `json:"-"`
-	TpStopType    *StopType    `json:"-"`
-	TpOrderType   *OrderType   `json:"-"`
-	TpOrderPrice  *float64     `json:"-"`
-	SlPrice       *float64     `json:"-"`
-	SlStopType    *StopType    `json:"-"`
-	SlOrderType   *OrderType   `json:"-"`
-	SlOrderPrice  *float64     `json:"-"`
-	CreateTime    time.Time    `json:"-"`
-	ModifyTime    time.Time    `json:"-"`
+	OrderID       string               `json:"orderId"`
+	Symbol        Symbol               `json:"-"`
+	Quantity      float64              `json:"-"`
+	TradeQuantity float64              `json:"-"`
+	PositionMode  PositionMode         `json:"-"`
+	MarginMode    MarginMode           `json:"-"`
+	Leverage      int                  `json:"leverage"`
+	Price         float64              `json:"-"`
+	Side          TradeSide            `json:"-"`
+	OrderType     OrderType            `json:"-"`
+	Effect        TimeInForce          `json:"-"`
+	ClientID      string               `json:"clientId"`
+	ReduceOnly    bool                 `json:"reduceOnly"`
+	Status        OrderStatus          `json:"-"`
+	Fee           float64              `json:"-"`
+	RealizedPNL   float64              `json:"-"`
+	TpPrice       *float64             `json:"-"`
+	TpStopType    *StopType            `json:"-"`
+	TpOrderType   *OrderType           `json:"-"`
+	TpOrderPrice  *float64             `json:"-"`
+	SlPrice       *float64             `json:"-"`
+	SlStopType    *StopType            `json:"-"`
+	SlOrderType   *OrderType           `json:"-"`
+	SlOrderPrice  *float64             `json:"-"`
+	CreateTime    time.Time            `json:"-"`
+	ModifyTime    time.Time            `json:"-"`
+	Exact         PendingOrderDecimals `json:"-"`
 }
 
 func (o *PendingOrder) UnmarshalJSON(data []byte) error {
@@ -1079,7 +1305,13 @@ func (o *PendingOrder) UnmarshalJSON(data []byte) error {
 		o.SlOrderType = &slOrderType
 	}
 
-	return nil
+	return parseDecimalFields(
+		decimalField{"qty", aux.Quantity, &o.Exact.Quantity},
+		decimalField{"tradeQty", aux.TradeQuantity, &o.Exact.TradeQuantity},
+		decimalField{"price", aux.Price, &o.Exact.Price},
+		decimalField{"fee", aux.Fee, &o.Exact.Fee},
+		decimalField{"realizedPNL", aux.RealizedPNL, &o.Exact.RealizedPNL},
+	)
 }
 
 type TPSLOrderHistoryParams struct {
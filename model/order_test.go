@@ -413,3 +413,124 @@ func TestCancelOrderRequestMarshaling(t *testing.T) {
 		}
 	}
 }
+
+func TestModifyOrderRequestMarshalJSON(t *testing.T) {
+	tpPrice := 55000.0
+
+	req := &ModifyOrderRequest{
+		OrderID:     "11111",
+		Qty:         0.25,
+		Price:       50000.5,
+		TpPrice:     &tpPrice,
+		TpStopType:  StopTypeMarkPrice,
+		TpOrderType: OrderTypeMarket,
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal modify order request: %v", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if m["orderId"] != "11111" {
+		t.Errorf("Expected orderId \"11111\", got %v", m["orderId"])
+	}
+	if m["qty"] != "0.25" {
+		t.Errorf("Expected qty to be marshaled as string \"0.25\", got %v", m["qty"])
+	}
+	if m["price"] != "50000.5" {
+		t.Errorf("Expected price to be marshaled as string \"50000.5\", got %v", m["price"])
+	}
+	if m["tpPrice"] != "55000" {
+		t.Errorf("Expected tpPrice to be marshaled as string \"55000\", got %v", m["tpPrice"])
+	}
+	if _, ok := m["slPrice"]; ok {
+		t.Errorf("Expected slPrice to be omitted, got %v", m["slPrice"])
+	}
+	if _, ok := m["clientId"]; ok {
+		t.Errorf("Expected clientId to be omitted, got %v", m["clientId"])
+	}
+}
+
+func TestModifyTPSLOrderRequestMarshalJSON(t *testing.T) {
+	slPrice := 45000.0
+	slQty := 0.5
+
+	req := &ModifyTPSLOrderRequest{
+		OrderID:     "tpsl123",
+		SlPrice:     &slPrice,
+		SlQty:       &slQty,
+		SlStopType:  StopTypeMarkPrice,
+		SlOrderType: OrderTypeMarket,
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal modify TPSL order request: %v", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if m["orderId"] != "tpsl123" {
+		t.Errorf("Expected orderId \"tpsl123\", got %v", m["orderId"])
+	}
+	if m["slPrice"] != "45000" {
+		t.Errorf("Expected slPrice to be marshaled as string \"45000\", got %v", m["slPrice"])
+	}
+	if m["slQty"] != "0.5" {
+		t.Errorf("Expected slQty to be marshaled as string \"0.5\", got %v", m["slQty"])
+	}
+	if m["slStopType"] != "MARK_PRICE" {
+		t.Errorf("Expected slStopType MARK_PRICE, got %v", m["slStopType"])
+	}
+	if _, ok := m["tpPrice"]; ok {
+		t.Errorf("Expected tpPrice to be omitted, got %v", m["tpPrice"])
+	}
+	if _, ok := m["tpQty"]; ok {
+		t.Errorf("Expected tpQty to be omitted, got %v", m["tpQty"])
+	}
+}
+
+func TestPositionTPSLOrderRequestMarshalJSON(t *testing.T) {
+	tpPrice := 60000.5
+
+	req := &PositionTPSLOrderRequest{
+		Symbol:     "BTCUSDT",
+		PositionID: "position123",
+		TpPrice:    &tpPrice,
+		TpStopType: StopTypeLastPrice,
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal position TPSL order request: %v", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if m["symbol"] != "BTCUSDT" {
+		t.Errorf("Expected symbol \"BTCUSDT\", got %v", m["symbol"])
+	}
+	if m["positionId"] != "position123" {
+		t.Errorf("Expected positionId \"position123\", got %v", m["positionId"])
+	}
+	if m["tpPrice"] != "60000.5" {
+		t.Errorf("Expected tpPrice to be marshaled as string \"60000.5\", got %v", m["tpPrice"])
+	}
+	if _, ok := m["slPrice"]; ok {
+		t.Errorf("Expected slPrice to be omitted, got %v", m["slPrice"])
+	}
+	if _, ok := m["slStopType"]; ok {
+		t.Errorf("Expected slStopType to be omitted, got %v", m["slStopType"])
+	}
+}
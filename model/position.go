@@ -26,23 +26,35 @@ type PositionHistoryResponse struct {
 	} `json:"data"`
 }
 
+type HistoricalPositionDecimals struct {
+	MaxQty      Decimal
+	EntryPrice  Decimal
+	ClosePrice  Decimal
+	LiqQty      Decimal
+	Fee         Decimal
+	Funding     Decimal
+	RealizedPNL Decimal
+	LiqPrice    Decimal
+}
+
 type HistoricalPosition struct {
-	PositionID   string       `json:"positionId"`
-	Symbol       Symbol       `json:"-"`
-	MaxQty       float64      `json:"-"`
-	EntryPrice   float64      `json:"-"`
-	ClosePrice   float64      `json:"-"`
-	LiqQty       float64      `json:"-"`
-	Side         TradeSide    `json:"-"`
-	PositionMode PositionMode `json:"-"`
-	MarginMode   MarginMode   `json:"-"`
-	Leverage     int          `json:"-"`
-	Fee          float64      `json:"-"`
-	Funding      float64      `json:"-"`
-	RealizedPNL  float64      `json:"-"`
-	LiqPrice     float64      `json:"-"`
-	Ctime        time.Time    `json:"-"`
-	Mtime        time.Time    `json:"-"`
+	PositionID   string                     `json:"positionId"`
+	Symbol       Symbol                     `json:"-"`
+	MaxQty       float64                    `json:"-"`
+	EntryPrice   float64                    `json:"-"`
+	ClosePrice   float64                    `json:"-"`
+	LiqQty       float64                    `json:"-"`
+	Side         TradeSide                  `json:"-"`
+	PositionMode PositionMode               `json:"-"`
+	MarginMode   MarginMode                 `json:"-"`
+	Leverage     int                        `json:"-"`
+	Fee          float64                    `json:"-"`
+	Funding      float64                    `json:"-"`
+	RealizedPNL  float64                    `json:"-"`
+	LiqPrice     float64                    `json:"-"`
+	Ctime        time.Time                  `json:"-"`
+	Mtime        time.Time                  `json:"-"`
+	Exact        HistoricalPositionDecimals `json:"-"`
 }
 
 func (p *HistoricalPosition) UnmarshalJSON(data []byte) error {
@@ -189,7 +201,16 @@ func (p *HistoricalPosition) UnmarshalJSON(data []byte) error {
 	}
 	p.MarginMode = marginMode
 
-	return nil
+	return parseDecimalFields(
+		decimalField{"maxQty", aux.MaxQty, &p.Exact.MaxQty},
+		decimalField{"entryPrice", aux.EntryPrice, &p.Exact.EntryPrice},
+		decimalField{"closePrice", aux.ClosePrice, &p.Exact.ClosePrice},
+		decimalField{"liqQty", aux.LiqQty, &p.Exact.LiqQty},
+		decimalField{"fee", aux.Fee, &p.Exact.Fee},
+		decimalField{"funding", aux.Funding, &p.Exact.Funding},
+		decimalField{"realizedPNL", aux.RealizedPNL, &p.Exact.RealizedPNL},
+		decimalField{"liqPrice", aux.LiqPrice, &p.Exact.LiqPrice},
+	)
 }
 
 type PendingPositionParams struct {
@@ -202,25 +223,39 @@ type PendingPositionResponse struct {
 	Data []PendingPosition `json:"data"`
 }
 
+type PendingPositionDecimals struct {
+	Qty           Decimal
+	EntryValue    Decimal
+	Fees          Decimal
+	Funding       Decimal
+	RealizedPNL   Decimal
+	Margin        Decimal
+	UnrealizedPNL Decimal
+	LiqPrice      Decimal
+	MarginRate    Decimal
+	AvgOpenPrice  Decimal
+}
+
 type PendingPosition struct {
-	PositionID    string       `json:"positionId"`
-	Symbol        Symbol       `json:"-"`
-	Qty           float64      `json:"-"`
-	EntryValue    float64      `json:"-"`
-	Side          TradeSide    `json:"-"`
-	MarginMode    MarginMode   `json:"-"`
-	PositionMode  PositionMode `json:"-"`
-	Leverage      int          `json:"-"`
-	Fees          float64      `json:"-"`
-	Funding       float64      `json:"-"`
-	RealizedPNL   float64      `json:"-"`
-	Margin        float64      `json:"-"`
-	UnrealizedPNL float64      `json:"-"`
-	LiqPrice      float64      `json:"-"`
-	MarginRate    float64      `json:"-"`
-	AvgOpenPrice  float64      `json:"-"`
-	CreateTime    time.Time    `json:"-"`
-	ModifyTime    time.Time    `json:"-"`
+	PositionID    string                  `json:"positionId"`
+	Symbol        Symbol                  `json:"-"`
+	Qty           float64                 `json:"-"`
+	EntryValue    float64                 `json:"-"`
+	Side          TradeSide               `json:"-"`
+	MarginMode    MarginMode              `json:"-"`
+	PositionMode  PositionMode            `json:"-"`
+	Leverage      int                     `json:"-"`
+	Fees          float64                 `json:"-"`
+	Funding       float64                 `json:"-"`
+	RealizedPNL   float64                 `json:"-"`
+	Margin        float64                 `json:"-"`
+	UnrealizedPNL float64                 `json:"-"`
+	LiqPrice      float64                 `json:"-"`
+	MarginRate    float64                 `json:"-"`
+	AvgOpenPrice  float64                 `json:"-"`
+	CreateTime    time.Time               `json:"-"`
+	ModifyTime    time.Time               `json:"-"`
+	Exact         PendingPositionDecimals `json:"-"`
 }
 
 func (p *PendingPosition) UnmarshalJSON(data []byte) error {
@@ -370,5 +405,87 @@ func (p *PendingPosition) UnmarshalJSON(data []byte) error {
 	}
 	p.PositionMode = positionMode
 
-	return nil
+	return parseDecimalFields(
+		decimalField{"qty", aux.Qty, &p.Exact.Qty},
+		decimalField{"entryValue", aux.EntryValue, &p.Exact.EntryValue},
+		decimalField{"fees", aux.Fees, &p.Exact.Fees},
+		decimalField{"funding", aux.Funding, &p.Exact.Funding},
+		decimalField{"realizedPNL", aux.RealizedPNL, &p.Exact.RealizedPNL},
+		decimalField{"margin", aux.Margin, &p.Exact.Margin},
+		decimalField{"unrealizedPNL", aux.UnrealizedPNL, &p.Exact.UnrealizedPNL},
+		decimalField{"liqPrice", aux.LiqPrice, &p.Exact.LiqPrice},
+		decimalField{"marginRate", aux.MarginRate, &p.Exact.MarginRate},
+		decimalField{"avgOpenPrice", aux.AvgOpenPrice, &p.Exact.AvgOpenPrice},
+	)
+}
+
+type AdjustPositionMarginRequest struct {
+	Symbol     Symbol       `json:"symbol"`
+	MarginCoin MarginCoin   `json:"marginCoin"`
+	Amount     float64      `json:"-"`
+	Side       PositionSide `json:"side,omitempty"`
+	PositionID string       `json:"positionId,omitempty"`
+}
+
+func (r *AdjustPositionMarginRequest) MarshalJSON() ([]byte, error) {
+	type Alias AdjustPositionMarginRequest
+
+	aux := &struct {
+		Amount string `json:"amount"`
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+
+	aux.Amount = strconv.FormatFloat(r.Amount, 'f', -1, 64)
+
+	return json.Marshal(aux)
+}
+
+type AdjustPositionMarginResponse struct {
+	BaseResponse
+	Data AdjustPositionMarginData `json:"data"`
+}
+
+// AdjustPositionMarginData is the margin of the position after the adjustment
+type AdjustPositionMarginData struct {
+	PositionID string     `json:"positionId"`
+	Symbol     Symbol     `json:"symbol"`
+	MarginCoin MarginCoin `json:"marginCoin"`
+	Margin     Decimal    `json:"margin"`
+}
+
+type FlashClosePositionRequest struct {
+	PositionID string `json:"positionId"`
+}
+
+type FlashClosePositionResponse struct {
+	BaseResponse
+	Data ClosePositionResult `json:"data"`
+}
+
+// ClosePositionResult is the market order placed to close a position
+type ClosePositionResult struct {
+	PositionID string `json:"positionId"`
+	OrderID    string `json:"orderId"`
+}
+
+type ClosePositionFailure struct {
+	PositionID string `json:"positionId"`
+	ErrorMsg   string `json:"errorMsg"`
+	ErrorCode  string `json:"errorCode"`
+}
+
+type CloseAllPositionsRequest struct {
+	Symbol Symbol `json:"symbol,omitempty"`
+}
+
+type CloseAllPositionsResponse struct {
+	BaseResponse
+	Data CloseAllPositionsData `json:"data"`
+}
+
+type CloseAllPositionsData struct {
+	SuccessList []ClosePositionResult  `json:"successList"`
+	FailureList []ClosePositionFailure `json:"failureList"`
 }
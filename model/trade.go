@@ -25,24 +25,32 @@ type TradeHistoryResponse struct {
 	} `json:"data"`
 }
 
+type HistoricalTradeDecimals struct {
+	Quantity    Decimal
+	Price       Decimal
+	Fee         Decimal
+	RealizedPNL Decimal
+}
+
 type HistoricalTrade struct {
-	TradeID      string        `json:"tradeId"`
-	OrderID      string        `json:"orderId"`
-	Symbol       Symbol        `json:"symbol"`
-	Quantity     float64       `json:"-"`
-	PositionMode PositionMode  `json:"-"`
-	MarginMode   MarginMode    `json:"-"`
-	Leverage     int           `json:"leverage"`
-	Price        float64       `json:"-"`
-	Side         TradeSide     `json:"-"`
-	OrderType    OrderType     `json:"-"`
-	Effect       string        `json:"effect"`
-	ClientID     string        `json:"clientId"`
-	ReduceOnly   bool          `json:"reduceOnly"`
-	Fee          float64       `json:"-"`
-	RealizedPNL  float64       `json:"-"`
-	CreateTime   time.Time     `json:"-"`
-	RoleType     TradeRoleType `json:"-"`
+	TradeID      string                  `json:"tradeId"`
+	OrderID      string                  `json:"orderId"`
+	Symbol       Symbol                  `json:"symbol"`
+	Quantity     float64                 `json:"-"`
+	PositionMode PositionMode            `json:"-"`
+	MarginMode   MarginMode              `json:"-"`
+	Leverage     int                     `json:"leverage"`
+	Price        float64                 `json:"-"`
+	Side         TradeSide               `json:"-"`
+	OrderType    OrderType               `json:"-"`
+	Effect       string                  `json:"effect"`
+	ClientID     string                  `json:"clientId"`
+	ReduceOnly   bool                    `json:"reduceOnly"`
+	Fee          float64                 `json:"-"`
+	RealizedPNL  float64                 `json:"-"`
+	CreateTime   time.Time               `json:"-"`
+	RoleType     TradeRoleType           `json:"-"`
+	Exact        HistoricalTradeDecimals `json:"-"`
 }
 
 func (t *HistoricalTrade) UnmarshalJSON(data []byte) error {
@@ -145,5 +153,10 @@ func (t *HistoricalTrade) UnmarshalJSON(data []byte) error {
 	}
 	t.RoleType = roleType
 
-	return nil
+	return parseDecimalFields(
+		decimalField{"qty", aux.Quantity, &t.Exact.Quantity},
+		decimalField{"price", aux.Price, &t.Exact.Price},
+		decimalField{"fee", aux.Fee, &t.Exact.Fee},
+		decimalField{"realizedPNL", aux.RealizedPNL, &t.Exact.RealizedPNL},
+	)
 }
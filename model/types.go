@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 type StopType string
@@ -313,6 +314,15 @@ func (s OrderStatus) IsValid() bool {
 	return false
 }
 
+// IsTerminal reports whether the order can no longer change
+func (s OrderStatus) IsTerminal() bool {
+	switch s {
+	case OrderStatusCanceled, OrderStatusSystemCanceled, OrderStatusExpired, OrderStatusFilled:
+		return true
+	}
+	return false
+}
+
 func (s OrderStatus) String() string {
 	return string(s)
 }
@@ -582,6 +592,42 @@ func (s Interval) IsValid() bool {
 	return false
 }
 
+// Duration returns the length of the interval, or 0 for months which have no fixed length
+func (s Interval) Duration() time.Duration {
+	switch s.Normalize() {
+	case Interval1Min:
+		return time.Minute
+	case Interval3Min:
+		return 3 * time.Minute
+	case Interval5Min:
+		return 5 * time.Minute
+	case Interval15Min:
+		return 15 * time.Minute
+	case Interval30Min:
+		return 30 * time.Minute
+	case Interval60Min:
+		return time.Hour
+	case Interval2H:
+		return 2 * time.Hour
+	case Interval4H:
+		return 4 * time.Hour
+	case Interval6H:
+		return 6 * time.Hour
+	case Interval8H:
+		return 8 * time.Hour
+	case Interval12H:
+		return 12 * time.Hour
+	case Interval1Day:
+		return 24 * time.Hour
+	case Interval3Day:
+		return 72 * time.Hour
+	case Interval1Week:
+		return 7 * 24 * time.Hour
+	}
+
+	return 0
+}
+
 func (s Interval) Normalize() Interval {
 	normalized := strings.ToLower(strings.TrimSpace(string(s)))
 
@@ -662,7 +708,13 @@ func (s PriceType) Normalize() PriceType {
 	return PriceType(normalized)
 }
 
-const ChannelKline Channel = "kline"
+const (
+	ChannelKline   Channel = "kline"
+	ChannelTrade   Channel = "trade"
+	ChannelTicker  Channel = "ticker"
+	ChannelTickers Channel = "tickers"
+	ChannelPrice   Channel = "price"
+)
 
 type Channel string
 
@@ -682,7 +734,7 @@ func ParseChannel(s string) (Channel, error) {
 
 func (s Channel) IsValid() bool {
 	switch s {
-	case ChannelKline:
+	case ChannelKline, ChannelTrade, ChannelTicker, ChannelTickers, ChannelPrice:
 		return true
 	}
 
@@ -695,11 +747,73 @@ func (s Channel) Normalize() Channel {
 	switch normalized {
 	case "kline", "k", "candle", "candlestick":
 		return ChannelKline
+	case "trade", "trades":
+		return ChannelTrade
+	case "ticker":
+		return ChannelTicker
+	case "tickers":
+		return ChannelTickers
+	case "price", "funding":
+		return ChannelPrice
 	}
 
 	return Channel(normalized)
 }
 
+const (
+	DepthBook1  DepthBook = "book1"
+	DepthBook5  DepthBook = "book5"
+	DepthBook15 DepthBook = "book15"
+	// DepthBooks streams incremental updates instead of snapshots
+	DepthBooks DepthBook = "books"
+)
+
+type DepthBook string
+
+func (s DepthBook) String() string {
+	return string(s)
+}
+
+func ParseDepthBook(s string) (DepthBook, error) {
+	book := DepthBook(s).Normalize()
+
+	if !book.IsValid() {
+		return book, fmt.Errorf("%s is not a valid depth book", s)
+	}
+
+	return book, nil
+}
+
+func (s DepthBook) IsValid() bool {
+	switch s {
+	case DepthBook1, DepthBook5, DepthBook15, DepthBooks:
+		return true
+	}
+
+	return false
+}
+
+func (s DepthBook) IsIncremental() bool {
+	return s == DepthBooks
+}
+
+func (s DepthBook) Normalize() DepthBook {
+	normalized := strings.ToLower(strings.TrimSpace(string(s)))
+
+	switch normalized {
+	case "book1", "1":
+		return DepthBook1
+	case "book5", "5":
+		return DepthBook5
+	case "book15", "15":
+		return DepthBook15
+	case "books", "incremental":
+		return DepthBooks
+	}
+
+	return DepthBook(normalized)
+}
+
 type LogLevel string
 
 const (
@@ -13,16 +13,28 @@ type BalanceChannelMessage struct {
 	Data BalanceEvent `json:"data"`
 }
 
+type BalanceEventDecimals struct {
+	Available       Decimal
+	Frozen          Decimal
+	IsolationFrozen Decimal
+	CrossFrozen     Decimal
+	Margin          Decimal
+	IsolationMargin Decimal
+	CrossMargin     Decimal
+	ExpMoney        Decimal
+}
+
 type BalanceEvent struct {
-	Coin            string  `json:"coin"`
-	Available       float64 `json:"-"`
-	Frozen          float64 `json:"-"`
-	IsolationFrozen float64 `json:"-"`
-	CrossFrozen     float64 `json:"-"`
-	Margin          float64 `json:"-"`
-	IsolationMargin float64 `json:"-"`
-	CrossMargin     float64 `json:"-"`
-	ExpMoney        float64 `json:"-"`
+	Coin            string               `json:"coin"`
+	Available       float64              `json:"-"`
+	Frozen          float64              `json:"-"`
+	IsolationFrozen float64              `json:"-"`
+	CrossFrozen     float64              `json:"-"`
+	Margin          float64              `json:"-"`
+	IsolationMargin float64              `json:"-"`
+	CrossMargin     float64              `json:"-"`
+	ExpMoney        float64              `json:"-"`
+	Exact           BalanceEventDecimals `json:"-"`
 }
 
 func (p *BalanceEvent) UnmarshalJSON(data []byte) error {
@@ -101,25 +113,45 @@ func (p *BalanceEvent) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("failed to parse expMoney: %w", err)
 	}
 
-	return nil
+	return parseDecimalFields(
+		decimalField{"available", aux.Available, &p.Exact.Available},
+		decimalField{"frozen", aux.Frozen, &p.Exact.Frozen},
+		decimalField{"isolationFrozen", aux.IsolationFrozen, &p.Exact.IsolationFrozen},
+		decimalField{"crossFrozen", aux.CrossFrozen, &p.Exact.CrossFrozen},
+		decimalField{"margin", aux.Margin, &p.Exact.Margin},
+		decimalField{"isolationMargin", aux.IsolationMargin, &p.Exact.IsolationMargin},
+		decimalField{"crossMargin", aux.CrossMargin, &p.Exact.CrossMargin},
+		decimalField{"expMoney", aux.ExpMoney, &p.Exact.ExpMoney},
+	)
+}
+
+type PositionEventDecimals struct {
+	Margin        Decimal
+	Quantity      Decimal
+	EntryValue    Decimal
+	RealizedPNL   Decimal
+	UnrealizedPNL Decimal
+	Funding       Decimal
+	Fee           Decimal
 }
 
 type PositionEvent struct {
-	Event         PositionEventType `json:"-"`
-	PositionID    string            `json:"positionId"`
-	MarginMode    MarginMode        `json:"-"`
-	PositionMode  PositionMode      `json:"-"`
-	Side          PositionSide      `json:"-"`
-	Leverage      int               `json:"-"`
-	Margin        float64           `json:"-"`
-	CreateTime    time.Time         `json:"-"`
-	Quantity      float64           `json:"-"`
-	EntryValue    float64           `json:"-"`
-	Symbol        Symbol            `json:"symbol"`
-	RealizedPNL   float64           `json:"-"`
-	UnrealizedPNL float64           `json:"-"`
-	Funding       float64           `json:"-"`
-	Fee           float64           `json:"-"`
+	Event         PositionEventType     `json:"-"`
+	PositionID    string                `json:"positionId"`
+	MarginMode    MarginMode            `json:"-"`
+	PositionMode  PositionMode          `json:"-"`
+	Side          PositionSide          `json:"-"`
+	Leverage      int                   `json:"-"`
+	Margin        float64               `json:"-"`
+	CreateTime    time.Time             `json:"-"`
+	Quantity      float64               `json:"-"`
+	EntryValue    float64               `json:"-"`
+	Symbol        Symbol                `json:"symbol"`
+	RealizedPNL   float64               `json:"-"`
+	UnrealizedPNL float64               `json:"-"`
+	Funding       float64               `json:"-"`
+	Fee           float64               `json:"-"`
+	Exact         PositionEventDecimals `json:"-"`
 }
 
 func (p *PositionEvent) UnmarshalJSON(data []byte) error {
@@ -254,7 +286,15 @@ func (p *PositionEvent) UnmarshalJSON(data []byte) error {
 		}
 	}
 
-	return nil
+	return parseDecimalFields(
+		decimalField{"margin", aux.Margin, &p.Exact.Margin},
+		decimalField{"qty", aux.Quantity, &p.Exact.Quantity},
+		decimalField{"entryValue", aux.EntryValue, &p.Exact.EntryValue},
+		decimalField{"realizedPNL", aux.RealizedPNL, &p.Exact.RealizedPNL},
+		decimalField{"unrealizedPNL", aux.UnrealizedPNL, &p.Exact.UnrealizedPNL},
+		decimalField{"funding", aux.Funding, &p.Exact.Funding},
+		decimalField{"fee", aux.Fee, &p.Exact.Fee},
+	)
 }
 
 type PositionChannelMessage struct {
@@ -263,6 +303,12 @@ type PositionChannelMessage struct {
 	Data      PositionEvent `json:"data"`
 }
 
+type OrderEventDecimals struct {
+	Quantity Decimal
+	Price    Decimal
+	Fee      Decimal
+}
+
 type OrderEvent struct {
 	Event        OrderEventType `json:"-"`
 	OrderID      string         `json:"orderId"`
@@ -294,6 +340,7 @@ type OrderEvent struct {
 		SLOrderType OrderType `json:"-"`
 		SLOrderPrice float64 `json:"-"`
 	*/
+	Exact OrderEventDecimals `json:"-"`
 }
 
 func (o *OrderEvent) UnmarshalJSON(data []byte) error {
@@ -423,7 +470,11 @@ func (o *OrderEvent) UnmarshalJSON(data []byte) error {
 		}
 	}
 
-	return nil
+	return parseDecimalFields(
+		decimalField{"qty", aux.Quantity, &o.Exact.Quantity},
+		decimalField{"price", aux.Price, &o.Exact.Price},
+		decimalField{"fee", aux.Fee, &o.Exact.Fee},
+	)
 }
 
 type OrderChannelMessage struct {
@@ -463,13 +514,23 @@ func (k *KLineChannelMessage) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type KLineEventDecimals struct {
+	OpenPrice   Decimal
+	HighPrice   Decimal
+	LowPrice    Decimal
+	ClosePrice  Decimal
+	BaseVolume  Decimal
+	QuoteVolume Decimal
+}
+
 type KLineEvent struct {
-	OpenPrice   float64 `json:"-"`
-	HighPrice   float64 `json:"-"`
-	LowPrice    float64 `json:"-"`
-	ClosePrice  float64 `json:"-"`
-	BaseVolume  float64 `json:"-"`
-	QuoteVolume float64 `json:"-"`
+	OpenPrice   float64            `json:"-"`
+	HighPrice   float64            `json:"-"`
+	LowPrice    float64            `json:"-"`
+	ClosePrice  float64            `json:"-"`
+	BaseVolume  float64            `json:"-"`
+	QuoteVolume float64            `json:"-"`
+	Exact       KLineEventDecimals `json:"-"`
 }
 
 func (k *KLineEvent) UnmarshalJSON(data []byte) error {
@@ -532,7 +593,14 @@ func (k *KLineEvent) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("failed to parse quote volume: %w", err)
 	}
 
-	return nil
+	return parseDecimalFields(
+		decimalField{"open price", aux.OpenPrice, &k.Exact.OpenPrice},
+		decimalField{"high price", aux.HighPrice, &k.Exact.HighPrice},
+		decimalField{"low price", aux.LowPrice, &k.Exact.LowPrice},
+		decimalField{"close price", aux.ClosePrice, &k.Exact.ClosePrice},
+		decimalField{"base volume", aux.BaseVolume, &k.Exact.BaseVolume},
+		decimalField{"quote volume", aux.QuoteVolume, &k.Exact.QuoteVolume},
+	)
 }
 
 type TpSlOrderEvent struct {
@@ -733,3 +801,352 @@ func (t *TpSlOrderEvent) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+type DepthChannelMessage struct {
+	Channel string     `json:"ch"`
+	Symbol  Symbol     `json:"-"`
+	Ts      int64      `json:"ts"`
+	Data    DepthEvent `json:"data"`
+}
+
+func (d *DepthChannelMessage) UnmarshalJSON(data []byte) error {
+	type Alias DepthChannelMessage
+	aux := &struct {
+		Symbol string `json:"symbol"`
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	d.Symbol = ParseSymbol(aux.Symbol).Normalize()
+
+	return nil
+}
+
+// DepthEvent holds the levels of a depth message. Snapshots carry the full book
+// up to the channel depth, incremental updates only the changed levels where a
+// quantity of zero removes the level.
+type DepthEvent struct {
+	Asks         []PriceLevel `json:"a"`
+	Bids         []PriceLevel `json:"b"`
+	Sequence     int64        `json:"seq"`
+	PrevSequence int64        `json:"prevSeq"`
+}
+
+type TradeChannelMessage struct {
+	Channel string       `json:"ch"`
+	Symbol  Symbol       `json:"-"`
+	Ts      int64        `json:"ts"`
+	Data    []TradeEvent `json:"data"`
+}
+
+func (t *TradeChannelMessage) UnmarshalJSON(data []byte) error {
+	type Alias TradeChannelMessage
+	aux := &struct {
+		Symbol string `json:"symbol"`
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	t.Symbol = ParseSymbol(aux.Symbol).Normalize()
+
+	return nil
+}
+
+type TradeEventDecimals struct {
+	Price  Decimal
+	Volume Decimal
+}
+
+type TradeEvent struct {
+	Time   time.Time          `json:"-"`
+	Price  float64            `json:"-"`
+	Volume float64            `json:"-"`
+	Side   TradeSide          `json:"-"`
+	Exact  TradeEventDecimals `json:"-"`
+}
+
+func (t *TradeEvent) UnmarshalJSON(data []byte) error {
+	aux := &struct {
+		Time   string `json:"t"`
+		Price  string `json:"p"`
+		Volume string `json:"v"`
+		Side   string `json:"s"`
+	}{}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.Time != "" {
+		tradeTime, err := time.Parse(time.RFC3339Nano, aux.Time)
+		if err != nil {
+			return fmt.Errorf("failed to parse trade time: %w", err)
+		}
+		t.Time = tradeTime
+	}
+
+	price, err := strconv.ParseFloat(aux.Price, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse price: %w", err)
+	}
+	t.Price = price
+
+	volume, err := strconv.ParseFloat(aux.Volume, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse volume: %w", err)
+	}
+	t.Volume = volume
+
+	side, err := ParseTradeSide(aux.Side)
+	if err != nil {
+		return fmt.Errorf("failed to parse side: %w", err)
+	}
+	t.Side = side
+
+	return parseDecimalFields(
+		decimalField{"price", aux.Price, &t.Exact.Price},
+		decimalField{"volume", aux.Volume, &t.Exact.Volume},
+	)
+}
+
+type TickerChannelMessage struct {
+	Channel string      `json:"ch"`
+	Symbol  Symbol      `json:"-"`
+	Ts      int64       `json:"ts"`
+	Data    TickerEvent `json:"data"`
+}
+
+func (t *TickerChannelMessage) UnmarshalJSON(data []byte) error {
+	type Alias TickerChannelMessage
+	aux := &struct {
+		Symbol string `json:"symbol"`
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	t.Symbol = ParseSymbol(aux.Symbol).Normalize()
+
+	return nil
+}
+
+type TickerEventDecimals struct {
+	LastPrice   Decimal
+	OpenPrice   Decimal
+	HighPrice   Decimal
+	LowPrice    Decimal
+	BaseVolume  Decimal
+	QuoteVolume Decimal
+	ChangeRate  Decimal
+}
+
+type TickerEvent struct {
+	LastPrice   float64             `json:"-"`
+	OpenPrice   float64             `json:"-"`
+	HighPrice   float64             `json:"-"`
+	LowPrice    float64             `json:"-"`
+	BaseVolume  float64             `json:"-"`
+	QuoteVolume float64             `json:"-"`
+	ChangeRate  float64             `json:"-"`
+	Exact       TickerEventDecimals `json:"-"`
+}
+
+type tickerEventFields struct {
+	LastPrice   string `json:"la"`
+	OpenPrice   string `json:"o"`
+	HighPrice   string `json:"h"`
+	LowPrice    string `json:"l"`
+	BaseVolume  string `json:"b"`
+	QuoteVolume string `json:"q"`
+	ChangeRate  string `json:"r"`
+}
+
+func (f tickerEventFields) parse(t *TickerEvent) error {
+	values := []struct {
+		name   string
+		value  string
+		target *float64
+	}{
+		{"last price", f.LastPrice, &t.LastPrice},
+		{"open price", f.OpenPrice, &t.OpenPrice},
+		{"high price", f.HighPrice, &t.HighPrice},
+		{"low price", f.LowPrice, &t.LowPrice},
+		{"base volume", f.BaseVolume, &t.BaseVolume},
+		{"quote volume", f.QuoteVolume, &t.QuoteVolume},
+		{"change rate", f.ChangeRate, &t.ChangeRate},
+	}
+
+	for _, v := range values {
+		if v.value == "" {
+			continue
+		}
+
+		parsed, err := strconv.ParseFloat(v.value, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", v.name, err)
+		}
+		*v.target = parsed
+	}
+
+	return parseDecimalFields(
+		decimalField{"last price", f.LastPrice, &t.Exact.LastPrice},
+		decimalField{"open price", f.OpenPrice, &t.Exact.OpenPrice},
+		decimalField{"high price", f.HighPrice, &t.Exact.HighPrice},
+		decimalField{"low price", f.LowPrice, &t.Exact.LowPrice},
+		decimalField{"base volume", f.BaseVolume, &t.Exact.BaseVolume},
+		decimalField{"quote volume", f.QuoteVolume, &t.Exact.QuoteVolume},
+		decimalField{"change rate", f.ChangeRate, &t.Exact.ChangeRate},
+	)
+}
+
+func (t *TickerEvent) UnmarshalJSON(data []byte) error {
+	var aux tickerEventFields
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	return aux.parse(t)
+}
+
+type TickersChannelMessage struct {
+	Channel string         `json:"ch"`
+	Ts      int64          `json:"ts"`
+	Data    []TickersEvent `json:"data"`
+}
+
+// TickersEvent is the ticker of one symbol in the all-symbols tickers channel
+type TickersEvent struct {
+	Symbol Symbol `json:"-"`
+	TickerEvent
+}
+
+func (t *TickersEvent) UnmarshalJSON(data []byte) error {
+	aux := &struct {
+		Symbol string `json:"s"`
+		tickerEventFields
+	}{}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	t.Symbol = ParseSymbol(aux.Symbol).Normalize()
+
+	return aux.tickerEventFields.parse(&t.TickerEvent)
+}
+
+type PriceChannelMessage struct {
+	Channel string     `json:"ch"`
+	Symbol  Symbol     `json:"-"`
+	Ts      int64      `json:"ts"`
+	Data    PriceEvent `json:"data"`
+}
+
+func (p *PriceChannelMessage) UnmarshalJSON(data []byte) error {
+	type Alias PriceChannelMessage
+	aux := &struct {
+		Symbol string `json:"symbol"`
+		*Alias
+	}{
+		Alias: (*Alias)(p),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	p.Symbol = ParseSymbol(aux.Symbol).Normalize()
+
+	return nil
+}
+
+type PriceEventDecimals struct {
+	MarkPrice   Decimal
+	IndexPrice  Decimal
+	FundingRate Decimal
+}
+
+type PriceEvent struct {
+	MarkPrice       float64            `json:"-"`
+	IndexPrice      float64            `json:"-"`
+	FundingRate     float64            `json:"-"`
+	FundingTime     time.Time          `json:"-"`
+	NextFundingTime time.Time          `json:"-"`
+	Exact           PriceEventDecimals `json:"-"`
+}
+
+func (p *PriceEvent) UnmarshalJSON(data []byte) error {
+	aux := &struct {
+		MarkPrice       string `json:"mp"`
+		IndexPrice      string `json:"ip"`
+		FundingRate     string `json:"fr"`
+		FundingTime     string `json:"ft"`
+		NextFundingTime string `json:"nft"`
+	}{}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	markPrice, err := strconv.ParseFloat(aux.MarkPrice, 64)
+	if err == nil {
+		p.MarkPrice = markPrice
+	} else {
+		return fmt.Errorf("failed to parse mark price: %w", err)
+	}
+
+	indexPrice, err := strconv.ParseFloat(aux.IndexPrice, 64)
+	if err == nil {
+		p.IndexPrice = indexPrice
+	} else {
+		return fmt.Errorf("failed to parse index price: %w", err)
+	}
+
+	fundingRate, err := strconv.ParseFloat(aux.FundingRate, 64)
+	if err == nil {
+		p.FundingRate = fundingRate
+	} else {
+		return fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+
+	if p.FundingTime, err = parseEventTime(aux.FundingTime); err != nil {
+		return fmt.Errorf("failed to parse funding time: %w", err)
+	}
+
+	if p.NextFundingTime, err = parseEventTime(aux.NextFundingTime); err != nil {
+		return fmt.Errorf("failed to parse next funding time: %w", err)
+	}
+
+	return parseDecimalFields(
+		decimalField{"mark price", aux.MarkPrice, &p.Exact.MarkPrice},
+		decimalField{"index price", aux.IndexPrice, &p.Exact.IndexPrice},
+		decimalField{"funding rate", aux.FundingRate, &p.Exact.FundingRate},
+	)
+}
+
+// parseEventTime accepts both RFC 3339 timestamps and milliseconds since the epoch
+func parseEventTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(millis), nil
+	}
+
+	return time.Parse(time.RFC3339Nano, value)
+}
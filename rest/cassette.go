@@ -0,0 +1,232 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tradingiq/bitunix-client/errors"
+)
+
+// RedactedValue replaces credentials in recorded headers
+const RedactedValue = "REDACTED"
+
+// redactedHeaders carry the API key and the request signature
+var redactedHeaders = []string{"Api-Key", "Sign"}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Cassette is a sequence of recorded HTTP exchanges, stored as JSON
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to read cassette", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to decode cassette %s", path), err)
+	}
+	return &cassette, nil
+}
+
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.NewInternalError("failed to encode cassette", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.NewInternalError("failed to write cassette", err)
+	}
+	return nil
+}
+
+// Recorder is an http.RoundTripper that sends requests through transport and
+// records every exchange. The Api-Key and Sign headers are redacted.
+type Recorder struct {
+	transport http.RoundTripper
+	mu        sync.Mutex
+	cassette  Cassette
+}
+
+// NewRecorder records the exchanges of transport, http.DefaultTransport when nil
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{transport: transport}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	header := req.Header.Clone()
+	for _, name := range redactedHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, RedactedValue)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  RecordedRequest{Method: req.Method, URL: req.URL.String(), Header: header, Body: string(requestBody)},
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: string(responseBody)},
+	})
+	return resp, nil
+}
+
+// readRequestBody reads a copy of the body, leaving the request untouched
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errors.NewInternalError("request body cannot be recorded without GetBody", nil)
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+// Cassette returns the exchanges recorded so far
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Cassette{Interactions: slices.Clone(r.cassette.Interactions)}
+}
+
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// Player is an http.RoundTripper that answers requests from a cassette
+// instead of the network. A request is answered with the first unplayed
+// interaction of the same method, path and query, the order of query
+// parameters does not matter. Every interaction is played once.
+type Player struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	played       []bool
+}
+
+func NewPlayer(cassette *Cassette) *Player {
+	return &Player{
+		interactions: cassette.Interactions,
+		played:       make([]bool, len(cassette.Interactions)),
+	}
+}
+
+func LoadPlayer(path string) (*Player, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewPlayer(cassette), nil
+}
+
+func (p *Player) RoundTrip(req *http.Request) (*http.Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, interaction := range p.interactions {
+		if p.played[i] || !matchesRequest(interaction.Request, req) {
+			continue
+		}
+
+		p.played[i] = true
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, errors.NewInternalError(fmt.Sprintf("no recorded interaction for %s %s", req.Method, req.URL.RequestURI()), nil)
+}
+
+// Remaining is the number of interactions that have not been played
+func (p *Player) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var remaining int
+	for _, played := range p.played {
+		if !played {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+func matchesRequest(recorded RecordedRequest, req *http.Request) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil || recordedURL.Path != req.URL.Path {
+		return false
+	}
+
+	return maps.EqualFunc(recordedURL.Query(), req.URL.Query(), slices.Equal)
+}
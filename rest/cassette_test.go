@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/model"
+)
+
+func TestRecorderAndPlayer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"code":0,"data":` + string(body) + `}`))
+			return
+		}
+		w.Write([]byte(`{"code":0,"data":"` + r.URL.Query().Get("symbol") + `"}`))
+	}))
+	defer server.Close()
+
+	signer := func(req *http.Request, body []byte) error {
+		req.Header.Set("Api-Key", "secret-key")
+		req.Header.Set("Sign", "secret-signature")
+		req.Header.Set("Nonce", "nonce")
+		return nil
+	}
+
+	recorder := NewRecorder(nil)
+	live, err := New(server.URL, WithTransport(recorder), WithRequestSigner(signer), WithLogLevel(model.LogLevelNone))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	requests := []url.Values{
+		{"symbol": {"BTCUSDT"}, "limit": {"10"}},
+		{"symbol": {"ETHUSDT"}, "limit": {"10"}},
+	}
+	for _, query := range requests {
+		if _, err := live.Get(ctx, "/api/v1/futures/market/tickers", query); err != nil {
+			t.Fatalf("Live request failed: %v", err)
+		}
+	}
+	if _, err := live.Post(ctx, "/api/v1/futures/trade/place_order", nil, []byte(`{"qty":"1"}`)); err != nil {
+		t.Fatalf("Live request failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatalf("Failed to save cassette: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read cassette: %v", err)
+	}
+	if strings.Contains(string(data), "secret-key") || strings.Contains(string(data), "secret-signature") {
+		t.Error("Cassette contains credentials")
+	}
+
+	player, err := LoadPlayer(path)
+	if err != nil {
+		t.Fatalf("Failed to load cassette: %v", err)
+	}
+	if player.interactions[0].Request.Header.Get("Api-Key") != RedactedValue || player.interactions[0].Request.Header.Get("Nonce") != "nonce" {
+		t.Errorf("Unexpected recorded headers %v", player.interactions[0].Request.Header)
+	}
+
+	replay, err := New("http://cassette.invalid", WithTransport(player), WithLogLevel(model.LogLevelNone))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// Requests are matched by method, path and query, not by their order
+	body, err := replay.Post(ctx, "/api/v1/futures/trade/place_order", nil, []byte(`{"qty":"1"}`))
+	if err != nil || string(body) != `{"code":0,"data":{"qty":"1"}}` {
+		t.Errorf("Unexpected replayed post %s: %v", body, err)
+	}
+
+	body, err = replay.Get(ctx, "/api/v1/futures/market/tickers", url.Values{"limit": {"10"}, "symbol": {"ETHUSDT"}})
+	if err != nil || string(body) != `{"code":0,"data":"ETHUSDT"}` {
+		t.Errorf("Unexpected replayed get %s: %v", body, err)
+	}
+
+	if _, err := replay.Get(ctx, "/api/v1/futures/market/tickers", url.Values{"symbol": {"ETHUSDT"}, "limit": {"10"}}); err == nil {
+		t.Error("Expected error for an interaction that was already played")
+	}
+
+	if _, err := replay.Get(ctx, "/api/v1/futures/market/tickers", url.Values{"symbol": {"XRPUSDT"}, "limit": {"10"}}); err == nil {
+		t.Error("Expected error for an unrecorded query")
+	}
+
+	if player.Remaining() != 1 {
+		t.Errorf("Expected 1 remaining interaction, got %d", player.Remaining())
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+	c := &client{httpClient: &http.Client{}}
+	WithHTTPClient(httpClient)(c)
+	WithTransport(NewPlayer(&Cassette{}))(c)
+	WithDefaultTimeout(time.Second)(c)
+
+	if c.httpClient.Timeout != time.Second {
+		t.Errorf("Expected timeout %v, got %v", time.Second, c.httpClient.Timeout)
+	}
+	if _, ok := c.httpClient.Transport.(*Player); !ok {
+		t.Error("WithTransport option did not set the transport")
+	}
+	if httpClient.Transport != nil || httpClient.Timeout != time.Minute {
+		t.Error("Options changed the http client passed to WithHTTPClient")
+	}
+
+	if _, err := New("http://example.com", WithHTTPClient(nil), WithTransport(NewPlayer(&Cassette{}))); err == nil {
+		t.Error("Expected error for a nil http client")
+	}
+}
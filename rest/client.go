@@ -27,6 +27,9 @@ type client struct {
 	baseUri     *url.URL
 	logger      *zap.Logger
 	logLevel    model.LogLevel
+	rateLimiter *RateLimiter
+	retryPolicy *RetryPolicy
+	errorMapper func(path string, body []byte) error
 }
 
 type ClientOption func(*client)
@@ -37,10 +40,66 @@ func WithRequestSigner(requestSigner func(req *http.Request, body []byte) error)
 	}
 }
 
+func WithRateLimits(limits RateLimits) ClientOption {
+	return func(c *client) {
+		c.rateLimiter = NewRateLimiter(limits)
+	}
+}
+
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *client) {
+		c.rateLimiter = limiter
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *client) {
+		c.retryPolicy = &policy
+	}
+}
+
+// WithResponseErrorMapper lets the retry policy see API errors that are
+// reported inside successful HTTP responses, such as rate limit codes.
+func WithResponseErrorMapper(mapper func(path string, body []byte) error) ClientOption {
+	return func(c *client) {
+		c.errorMapper = mapper
+	}
+}
+
 func WithDefaultTimeout(timeout time.Duration) ClientOption {
 	return func(c *client) {
-		c.httpClient.Timeout = timeout
+		if httpClient := c.copyHTTPClient(); httpClient != nil {
+			httpClient.Timeout = timeout
+		}
+	}
+}
+
+// WithHTTPClient sends requests through httpClient, options such as WithTransport change a copy of it
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTransport sends requests through transport, e.g. a Recorder or a Player
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *client) {
+		if httpClient := c.copyHTTPClient(); httpClient != nil {
+			httpClient.Transport = transport
+		}
+	}
+}
+
+// copyHTTPClient replaces the http client with a copy that options can change
+// without touching a client passed to WithHTTPClient, such as http.DefaultClient
+func (c *client) copyHTTPClient() *http.Client {
+	if c.httpClient == nil {
+		return nil
 	}
+
+	cp := *c.httpClient
+	c.httpClient = &cp
+	return c.httpClient
 }
 
 func WithDebug(enabled bool) ClientOption {
@@ -104,6 +163,10 @@ func New(baseUri string, options ...ClientOption) (Client, error) {
 		option(c)
 	}
 
+	if c.httpClient == nil {
+		return nil, errors.NewValidationError("httpClient", "cannot be nil", nil)
+	}
+
 	if c.logger == nil {
 		c.logger = createLoggerForLevel(c.logLevel)
 	}
@@ -126,6 +189,12 @@ func (c *client) request(ctx context.Context, method, path string, query url.Val
 			zap.Int("body_size", len(bodyBytes)))
 	}
 
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx, path); err != nil {
+			return nil, err
+		}
+	}
+
 	reqURL := *c.baseUri
 	reqURL.Path = path
 
@@ -143,12 +212,14 @@ func (c *client) request(ctx context.Context, method, path string, query url.Val
 
 	req.Header.Set("Content-Type", "application/json")
 
-	if c.logLevel.ShouldLog(model.LogLevelVeryAggressive) {
-		c.logger.Debug("signing request with authentication")
-	}
+	if c.signRequest != nil {
+		if c.logLevel.ShouldLog(model.LogLevelVeryAggressive) {
+			c.logger.Debug("signing request with authentication")
+		}
 
-	if err := c.signRequest(req, bodyBytes); err != nil {
-		return nil, errors.NewAuthenticationError("failed to sign request", err)
+		if err := c.signRequest(req, bodyBytes); err != nil {
+			return nil, errors.NewAuthenticationError("failed to sign request", err)
+		}
 	}
 
 	c.logRequest(req, bodyBytes)
@@ -215,6 +286,10 @@ func (c *client) request(ctx context.Context, method, path string, query url.Val
 }
 
 func (c *client) Get(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	if c.retryPolicy != nil {
+		return c.requestWithRetry(ctx, http.MethodGet, path, query, nil)
+	}
+
 	respBody, err := c.request(ctx, http.MethodGet, path, query, nil)
 	if err != nil {
 		return nil, err
@@ -223,6 +298,42 @@ func (c *client) Get(ctx context.Context, path string, query url.Values) ([]byte
 	return respBody, nil
 }
 
+func (c *client) requestWithRetry(ctx context.Context, method, path string, query url.Values, bodyBytes []byte) ([]byte, error) {
+	var respBody []byte
+
+	err := c.retryPolicy.Do(ctx, func() error {
+		body, err := c.request(ctx, method, path, query, bodyBytes)
+		if err != nil {
+			respBody = nil
+			c.logRetry(path, err)
+			return err
+		}
+
+		respBody = body
+		if c.errorMapper != nil {
+			if err := c.errorMapper(path, body); err != nil {
+				c.logRetry(path, err)
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	// API errors are left in the body so callers decode them as usual
+	if err != nil && respBody == nil {
+		return nil, err
+	}
+
+	return respBody, nil
+}
+
+func (c *client) logRetry(path string, err error) {
+	if c.logLevel.ShouldLog(model.LogLevelAggressive) && c.retryPolicy.IsRetryable(err) {
+		c.logger.Debug("retryable request failure", zap.String("path", path), zap.Error(err))
+	}
+}
+
 func (c *client) Post(ctx context.Context, path string, query url.Values, body []byte) ([]byte, error) {
 	respBody, err := c.request(ctx, http.MethodPost, path, query, body)
 	if err != nil {
@@ -99,6 +99,31 @@ func TestClientRequest(t *testing.T) {
 	}
 }
 
+func TestClientRequestWithoutSigner(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("sign") != "" || r.Header.Get("api-key") != "" {
+			t.Errorf("Expected unsigned request, got headers %v", r.Header)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resp, err := client.Get(context.Background(), "/public/path", nil)
+	if err != nil {
+		t.Fatalf("GET request failed: %v", err)
+	}
+	if string(resp) != `{"success":true}` {
+		t.Errorf("Expected response {\"success\":true}, got %s", string(resp))
+	}
+}
+
 func TestClientRequestErrors(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+)
+
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+func (l RateLimit) enabled() bool {
+	return l.Requests > 0 && l.Per > 0
+}
+
+type RateLimits struct {
+	Default   RateLimit
+	Endpoints map[string]RateLimit
+}
+
+type RateLimiter struct {
+	limits  RateLimits
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+func NewRateLimiter(limits RateLimits) *RateLimiter {
+	return &RateLimiter{
+		limits:  limits,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Wait blocks until a request to path may be sent. If the context deadline
+// expires before a token becomes available it fails immediately instead.
+func (r *RateLimiter) Wait(ctx context.Context, path string) error {
+	bucket := r.bucket(path)
+	if bucket == nil {
+		return nil
+	}
+
+	wait, ok := bucket.reserve(ctx, r.now())
+	if !ok {
+		return errors.NewRateLimitError(path, wait.String(), nil)
+	}
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		bucket.cancel()
+		return errors.NewRateLimitError(path, "", ctx.Err())
+	}
+}
+
+func (r *RateLimiter) bucket(path string) *tokenBucket {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if bucket, ok := r.buckets[path]; ok {
+		return bucket
+	}
+
+	limit, ok := r.limits.Endpoints[path]
+	if !ok {
+		limit = r.limits.Default
+	}
+
+	if !limit.enabled() {
+		r.buckets[path] = nil
+		return nil
+	}
+
+	bucket := newTokenBucket(limit, r.now())
+	r.buckets[path] = bucket
+	return bucket
+}
+
+type tokenBucket struct {
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+	mu       sync.Mutex
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	capacity := limit.Burst
+	if capacity <= 0 {
+		capacity = limit.Requests
+	}
+
+	return &tokenBucket{
+		rate:     float64(limit.Requests) / limit.Per.Seconds(),
+		capacity: float64(capacity),
+		tokens:   float64(capacity),
+		last:     now,
+	}
+}
+
+// Tokens may go negative; each negative token is a reservation held by a waiting caller
+func (b *tokenBucket) reserve(ctx context.Context, now time.Time) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.capacity, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+
+	var wait time.Duration
+	if b.tokens < 1 {
+		wait = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && wait > 0 && now.Add(wait).After(deadline) {
+		return wait, false
+	}
+
+	b.tokens--
+	return wait, true
+}
+
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.capacity, b.tokens+1)
+}
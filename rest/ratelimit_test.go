@@ -0,0 +1,158 @@
+package rest
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+)
+
+func TestRateLimiterBurstThenWait(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{
+		Default: RateLimit{Requests: 2, Per: 100 * time.Millisecond},
+	})
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(context.Background(), "/path"); err != nil {
+			t.Fatalf("Unexpected error within burst: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("Expected burst to pass immediately, took %v", elapsed)
+	}
+
+	if err := limiter.Wait(context.Background(), "/path"); err != nil {
+		t.Fatalf("Unexpected error waiting for token: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Expected third request to wait for a token, took %v", elapsed)
+	}
+}
+
+func TestRateLimiterFailsFastOnDeadline(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{
+		Default: RateLimit{Requests: 1, Per: time.Second},
+	})
+
+	if err := limiter.Wait(context.Background(), "/path"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := limiter.Wait(ctx, "/path")
+	if !stderrors.Is(err, errors.ErrRateLimitExceeded) {
+		t.Fatalf("Expected ErrRateLimitExceeded, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("Expected fail fast, took %v", elapsed)
+	}
+}
+
+func TestRateLimiterCancelReleasesReservation(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{
+		Default: RateLimit{Requests: 1, Per: 200 * time.Millisecond},
+	})
+
+	if err := limiter.Wait(context.Background(), "/path"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	err := limiter.Wait(ctx, "/path")
+	if !stderrors.Is(err, errors.ErrRateLimitExceeded) || !stderrors.Is(err, context.Canceled) {
+		t.Fatalf("Expected rate limit error wrapping context.Canceled, got %v", err)
+	}
+
+	start := time.Now()
+	if err := limiter.Wait(context.Background(), "/path"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("Expected cancelled reservation to be released, waited %v", elapsed)
+	}
+}
+
+func TestRateLimiterPerEndpoint(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{
+		Endpoints: map[string]RateLimit{
+			"/limited": {Requests: 1, Per: time.Hour},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	for i := 0; i < 100; i++ {
+		if err := limiter.Wait(ctx, "/unlimited"); err != nil {
+			t.Fatalf("Expected unlimited endpoint to pass, got %v", err)
+		}
+	}
+
+	if err := limiter.Wait(ctx, "/limited"); err != nil {
+		t.Fatalf("Unexpected error for first limited request: %v", err)
+	}
+
+	if err := limiter.Wait(ctx, "/limited"); !stderrors.Is(err, errors.ErrRateLimitExceeded) {
+		t.Errorf("Expected ErrRateLimitExceeded for second limited request, got %v", err)
+	}
+}
+
+func TestClientWithRateLimits(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":0}`))
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, WithRateLimits(RateLimits{
+		Default: RateLimit{Requests: 5, Per: time.Hour},
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var limited atomic.Int32
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Get(ctx, "/test/path", nil); stderrors.Is(err, errors.ErrRateLimitExceeded) {
+				limited.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if requests.Load() != 5 {
+		t.Errorf("Expected 5 requests to reach the server, got %d", requests.Load())
+	}
+
+	if limited.Load() != 3 {
+		t.Errorf("Expected 3 rate limited requests, got %d", limited.Load())
+	}
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"context"
+	stderrors "errors"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+)
+
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+	Retryable      []error
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		Retryable: []error{
+			errors.ErrNetwork,
+			errors.ErrTimeout,
+			errors.ErrRateLimitExceeded,
+		},
+	}
+}
+
+func (p RetryPolicy) IsRetryable(err error) bool {
+	for _, target := range p.Retryable {
+		if stderrors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns the delay before the given retry, where attempt 1 is the first retry
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// Do runs fn until it succeeds, returns a non-retryable error, the attempts are
+// exhausted or the context is done. The last error from fn is returned.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	attempts := max(p.MaxAttempts, 1)
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil || !p.IsRetryable(err) || attempt == attempts {
+			return err
+		}
+
+		timer := time.NewTimer(p.Backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,185 @@
+package rest
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tradingiq/bitunix-client/errors"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, want := range expected {
+		if got := policy.Backoff(i + 1); got != want {
+			t.Errorf("Backoff(%d): expected %v, got %v", i+1, want, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		got := policy.Backoff(1)
+		if got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("Expected jittered backoff within 50ms-150ms, got %v", got)
+		}
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Retryable:      []error{errors.ErrNetwork},
+	}
+
+	t.Run("retries until success", func(t *testing.T) {
+		calls := 0
+		err := policy.Do(context.Background(), func() error {
+			calls++
+			if calls < 3 {
+				return errors.NewNetworkError("HTTP request", "connection reset", nil)
+			}
+			return nil
+		})
+		if err != nil || calls != 3 {
+			t.Errorf("Expected success after 3 calls, got %v after %d", err, calls)
+		}
+	})
+
+	t.Run("stops after max attempts", func(t *testing.T) {
+		calls := 0
+		err := policy.Do(context.Background(), func() error {
+			calls++
+			return errors.NewNetworkError("HTTP request", "connection reset", nil)
+		})
+		if !stderrors.Is(err, errors.ErrNetwork) || calls != 3 {
+			t.Errorf("Expected network error after 3 calls, got %v after %d", err, calls)
+		}
+	})
+
+	t.Run("does not retry non-retryable errors", func(t *testing.T) {
+		calls := 0
+		err := policy.Do(context.Background(), func() error {
+			calls++
+			return errors.NewValidationError("qty", "is required", nil)
+		})
+		if !stderrors.Is(err, errors.ErrValidation) || calls != 1 {
+			t.Errorf("Expected validation error after 1 call, got %v after %d", err, calls)
+		}
+	})
+
+	t.Run("stops when context is done", func(t *testing.T) {
+		slow := policy
+		slow.InitialBackoff = time.Hour
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		calls := 0
+		err := slow.Do(ctx, func() error {
+			calls++
+			return errors.NewNetworkError("HTTP request", "connection reset", nil)
+		})
+		if !stderrors.Is(err, errors.ErrNetwork) || calls != 1 {
+			t.Errorf("Expected network error after 1 call, got %v after %d", err, calls)
+		}
+	})
+}
+
+func TestClientGetRetriesMappedResponseErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if calls.Add(1) < 3 {
+			w.Write([]byte(`{"code":10005}`))
+			return
+		}
+		w.Write([]byte(`{"code":0}`))
+	}))
+	defer server.Close()
+
+	mapper := func(path string, body []byte) error {
+		if string(body) == `{"code":10005}` {
+			return errors.NewAPIError(10005, "rate limited", path, errors.ErrRateLimitExceeded)
+		}
+		return nil
+	}
+
+	client, err := New(server.URL,
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond, Retryable: []error{errors.ErrRateLimitExceeded}}),
+		WithResponseErrorMapper(mapper),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	body, err := client.Get(context.Background(), "/path", nil)
+	if err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+
+	if string(body) != `{"code":0}` || calls.Load() != 3 {
+		t.Errorf("Expected final body after 3 calls, got %s after %d", string(body), calls.Load())
+	}
+}
+
+func TestClientGetReturnsLastBodyWhenRetriesExhausted(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":10005}`))
+	}))
+	defer server.Close()
+
+	client, _ := New(server.URL,
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, Retryable: []error{errors.ErrRateLimitExceeded}}),
+		WithResponseErrorMapper(func(path string, body []byte) error {
+			return errors.NewAPIError(10005, "rate limited", path, errors.ErrRateLimitExceeded)
+		}),
+	)
+
+	body, err := client.Get(context.Background(), "/path", nil)
+	if err != nil {
+		t.Fatalf("Expected body to be returned for the caller to decode, got %v", err)
+	}
+
+	if string(body) != `{"code":10005}` || calls.Load() != 2 {
+		t.Errorf("Expected last body after 2 calls, got %s after %d", string(body), calls.Load())
+	}
+}
+
+func TestClientPostDoesNotRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"code":10005}`))
+	}))
+	defer server.Close()
+
+	client, _ := New(server.URL,
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Retryable: []error{errors.ErrRateLimitExceeded}}),
+		WithResponseErrorMapper(func(path string, body []byte) error {
+			return errors.NewAPIError(10005, "rate limited", path, errors.ErrRateLimitExceeded)
+		}),
+	)
+
+	if _, err := client.Post(context.Background(), "/path", nil, []byte(`{}`)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected POST to be sent once, got %d", calls.Load())
+	}
+}